@@ -54,6 +54,12 @@ var ciCmd = &cli.Command{
 			Value:   "bug",
 			Usage:   "Exit with non-zero code if there are problems with given severity (or higher) detected",
 		},
+		&cli.StringFlag{
+			Name:    formatFlag,
+			Aliases: []string{"f"},
+			Value:   string(reporter.ConsoleFormatText),
+			Usage:   "Console output format, one of: text, github, azure, teamcity",
+		},
 	},
 }
 
@@ -63,6 +69,11 @@ func actionCI(c *cli.Context) error {
 		return err
 	}
 
+	format, err := reporter.ParseConsoleFormat(c.String(formatFlag))
+	if err != nil {
+		return fmt.Errorf("invalid --%s value: %w", formatFlag, err)
+	}
+
 	includeRe := []*regexp.Regexp{}
 	for _, pattern := range meta.cfg.CI.Include {
 		includeRe = append(includeRe, regexp.MustCompile("^"+pattern+"$"))
@@ -113,7 +124,7 @@ func actionCI(c *cli.Context) error {
 	}
 
 	reps := []reporter.Reporter{
-		reporter.NewConsoleReporter(os.Stderr, checks.Information),
+		reporter.NewConsoleReporter(consoleOutput(format), checks.Information, format),
 	}
 
 	if meta.cfg.Repository != nil && meta.cfg.Repository.BitBucket != nil {
@@ -170,6 +181,35 @@ func actionCI(c *cli.Context) error {
 		reps = append(reps, gr)
 	}
 
+	if meta.cfg.Repository != nil && meta.cfg.Repository.GitLab != nil {
+		token, ok := os.LookupEnv("GITLAB_AUTH_TOKEN")
+		if !ok {
+			return fmt.Errorf("GITLAB_AUTH_TOKEN env variable is required when reporting to GitLab")
+		}
+
+		mrVal, ok := os.LookupEnv("CI_MERGE_REQUEST_IID")
+		if !ok {
+			return fmt.Errorf("CI_MERGE_REQUEST_IID env variable is required when reporting to GitLab")
+		}
+
+		var mrIID int
+		if mrIID, err = strconv.Atoi(mrVal); err != nil {
+			return fmt.Errorf("got not a valid number via CI_MERGE_REQUEST_IID: %w", err)
+		}
+
+		timeout, _ := time.ParseDuration(meta.cfg.Repository.GitLab.Timeout)
+		gl := reporter.NewGitLabReporter(
+			version,
+			meta.cfg.Repository.GitLab.URI,
+			timeout,
+			token,
+			meta.cfg.Repository.GitLab.Project,
+			mrIID,
+			git.RunGit,
+		)
+		reps = append(reps, gl)
+	}
+
 	minSeverity, err := checks.ParseSeverity(c.String(failOnFlag))
 	if err != nil {
 		return fmt.Errorf("invalid --%s value: %w", failOnFlag, err)
@@ -212,6 +252,12 @@ func detectCI(cfg *config.CI) *config.CI {
 		log.Debug().Str("branch", bb).Msg("got base branch from GITHUB_BASE_REF env variable")
 	}
 
+	if bb := os.Getenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME"); bb != "" {
+		isDirty = true
+		cfg.BaseBranch = bb
+		log.Debug().Str("branch", bb).Msg("got base branch from CI_MERGE_REQUEST_TARGET_BRANCH_NAME env variable")
+	}
+
 	if isNil && !isDirty {
 		return nil
 	}
@@ -231,6 +277,11 @@ func detectRepository(cfg *config.Repository) *config.Repository {
 		cfg.GitHub = detectGithubActions(cfg.GitHub)
 	}
 
+	if os.Getenv("GITLAB_CI") != "" && cfg.GitLab != nil {
+		isDirty = true
+		cfg.GitLab = detectGitLabCI(cfg.GitLab)
+	}
+
 	if isNil && !isDirty {
 		return nil
 	}
@@ -287,3 +338,19 @@ func detectGithubActions(gh *config.GitHub) *config.GitHub {
 	}
 	return gh
 }
+
+func detectGitLabCI(gl *config.GitLab) *config.GitLab {
+	if gl.URI == "" {
+		if uri := os.Getenv("CI_SERVER_URL"); uri != "" {
+			log.Info().Str("uri", uri).Msg("Setting repository URI from CI_SERVER_URL env variable")
+			gl.URI = uri
+		}
+	}
+	if gl.Project == "" {
+		if project := os.Getenv("CI_PROJECT_ID"); project != "" {
+			log.Info().Str("project", project).Msg("Setting repository project from CI_PROJECT_ID env variable")
+			gl.Project = project
+		}
+	}
+	return gl
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+// fixProblems applies all fixes suggested by checks to files on disk and
+// returns the number of fixes applied.
+// Problems with severity lower than minSeverity are not reported and so
+// they are not fixed either.
+func fixProblems(summary reporter.Summary, minSeverity checks.Severity) (fixed int, err error) {
+	edits := map[string][]checks.TextEdit{}
+	var paths []string
+	for _, report := range summary.Reports() {
+		if len(report.Problem.Fixes) == 0 || report.Problem.Severity < minSeverity {
+			continue
+		}
+		if _, ok := edits[report.SourcePath]; !ok {
+			paths = append(paths, report.SourcePath)
+		}
+		edits[report.SourcePath] = append(edits[report.SourcePath], report.Problem.Fixes...)
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fixed, err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fixed, err
+		}
+		out, applied := checks.ApplyTextEdits(content, edits[path])
+		if len(applied) == 0 {
+			continue
+		}
+		if err = os.WriteFile(path, out, info.Mode().Perm()); err != nil {
+			return fixed, fmt.Errorf("failed to write fixes to %s: %w", path, err)
+		}
+		log.Info().Str("path", path).Int("fixes", len(applied)).Msg("Applied fixes")
+		fixed += len(applied)
+	}
+
+	return fixed, nil
+}
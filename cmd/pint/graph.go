@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/graph"
+)
+
+const (
+	formatFlag = "format"
+
+	graphFormatDOT  = "dot"
+	graphFormatJSON = "json"
+)
+
+var graphCmd = &cli.Command{
+	Name:   "graph",
+	Usage:  "Print dependency graph of all rules in specified files",
+	Action: actionGraph,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    formatFlag,
+			Aliases: []string{"f"},
+			Value:   graphFormatDOT,
+			Usage:   "Output format, one of: dot, json",
+		},
+	},
+}
+
+func actionGraph(c *cli.Context) error {
+	meta, err := actionSetup(c)
+	if err != nil {
+		return err
+	}
+
+	paths := c.Args().Slice()
+	if len(paths) == 0 {
+		return fmt.Errorf("at least one file or directory required")
+	}
+
+	format := c.String(formatFlag)
+	if format != graphFormatDOT && format != graphFormatJSON {
+		return fmt.Errorf("invalid --%s value: %q, must be one of: %s, %s", formatFlag, format, graphFormatDOT, graphFormatJSON)
+	}
+
+	finder := discovery.NewGlobFinder(paths, meta.cfg.Parser.CompileRelaxed())
+	entries, err := finder.Find()
+	if err != nil {
+		return err
+	}
+
+	g := graph.New(entries)
+	if format == graphFormatJSON {
+		return g.WriteJSON(os.Stdout)
+	}
+	return g.WriteDOT(os.Stdout)
+}
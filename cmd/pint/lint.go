@@ -15,7 +15,13 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
-var requireOwnerFlag = "require-owner"
+var (
+	requireOwnerFlag = "require-owner"
+	fixFlag          = "fix"
+	baselineFlag     = "baseline"
+	baselineWrite    = "baseline-write"
+	formatTemplate   = "format-template"
+)
 
 var lintCmd = &cli.Command{
 	Name:   "lint",
@@ -40,6 +46,29 @@ var lintCmd = &cli.Command{
 			Value:   "bug",
 			Usage:   "Exit with non-zero code if there are problems with given severity (or higher) detected",
 		},
+		&cli.BoolFlag{
+			Name:  fixFlag,
+			Value: false,
+			Usage: "Automatically fix problems where possible, modified files are rewritten in place",
+		},
+		&cli.StringFlag{
+			Name:  baselineFlag,
+			Usage: "Path to a baseline file, problems listed there will not be reported",
+		},
+		&cli.StringFlag{
+			Name:  baselineWrite,
+			Usage: "Write all detected problems to given baseline file",
+		},
+		&cli.StringFlag{
+			Name:    formatFlag,
+			Aliases: []string{"f"},
+			Value:   string(reporter.ConsoleFormatText),
+			Usage:   "Console output format, one of: text, github, azure, teamcity",
+		},
+		&cli.PathFlag{
+			Name:  formatTemplate,
+			Usage: "Path to a Go text/template file used to print each problem, instead of the default console output",
+		},
 	},
 }
 
@@ -54,6 +83,36 @@ func actionLint(c *cli.Context) error {
 		return fmt.Errorf("at least one file or directory required")
 	}
 
+	minSeverity, err := checks.ParseSeverity(c.String(minSeverityFlag))
+	if err != nil {
+		return fmt.Errorf("invalid --%s value: %w", minSeverityFlag, err)
+	}
+	failOn, err := checks.ParseSeverity(c.String(failOnFlag))
+	if err != nil {
+		return fmt.Errorf("invalid --%s value: %w", failOnFlag, err)
+	}
+
+	format, err := reporter.ParseConsoleFormat(c.String(formatFlag))
+	if err != nil {
+		return fmt.Errorf("invalid --%s value: %w", formatFlag, err)
+	}
+
+	var r reporter.Reporter = reporter.NewConsoleReporter(consoleOutput(format), minSeverity, format)
+	if path := c.Path(formatTemplate); path != "" {
+		if c.IsSet(formatFlag) {
+			return fmt.Errorf("--%s and --%s flags cannot be used together", formatFlag, formatTemplate)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read --%s file: %w", formatTemplate, err)
+		}
+		tmpl, err := reporter.ParseTemplate(path, string(content))
+		if err != nil {
+			return fmt.Errorf("invalid --%s file: %w", formatTemplate, err)
+		}
+		r = reporter.NewTemplateReporter(os.Stdout, tmpl, minSeverity)
+	}
+
 	finder := discovery.NewGlobFinder(paths, meta.cfg.Parser.CompileRelaxed())
 	entries, err := finder.Find()
 	if err != nil {
@@ -68,20 +127,52 @@ func actionLint(c *cli.Context) error {
 	ctx := context.WithValue(context.Background(), config.CommandKey, config.LintCommand)
 	summary := checkRules(ctx, meta.workers, meta.cfg, entries)
 
+	if c.Bool(fixFlag) {
+		fixed, err := fixProblems(summary, minSeverity)
+		if err != nil {
+			return err
+		}
+		// Files were modified so we need to check them again to report
+		// any remaining problems with correct line numbers.
+		if fixed > 0 {
+			entries, err = finder.Find()
+			if err != nil {
+				return err
+			}
+			summary = checkRules(ctx, meta.workers, meta.cfg, entries)
+		}
+	}
+
 	if c.Bool(requireOwnerFlag) {
 		summary.Report(verifyOwners(entries, meta.cfg.Owners.CompileAllowed())...)
 	}
 
-	minSeverity, err := checks.ParseSeverity(c.String(minSeverityFlag))
-	if err != nil {
-		return fmt.Errorf("invalid --%s value: %w", minSeverityFlag, err)
+	if path := c.String(baselineWrite); path != "" {
+		baseline := reporter.NewBaseline(summary.Reports())
+		if err = baseline.Write(path); err != nil {
+			return fmt.Errorf("failed to write baseline file: %w", err)
+		}
+		log.Info().Str("path", path).Int("entries", len(baseline.Entries)).Msg("Baseline file written")
+		// All problems are now accepted by the baseline file.
+		return nil
 	}
-	failOn, err := checks.ParseSeverity(c.String(failOnFlag))
-	if err != nil {
-		return fmt.Errorf("invalid --%s value: %w", failOnFlag, err)
+
+	if path := c.String(baselineFlag); path != "" {
+		baseline, err := reporter.LoadBaseline(path)
+		if err != nil {
+			return fmt.Errorf("failed to load baseline file: %w", err)
+		}
+		for _, be := range baseline.Apply(&summary) {
+			log.Warn().
+				Str("path", be.Path).
+				Str("rule", be.Rule).
+				Str("reporter", be.Reporter).
+				Str("hash", be.Hash).
+				Int("count", be.Count).
+				Msg("Stale baseline entry, problem is no longer reported")
+		}
 	}
 
-	r := reporter.NewConsoleReporter(os.Stderr, minSeverity)
 	err = r.Submit(summary)
 	if err != nil {
 		return err
@@ -119,6 +210,15 @@ func actionLint(c *cli.Context) error {
 	return nil
 }
 
+// consoleOutput returns where console reporter should write to.
+// CI annotations are parsed from stdout, everything else goes to stderr.
+func consoleOutput(format reporter.ConsoleFormat) *os.File {
+	if format == reporter.ConsoleFormatText {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
 func report(summary reporter.Summary, reporters *config.Reporters) error {
 	if reporters != nil && reporters.JSON != nil {
 		if reporters.JSON != nil {
@@ -129,9 +229,56 @@ func report(summary reporter.Summary, reporters *config.Reporters) error {
 		}
 	}
 
+	if reporters != nil && reporters.SARIF != nil {
+		r := reporter.NewSARIFReporter(version, reporters.SARIF.Path)
+		if err := r.Submit(summary.Reports()); err != nil {
+			return err
+		}
+	}
+
+	if reporters != nil && reporters.JUnit != nil {
+		r := reporter.NewJUnitReporter(reporters.JUnit.Path)
+		if err := r.Submit(summary); err != nil {
+			return err
+		}
+	}
+
+	if reporters != nil && reporters.CodeQuality != nil {
+		r := reporter.NewCodeQualityReporter(reporters.CodeQuality.Path)
+		if err := r.Submit(summary.Reports()); err != nil {
+			return err
+		}
+	}
+
+	if reporters != nil && reporters.Checkstyle != nil {
+		r := reporter.NewCheckstyleReporter(reporters.Checkstyle.Path)
+		if err := r.Submit(summary.Reports()); err != nil {
+			return err
+		}
+	}
+
+	if reporters != nil && reporters.Template != nil {
+		if err := writeTemplateReport(summary, reporters.Template); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+func writeTemplateReport(summary reporter.Summary, settings *config.TemplateReporterSettings) error {
+	tmpl, err := reporter.ParseTemplate("template", settings.Template)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(settings.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return reporter.NewTemplateReporter(f, tmpl, checks.Information).Submit(summary)
+}
+
 func verifyOwners(entries []discovery.Entry, allowedOwners []*regexp.Regexp) (reports []reporter.Report) {
 	for _, entry := range entries {
 		if entry.State == discovery.Removed {
@@ -185,6 +185,126 @@ func TestJSONLintReporter(t *testing.T) {
 	require.FileExists(t, jsonFile)
 }
 
+func TestSARIFLintReporter(t *testing.T) {
+	var err error
+
+	rulesDir := t.TempDir()
+	err = mockRules(rulesDir, 1, 1)
+	require.NoError(t, err)
+	configPath := path.Join(rulesDir, ".pint.hcl")
+	err = mockConfig(configPath)
+	require.NoError(t, err)
+
+	sarifFile := path.Join(rulesDir, ".reporter.sarif")
+	content := fmt.Sprintf(`
+  parser {
+    relaxed = ["(.*)"]
+  }
+
+  reporters {
+    sarif {
+      path = "%s"
+    }
+  }
+  `, strings.ReplaceAll(sarifFile, `\`, `\\`))
+	err = os.WriteFile(configPath, []byte(content), 0o644)
+	require.NoError(t, err)
+	app := newApp()
+	err = app.Run([]string{"pint", "-c", configPath, "-l", "error", "--offline", "lint", rulesDir + "/*.yaml"})
+	require.NoError(t, err)
+	require.FileExists(t, sarifFile)
+}
+
+func TestJUnitLintReporter(t *testing.T) {
+	var err error
+
+	rulesDir := t.TempDir()
+	err = mockRules(rulesDir, 1, 1)
+	require.NoError(t, err)
+	configPath := path.Join(rulesDir, ".pint.hcl")
+	err = mockConfig(configPath)
+	require.NoError(t, err)
+
+	junitFile := path.Join(rulesDir, ".reporter.xml")
+	content := fmt.Sprintf(`
+  parser {
+    relaxed = ["(.*)"]
+  }
+
+  reporters {
+    junit {
+      path = "%s"
+    }
+  }
+  `, strings.ReplaceAll(junitFile, `\`, `\\`))
+	err = os.WriteFile(configPath, []byte(content), 0o644)
+	require.NoError(t, err)
+	app := newApp()
+	err = app.Run([]string{"pint", "-c", configPath, "-l", "error", "--offline", "lint", rulesDir + "/*.yaml"})
+	require.NoError(t, err)
+	require.FileExists(t, junitFile)
+}
+
+func TestCodeQualityLintReporter(t *testing.T) {
+	var err error
+
+	rulesDir := t.TempDir()
+	err = mockRules(rulesDir, 1, 1)
+	require.NoError(t, err)
+	configPath := path.Join(rulesDir, ".pint.hcl")
+	err = mockConfig(configPath)
+	require.NoError(t, err)
+
+	codeQualityFile := path.Join(rulesDir, ".reporter.codequality.json")
+	content := fmt.Sprintf(`
+  parser {
+    relaxed = ["(.*)"]
+  }
+
+  reporters {
+    codeQuality {
+      path = "%s"
+    }
+  }
+  `, strings.ReplaceAll(codeQualityFile, `\`, `\\`))
+	err = os.WriteFile(configPath, []byte(content), 0o644)
+	require.NoError(t, err)
+	app := newApp()
+	err = app.Run([]string{"pint", "-c", configPath, "-l", "error", "--offline", "lint", rulesDir + "/*.yaml"})
+	require.NoError(t, err)
+	require.FileExists(t, codeQualityFile)
+}
+
+func TestCheckstyleLintReporter(t *testing.T) {
+	var err error
+
+	rulesDir := t.TempDir()
+	err = mockRules(rulesDir, 1, 1)
+	require.NoError(t, err)
+	configPath := path.Join(rulesDir, ".pint.hcl")
+	err = mockConfig(configPath)
+	require.NoError(t, err)
+
+	checkstyleFile := path.Join(rulesDir, ".reporter.checkstyle.xml")
+	content := fmt.Sprintf(`
+  parser {
+    relaxed = ["(.*)"]
+  }
+
+  reporters {
+    checkstyle {
+      path = "%s"
+    }
+  }
+  `, strings.ReplaceAll(checkstyleFile, `\`, `\\`))
+	err = os.WriteFile(configPath, []byte(content), 0o644)
+	require.NoError(t, err)
+	app := newApp()
+	err = app.Run([]string{"pint", "-c", configPath, "-l", "error", "--offline", "lint", rulesDir + "/*.yaml"})
+	require.NoError(t, err)
+	require.FileExists(t, checkstyleFile)
+}
+
 func TestNoLintReporters(t *testing.T) {
 	var err error
 
@@ -0,0 +1,532 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/config"
+	"github.com/cloudflare/pint/internal/discovery"
+)
+
+const (
+	debounceFlag = "debounce"
+
+	lspParseError     = -32700
+	lspMethodNotFound = -32601
+	lspNotInitialized = -32002
+	lspInvalidRequest = -32600
+
+	lspSeverityError       = 1
+	lspSeverityWarning     = 2
+	lspSeverityInformation = 3
+
+	lspSyncFull = 1
+)
+
+var lspCmd = &cli.Command{
+	Name:   "lsp",
+	Usage:  "Run a Language Server Protocol server over stdin/stdout",
+	Action: actionLSP,
+	Flags: []cli.Flag{
+		&cli.DurationFlag{
+			Name:  debounceFlag,
+			Value: 0,
+			Usage: "Only run online checks once a document wasn't modified for this long, 0 - run all checks on every change",
+		},
+	},
+}
+
+func actionLSP(c *cli.Context) error {
+	meta, err := actionSetup(c)
+	if err != nil {
+		return err
+	}
+
+	for _, prom := range meta.cfg.PrometheusServers {
+		prom.StartWorkers()
+	}
+	defer meta.cleanup()
+
+	srv := newLSPServer(meta.cfg, meta.workers, c.Duration(debounceFlag), os.Stdout)
+	return srv.serve(os.Stdin)
+}
+
+type lspMessage struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+}
+
+type lspResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  any              `json:"result"`
+}
+
+type lspErrorResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Error   lspError         `json:"error"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspNotification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+type lspTextDocument struct {
+	URI     string `json:"uri"`
+	Version int    `json:"version"`
+	Text    string `json:"text"`
+}
+
+type lspTextDocumentParams struct {
+	TextDocument   lspTextDocument `json:"textDocument"`
+	Text           *string         `json:"text"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Code     string   `json:"code"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type lspPublishDiagnosticsParams struct {
+	URI         string          `json:"uri"`
+	Version     int             `json:"version"`
+	Diagnostics []lspDiagnostic `json:"diagnostics"`
+}
+
+type lspDocument struct {
+	path    string
+	version int
+	text    string
+	run     int
+	timer   *time.Timer
+}
+
+type lspServer struct {
+	cfg      config.Config
+	offline  config.Config
+	relaxed  []*regexp.Regexp
+	workers  int
+	debounce time.Duration
+
+	outLock sync.Mutex
+	out     io.Writer
+
+	mtx         sync.Mutex
+	docs        map[string]*lspDocument
+	initialized bool
+	shutdown    bool
+}
+
+func newLSPServer(cfg config.Config, workers int, debounce time.Duration, out io.Writer) *lspServer {
+	// Offline checks are run on every change, online checks only once the document
+	// wasn't modified for the debounce duration, so we need a copy of the config
+	// with all online checks disabled.
+	offline := cfg
+	if debounce > 0 {
+		offlineChecks := config.Checks{}
+		if cfg.Checks != nil {
+			offlineChecks = *cfg.Checks
+			offlineChecks.Disabled = append([]string{}, cfg.Checks.Disabled...)
+		}
+		offline.Checks = &offlineChecks
+		offline.DisableOnlineChecks()
+	}
+
+	return &lspServer{
+		cfg:      cfg,
+		offline:  offline,
+		relaxed:  cfg.Parser.CompileRelaxed(),
+		workers:  workers,
+		debounce: debounce,
+		out:      out,
+		docs:     map[string]*lspDocument{},
+	}
+}
+
+func (s *lspServer) serve(in io.Reader) error {
+	r := textproto.NewReader(bufio.NewReader(in))
+	for {
+		body, err := readLSPMessage(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return errors.New("connection closed before exit notification")
+			}
+			return err
+		}
+
+		var msg lspMessage
+		if err = json.Unmarshal(body, &msg); err != nil {
+			log.Error().Err(err).Msg("Failed to decode LSP message")
+			s.replyError(nil, lspParseError, err.Error())
+			continue
+		}
+
+		log.Debug().Str("method", msg.Method).Msg("Received LSP message")
+		if msg.Method == "exit" {
+			s.stopTimers()
+			if !s.isShutdown() {
+				return errors.New("exit notification received before shutdown request")
+			}
+			return nil
+		}
+		s.handle(msg)
+	}
+}
+
+func readLSPMessage(r *textproto.Reader) ([]byte, error) {
+	headers, err := r.ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(headers.Get("Content-Length"))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid Content-Length header: %q", headers.Get("Content-Length"))
+	}
+	body := make([]byte, length)
+	if _, err = io.ReadFull(r.R, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func (s *lspServer) handle(msg lspMessage) {
+	s.mtx.Lock()
+	initialized, shutdown := s.initialized, s.shutdown
+	s.mtx.Unlock()
+
+	if !initialized && msg.Method != "initialize" {
+		if msg.ID != nil {
+			s.replyError(msg.ID, lspNotInitialized, "server not initialized")
+		}
+		return
+	}
+	if shutdown && msg.ID != nil {
+		s.replyError(msg.ID, lspInvalidRequest, "server is shutting down")
+		return
+	}
+
+	switch msg.Method {
+	case "initialize":
+		s.mtx.Lock()
+		s.initialized = true
+		s.mtx.Unlock()
+		s.reply(msg.ID, map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync": map[string]any{
+					"openClose": true,
+					"change":    lspSyncFull,
+					"save":      map[string]any{"includeText": true},
+				},
+			},
+			"serverInfo": map[string]any{
+				"name":    "pint",
+				"version": version,
+			},
+		})
+	case "initialized":
+	case "shutdown":
+		s.stopTimers()
+		s.mtx.Lock()
+		s.shutdown = true
+		s.mtx.Unlock()
+		s.reply(msg.ID, nil)
+	case "textDocument/didOpen", "textDocument/didChange", "textDocument/didSave", "textDocument/didClose":
+		var params lspTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			log.Error().Err(err).Str("method", msg.Method).Msg("Failed to decode LSP message params")
+			return
+		}
+		s.updateDocument(msg.Method, params)
+	default:
+		if msg.ID != nil {
+			s.replyError(msg.ID, lspMethodNotFound, fmt.Sprintf("unsupported method: %s", msg.Method))
+		}
+	}
+}
+
+func (s *lspServer) updateDocument(method string, params lspTextDocumentParams) {
+	uri := params.TextDocument.URI
+
+	s.mtx.Lock()
+	doc, ok := s.docs[uri]
+	switch {
+	case method == "textDocument/didOpen":
+		if ok && doc.timer != nil {
+			doc.timer.Stop()
+		}
+		doc = &lspDocument{
+			path:    uriToPath(uri),
+			version: params.TextDocument.Version,
+			text:    params.TextDocument.Text,
+		}
+		s.docs[uri] = doc
+	case !ok:
+		s.mtx.Unlock()
+		log.Warn().Str("uri", uri).Str("method", method).Msg("Received LSP notification for a document that isn't open")
+		return
+	case method == "textDocument/didClose":
+		if doc.timer != nil {
+			doc.timer.Stop()
+		}
+		delete(s.docs, uri)
+		s.mtx.Unlock()
+		s.notify("textDocument/publishDiagnostics", lspPublishDiagnosticsParams{
+			URI:         uri,
+			Version:     doc.version,
+			Diagnostics: []lspDiagnostic{},
+		})
+		return
+	case method == "textDocument/didChange":
+		doc.version = params.TextDocument.Version
+		if n := len(params.ContentChanges); n > 0 {
+			doc.text = params.ContentChanges[n-1].Text
+		}
+	case method == "textDocument/didSave":
+		if params.Text != nil {
+			doc.text = *params.Text
+		}
+	}
+	if doc.timer != nil {
+		doc.timer.Stop()
+		doc.timer = nil
+	}
+	s.mtx.Unlock()
+
+	// All checks are run when document is opened or saved, on every other change
+	// we only run offline checks and schedule online ones.
+	if s.debounce <= 0 || method != "textDocument/didChange" {
+		s.checkDocument(uri, s.cfg)
+		return
+	}
+
+	s.checkDocument(uri, s.offline)
+	s.mtx.Lock()
+	doc.timer = time.AfterFunc(s.debounce, func() {
+		s.checkDocument(uri, s.cfg)
+	})
+	s.mtx.Unlock()
+}
+
+// checkDocument runs all checks enabled in cfg on a document and publishes
+// the results, unless the document was modified or closed in the meantime.
+func (s *lspServer) checkDocument(uri string, cfg config.Config) {
+	s.mtx.Lock()
+	doc, ok := s.docs[uri]
+	if !ok {
+		s.mtx.Unlock()
+		return
+	}
+	doc.run++
+	run, path, text, ver := doc.run, doc.path, doc.text, doc.version
+	s.mtx.Unlock()
+
+	go func() {
+		diagnostics := s.diagnostics(path, text, cfg)
+
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+		if doc, ok := s.docs[uri]; !ok || doc.run != run {
+			log.Debug().Str("uri", uri).Msg("Document changed while running checks, discarding results")
+			return
+		}
+		s.notify("textDocument/publishDiagnostics", lspPublishDiagnosticsParams{
+			URI:         uri,
+			Version:     ver,
+			Diagnostics: diagnostics,
+		})
+	}()
+}
+
+func (s *lspServer) diagnostics(path, text string, cfg config.Config) []lspDiagnostic {
+	diagnostics := []lspDiagnostic{}
+
+	isStrict := true
+	for _, re := range s.relaxed {
+		if re.MatchString(path) {
+			isStrict = false
+			break
+		}
+	}
+	entries, err := discovery.ReadRules(path, path, strings.NewReader(text), isStrict)
+	if err != nil {
+		log.Error().Err(err).Str("path", path).Msg("Failed to read rules")
+		return diagnostics
+	}
+
+	lines := strings.Split(text, "\n")
+	ctx := context.WithValue(context.Background(), config.CommandKey, config.LintCommand)
+	summary := checkRules(ctx, s.workers, cfg, entries)
+	for _, report := range summary.Reports() {
+		if report.Problem.Reporter == ignoreFileReporter {
+			continue
+		}
+		diagnostics = append(diagnostics, lspDiagnostic{
+			Range:    problemRange(lines, report.Problem),
+			Severity: lspSeverity(report.Problem.Severity),
+			Code:     report.Problem.Reporter,
+			Source:   "pint",
+			Message:  report.Problem.Text,
+		})
+	}
+	return diagnostics
+}
+
+func problemRange(lines []string, problem checks.Problem) (r lspRange) {
+	if len(problem.Lines) == 0 {
+		return r
+	}
+	if tr := problem.Range; tr != nil {
+		r.Start.Line = clampLine(tr.Line-1, len(lines))
+		r.Start.Character = utf16Column(lines[r.Start.Line], tr.Column)
+		r.End.Line = clampLine(tr.EndLine-1, len(lines))
+		r.End.Character = utf16Column(lines[r.End.Line], tr.EndColumn)
+		return r
+	}
+	first, last := problem.LineRange()
+	r.Start.Line = clampLine(first-1, len(lines))
+	r.End.Line = clampLine(last-1, len(lines))
+	r.End.Character = utf16Len(lines[r.End.Line])
+	return r
+}
+
+// utf16Column converts a 1-based byte column into a 0-based character
+// offset in UTF-16 code units.
+func utf16Column(line string, column int) int {
+	return utf16Len(line[:max(0, min(column-1, len(line)))])
+}
+
+func clampLine(line, count int) int {
+	if line >= count {
+		line = count - 1
+	}
+	if line < 0 {
+		line = 0
+	}
+	return line
+}
+
+// utf16Len returns the length of a line in UTF-16 code units, which is
+// the default position encoding used by LSP clients.
+func utf16Len(line string) (n int) {
+	for _, r := range strings.TrimSuffix(line, "\r") {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+func lspSeverity(s checks.Severity) int {
+	switch s {
+	case checks.Information:
+		return lspSeverityInformation
+	case checks.Warning:
+		return lspSeverityWarning
+	default:
+		return lspSeverityError
+	}
+}
+
+// uriToPath converts a document URI to a file path, relative to the current
+// working directory if possible, so it can be matched against paths in pint config.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	path := filepath.FromSlash(u.Path)
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return path
+}
+
+func (s *lspServer) isShutdown() bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.shutdown
+}
+
+func (s *lspServer) stopTimers() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, doc := range s.docs {
+		if doc.timer != nil {
+			doc.timer.Stop()
+			doc.timer = nil
+		}
+	}
+}
+
+func (s *lspServer) reply(id *json.RawMessage, result any) {
+	s.write(lspResponse{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *lspServer) replyError(id *json.RawMessage, code int, message string) {
+	s.write(lspErrorResponse{JSONRPC: "2.0", ID: id, Error: lspError{Code: code, Message: message}})
+}
+
+func (s *lspServer) notify(method string, params any) {
+	s.write(lspNotification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func (s *lspServer) write(msg any) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode LSP message")
+		return
+	}
+
+	s.outLock.Lock()
+	defer s.outLock.Unlock()
+	if _, err = fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		log.Error().Err(err).Msg("Failed to write LSP message")
+	}
+}
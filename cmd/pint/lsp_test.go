@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/config"
+)
+
+type lspTestClient struct {
+	t      *testing.T
+	in     *io.PipeWriter
+	out    *textproto.Reader
+	result chan error
+}
+
+func newLSPTestClient(t *testing.T, debounce time.Duration) *lspTestClient {
+	cfg, err := config.Load(path.Join(t.TempDir(), ".pint.hcl"), false)
+	require.NoError(t, err)
+	cfg.DisableOnlineChecks()
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	c := lspTestClient{
+		t:      t,
+		in:     inW,
+		out:    textproto.NewReader(bufio.NewReader(outR)),
+		result: make(chan error, 1),
+	}
+	srv := newLSPServer(cfg, 1, debounce, outW)
+	go func() {
+		c.result <- srv.serve(inR)
+		outW.Close()
+	}()
+	return &c
+}
+
+func (c *lspTestClient) send(msg string) {
+	_, err := fmt.Fprintf(c.in, "Content-Length: %d\r\n\r\n%s", len(msg), msg)
+	require.NoError(c.t, err)
+}
+
+func (c *lspTestClient) receive() string {
+	body, err := readLSPMessage(c.out)
+	require.NoError(c.t, err)
+	return string(body)
+}
+
+func (c *lspTestClient) wait() error {
+	c.in.Close()
+	select {
+	case err := <-c.result:
+		return err
+	case <-time.After(time.Second * 5):
+		c.t.Fatal("LSP server didn't stop")
+	}
+	return nil
+}
+
+func lspDidOpen(uri, text string) string {
+	body, _ := json.Marshal(text)
+	return fmt.Sprintf(`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":%q,"languageId":"yaml","version":1,"text":%s}}}`, uri, body)
+}
+
+func lspDidChange(uri string, version int, text string) string {
+	body, _ := json.Marshal(text)
+	return fmt.Sprintf(`{"jsonrpc":"2.0","method":"textDocument/didChange","params":{"textDocument":{"uri":%q,"version":%d},"contentChanges":[{"text":%s}]}}`, uri, version, body)
+}
+
+func TestLSPServer(t *testing.T) {
+	c := newLSPTestClient(t, 0)
+
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"textDocument/hover","params":{}}`)
+	require.Equal(t, `{"jsonrpc":"2.0","id":1,"error":{"code":-32002,"message":"server not initialized"}}`, c.receive())
+
+	c.send(`{"jsonrpc":"2.0","id":2,"method":"initialize","params":{"capabilities":{}}}`)
+	require.Equal(t, `{"jsonrpc":"2.0","id":2,"result":{"capabilities":{"textDocumentSync":{"change":1,"openClose":true,"save":{"includeText":true}}},"serverInfo":{"name":"pint","version":"unknown"}}}`, c.receive())
+	c.send(`{"jsonrpc":"2.0","method":"initialized","params":{}}`)
+
+	c.send(`{"jsonrpc":"2.0","id":3,"method":"textDocument/hover","params":{}}`)
+	require.Equal(t, `{"jsonrpc":"2.0","id":3,"error":{"code":-32601,"message":"unsupported method: textDocument/hover"}}`, c.receive())
+
+	c.send(lspDidOpen("file:///rules/foo.yaml", "groups:\n- name: foo\n  rules:\n  - record: foo\n    expr: sum(foo) without(\n"))
+	require.Equal(t, `{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics","params":{"uri":"file:///rules/foo.yaml","version":1,"diagnostics":[{"range":{"start":{"line":4,"character":27},"end":{"line":4,"character":27}},"severity":1,"code":"promql/syntax","source":"pint","message":"syntax error: unclosed left parenthesis"}]}}`, c.receive())
+
+	c.send(lspDidChange("file:///rules/foo.yaml", 2, "groups:\n- name: foo\n  rules:\n  - record: foo\n    expr: sum(bar) without(job)\n"))
+	require.Equal(t, `{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics","params":{"uri":"file:///rules/foo.yaml","version":2,"diagnostics":[]}}`, c.receive())
+
+	c.send(lspDidChange("file:///rules/foo.yaml", 3, "groups:\n- name: foo\n  rules:\n  - alert: foo\n    expr: up == 0\n    for: 0s\n"))
+	require.Equal(t, `{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics","params":{"uri":"file:///rules/foo.yaml","version":3,"diagnostics":[{"range":{"start":{"line":5,"character":0},"end":{"line":5,"character":11}},"severity":3,"code":"alerts/for","source":"pint","message":"\"0s\" is the default value of \"for\", consider removing this line"}]}}`, c.receive())
+
+	c.send(`{"jsonrpc":"2.0","method":"textDocument/didClose","params":{"textDocument":{"uri":"file:///rules/foo.yaml"}}}`)
+	require.Equal(t, `{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics","params":{"uri":"file:///rules/foo.yaml","version":3,"diagnostics":[]}}`, c.receive())
+
+	c.send(`{"jsonrpc":"2.0","id":4,"method":"shutdown"}`)
+	require.Equal(t, `{"jsonrpc":"2.0","id":4,"result":null}`, c.receive())
+	c.send(`{"jsonrpc":"2.0","method":"exit"}`)
+	require.NoError(t, c.wait())
+}
+
+func TestLSPServerDebounce(t *testing.T) {
+	c := newLSPTestClient(t, time.Millisecond*500)
+
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"capabilities":{}}}`)
+	c.receive()
+
+	c.send(lspDidOpen("file:///rules/foo.yaml", "groups:\n- name: foo\n  rules:\n  - record: foo\n    expr: sum(bar) without(job)\n"))
+	require.Equal(t, `{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics","params":{"uri":"file:///rules/foo.yaml","version":1,"diagnostics":[]}}`, c.receive())
+
+	// Offline checks are run right away, all checks once the document wasn't modified.
+	c.send(lspDidChange("file:///rules/foo.yaml", 2, "groups:\n- name: foo\n  rules:\n  - record: foo\n    expr: sum(foo) without(\n"))
+	expected := `{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics","params":{"uri":"file:///rules/foo.yaml","version":2,"diagnostics":[{"range":{"start":{"line":4,"character":27},"end":{"line":4,"character":27}},"severity":1,"code":"promql/syntax","source":"pint","message":"syntax error: unclosed left parenthesis"}]}}`
+	require.Equal(t, expected, c.receive())
+	require.Equal(t, expected, c.receive())
+
+	c.send(`{"jsonrpc":"2.0","method":"exit"}`)
+	require.EqualError(t, c.wait(), "exit notification received before shutdown request")
+}
+
+func TestLSPServerClosed(t *testing.T) {
+	c := newLSPTestClient(t, 0)
+	require.EqualError(t, c.wait(), "connection closed before exit notification")
+}
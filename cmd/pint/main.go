@@ -74,6 +74,9 @@ func newApp() *cli.App {
 			watchCmd,
 			configCmd,
 			parseCmd,
+			lspCmd,
+			graphCmd,
+			testCmd,
 		},
 	}
 }
@@ -106,18 +109,26 @@ func actionSetup(c *cli.Context) (meta actionMeta, err error) {
 		return meta, fmt.Errorf("--%s flag must be > 0", workersFlag)
 	}
 
-	meta.cfg, err = config.Load(c.Path(configFlag), c.IsSet(configFlag))
+	meta.cfg, err = loadConfig(c)
 	if err != nil {
-		return meta, fmt.Errorf("failed to load config file %q: %w", c.Path(configFlag), err)
-	}
-	meta.cfg.SetDisabledChecks(c.StringSlice(disabledFlag))
-	if c.Bool(offlineFlag) {
-		meta.cfg.DisableOnlineChecks()
+		return meta, err
 	}
 
 	return meta, nil
 }
 
+func loadConfig(c *cli.Context) (cfg config.Config, err error) {
+	cfg, err = config.Load(c.Path(configFlag), c.IsSet(configFlag))
+	if err != nil {
+		return cfg, fmt.Errorf("failed to load config file %q: %w", c.Path(configFlag), err)
+	}
+	cfg.SetDisabledChecks(c.StringSlice(disabledFlag))
+	if c.Bool(offlineFlag) {
+		cfg.DisableOnlineChecks()
+	}
+	return cfg, nil
+}
+
 func main() {
 	app := newApp()
 	err := app.Run(os.Args)
@@ -54,4 +54,16 @@ var (
 		},
 		[]string{"kind"},
 	)
+	configLastReloadSuccessful = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "pint_config_last_reload_successful",
+			Help: "Whether the last configuration reload attempt was successful",
+		},
+	)
+	configLastReloadSuccessTime = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "pint_config_last_reload_success_timestamp_seconds",
+			Help: "Timestamp of the last successful configuration reload",
+		},
+	)
 )
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
 	"sync"
@@ -11,11 +12,14 @@ import (
 	"github.com/prometheus/prometheus/model/rulefmt"
 	"github.com/rs/zerolog/log"
 	"go.uber.org/atomic"
+	"golang.org/x/exp/slices"
 
 	"github.com/cloudflare/pint/internal/checks"
 	"github.com/cloudflare/pint/internal/config"
 	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/graph"
 	"github.com/cloudflare/pint/internal/output"
+	"github.com/cloudflare/pint/internal/parser"
 	"github.com/cloudflare/pint/internal/reporter"
 )
 
@@ -72,6 +76,8 @@ func checkRules(ctx context.Context, workers int, cfg config.Config, entries []d
 		key := checks.SettingsKey(s.Name)
 		ctx = context.WithValue(ctx, key, settings)
 	}
+	// Rule dependency graph is built once and shared by all checks using it.
+	ctx = graph.NewContext(ctx, entries)
 
 	for w := 1; w <= workers; w++ {
 		wg.Add(1)
@@ -136,6 +142,18 @@ func checkRules(ctx context.Context, workers int, cfg config.Config, entries []d
 	for result := range results {
 		summary.Report(result)
 	}
+	summary.Report(expiredSnoozes(entries)...)
+	for _, entry := range entries {
+		if entry.State == discovery.Removed || entry.PathError != nil {
+			continue
+		}
+		summary.AddRules(reporter.CheckedRule{
+			ReportedPath: entry.ReportedPath,
+			SourcePath:   entry.SourcePath,
+			Rule:         entry.Rule,
+			Owner:        entry.Owner,
+		})
+	}
 	summary.Duration = time.Since(start)
 	summary.Entries = len(entries)
 	summary.OnlineChecks = onlineChecksCount.Load()
@@ -146,6 +164,39 @@ func checkRules(ctx context.Context, workers int, cfg config.Config, entries []d
 	return summary
 }
 
+// expiredSnoozes reports all rule snooze comments with a timestamp in the past,
+// so they can be removed. Problems are reported on the line of the comment itself.
+func expiredSnoozes(entries []discovery.Entry) (reports []reporter.Report) {
+	for _, entry := range entries {
+		if entry.State == discovery.Removed || entry.PathError != nil || entry.Rule.Error.Err != nil {
+			continue
+		}
+		for _, comment := range entry.Comments {
+			if comment.Key != "snooze" && comment.Key != discovery.RuleSnoozeCheckComment {
+				continue
+			}
+			s := parser.ParseExpiredSnooze(comment.Value)
+			if s == nil {
+				continue
+			}
+			reports = append(reports, reporter.Report{
+				ReportedPath:  entry.ReportedPath,
+				SourcePath:    entry.SourcePath,
+				ModifiedLines: withCommentLines(entry.ModifiedLines, entry.Comments),
+				Rule:          entry.Rule,
+				Problem: checks.Problem{
+					Lines:    []int{comment.Line},
+					Reporter: discovery.RuleSnoozeCheckComment,
+					Text:     fmt.Sprintf("%q comment expired on %s and no longer has any effect, it can be removed", "# pint "+comment.String(), s.Until.Format(time.RFC3339)),
+					Severity: checks.Information,
+				},
+				Owner: entry.Owner,
+			})
+		}
+	}
+	return reports
+}
+
 type scanJob struct {
 	allEntries []discovery.Entry
 	entry      discovery.Entry
@@ -209,11 +260,17 @@ func scanWorker(ctx context.Context, jobs <-chan scanJob, results chan<- reporte
 				start := time.Now()
 				problems := job.check.Check(ctx, job.entry.ReportedPath, job.entry.Rule, job.allEntries)
 				checkDuration.WithLabelValues(job.check.Reporter()).Observe(time.Since(start).Seconds())
+				modifiedLines := job.entry.ModifiedLines
+				if job.check.Reporter() == checks.CommentsCheckName {
+					// Comments can be placed outside of the rule they belong to,
+					// make sure that problems reported on them are not hidden.
+					modifiedLines = withCommentLines(modifiedLines, job.entry.Comments)
+				}
 				for _, problem := range problems {
 					results <- reporter.Report{
 						ReportedPath:  job.entry.ReportedPath,
 						SourcePath:    job.entry.SourcePath,
-						ModifiedLines: job.entry.ModifiedLines,
+						ModifiedLines: modifiedLines,
 						Rule:          job.entry.Rule,
 						Problem:       problem,
 						Owner:         job.entry.Owner,
@@ -226,6 +283,17 @@ func scanWorker(ctx context.Context, jobs <-chan scanJob, results chan<- reporte
 	}
 }
 
+func withCommentLines(lines []int, comments []parser.ControlComment) []int {
+	merged := slices.Clone(lines)
+	for _, cmt := range comments {
+		if !slices.Contains(merged, cmt.Line) {
+			merged = append(merged, cmt.Line)
+		}
+	}
+	slices.Sort(merged)
+	return merged
+}
+
 func submitReports(reps []reporter.Reporter, summary reporter.Summary) (err error) {
 	for _, rep := range reps {
 		err = rep.Submit(summary)
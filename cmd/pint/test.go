@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/exp/slices"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/reporter"
+	"github.com/cloudflare/pint/internal/ruletest"
+)
+
+var requireCoverageFlag = "require-coverage"
+
+var testCmd = &cli.Command{
+	Name:   "test",
+	Usage:  "Run rule unit tests from specified files",
+	Action: actionTest,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  requireCoverageFlag,
+			Value: false,
+			Usage: "Report all alerting rules that are not tested by any of the unit test files",
+		},
+	},
+}
+
+func actionTest(c *cli.Context) error {
+	meta, err := actionSetup(c)
+	if err != nil {
+		return err
+	}
+
+	paths := c.Args().Slice()
+	if len(paths) == 0 {
+		return fmt.Errorf("at least one unit test file required")
+	}
+
+	summary := reporter.NewSummary(nil)
+	files := make([]*ruletest.File, 0, len(paths))
+	var ruleFiles []string
+	var tests int
+	for _, path := range paths {
+		f, err := ruletest.Load(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+
+		entries, err := discovery.NewGlobFinder(f.RuleFiles, meta.cfg.Parser.CompileRelaxed()).Find()
+		if err != nil {
+			return err
+		}
+
+		log.Info().Str("path", path).Strs("rules", f.RuleFiles).Int("groups", len(f.Tests)).Msg("Running unit tests")
+		reports, err := ruletest.Run(f, entries)
+		if err != nil {
+			return err
+		}
+		summary.Report(reports...)
+
+		for _, tg := range f.Tests {
+			tests += len(tg.AlertRuleTests) + len(tg.PromQLExprTests)
+		}
+		for _, rf := range f.RuleFiles {
+			if !slices.Contains(ruleFiles, rf) {
+				ruleFiles = append(ruleFiles, rf)
+			}
+		}
+	}
+
+	if c.Bool(requireCoverageFlag) && len(ruleFiles) > 0 {
+		entries, err := discovery.NewGlobFinder(ruleFiles, meta.cfg.Parser.CompileRelaxed()).Find()
+		if err != nil {
+			return err
+		}
+		summary.Report(ruletest.Untested(files, entries)...)
+	}
+
+	r := reporter.NewConsoleReporter(os.Stderr, checks.Information, reporter.ConsoleFormatText)
+	if err = r.Submit(summary); err != nil {
+		return err
+	}
+
+	problems := len(summary.Reports())
+	log.Info().Int("tests", tests).Int("problems", problems).Msg("Unit tests completed")
+	if problems > 0 {
+		return fmt.Errorf("found %d problem(s) in unit tests", problems)
+	}
+	return nil
+}
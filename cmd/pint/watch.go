@@ -8,6 +8,7 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"os/signal"
+	"reflect"
 	"sort"
 	"strings"
 	"sync"
@@ -25,6 +26,7 @@ import (
 	dto "github.com/prometheus/client_model/go"
 	"github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -50,7 +52,7 @@ var watchCmd = &cli.Command{
 			Name:    listenFlag,
 			Aliases: []string{"s"},
 			Value:   ":8080",
-			Usage:   "Listen address for HTTP web server exposing metrics",
+			Usage:   "Listen address for HTTP web server exposing metrics, API and web UI",
 		},
 		&cli.StringFlag{
 			Name:    pidfileFlag,
@@ -107,6 +109,7 @@ func actionWatch(c *cli.Context) error {
 
 	// start HTTP server for metrics
 	collector := newProblemCollector(meta.cfg, paths, minSeverity, c.Int(maxProblemsFlag))
+	collector.loadConfig = func() (config.Config, error) { return loadConfig(c) }
 	// register all metrics
 	prometheus.MustRegister(collector)
 	prometheus.MustRegister(checkDuration)
@@ -117,6 +120,8 @@ func actionWatch(c *cli.Context) error {
 	prometheus.MustRegister(lastRunTime)
 	prometheus.MustRegister(lastRunDuration)
 	prometheus.MustRegister(rulesParsedTotal)
+	prometheus.MustRegister(configLastReloadSuccessful)
+	prometheus.MustRegister(configLastReloadSuccessTime)
 	promapi.RegisterMetrics()
 
 	// init metrics if needed
@@ -124,8 +129,12 @@ func actionWatch(c *cli.Context) error {
 	rulesParsedTotal.WithLabelValues(config.AlertingRuleType).Add(0)
 	rulesParsedTotal.WithLabelValues(config.RecordingRuleType).Add(0)
 	rulesParsedTotal.WithLabelValues(config.InvalidRuleType).Add(0)
+	configLastReloadSuccessful.Set(1)
+	configLastReloadSuccessTime.SetToCurrentTime()
 
 	http.Handle("/metrics", promhttp.Handler())
+	collector.registerHandlers(http.DefaultServeMux)
+	http.HandleFunc("/-/reload", collector.handleReload)
 	listen := c.String(listenFlag)
 	server := http.Server{
 		Addr:         listen,
@@ -150,18 +159,30 @@ func actionWatch(c *cli.Context) error {
 	mainCtx, mainCancel := context.WithCancel(context.WithValue(context.Background(), config.CommandKey, config.WatchCommand))
 	stop := startTimer(mainCtx, meta.cfg, meta.workers, interval, ack, collector)
 
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			log.Info().Msg("Received SIGHUP, reloading configuration")
+			if reloadErr := collector.requestReload(mainCtx); reloadErr != nil {
+				log.Error().Err(reloadErr).Msg("Failed to reload configuration")
+			}
+		}
+	}()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Info().Msg("Shutting down")
+	signal.Stop(hup)
 	mainCancel()
 
 	stop <- true
 	log.Info().Msg("Waiting for all background tasks to finish")
 	<-ack
 
-	for _, prom := range meta.cfg.PrometheusServers {
+	for _, prom := range collector.config().PrometheusServers {
 		prom.Close()
 	}
 
@@ -192,6 +213,14 @@ func startTimer(ctx context.Context, _ config.Config, workers int, interval time
 					log.Error().Err(err).Msg("Got an error when running checks")
 				}
 				checkIterationsTotal.Inc()
+			case errc := <-collector.reload:
+				err := collector.reloadConfig()
+				errc <- err
+				if err == nil {
+					// Run all checks again using the new configuration.
+					ticker.Reset(time.Second)
+					wasBootstrapped = false
+				}
 			case <-stop:
 				ticker.Stop()
 				log.Info().Msg("Background worker finished")
@@ -208,9 +237,14 @@ func startTimer(ctx context.Context, _ config.Config, workers int, interval time
 type problemCollector struct {
 	lock             sync.Mutex
 	cfg              config.Config
+	loadConfig       func() (config.Config, error)
+	reload           chan chan error
 	paths            []string
 	fileOwners       map[string]string
 	summary          *reporter.Summary
+	rules            []apiRule
+	checks           []apiCheck
+	lastRun          time.Time
 	problem          *prometheus.Desc
 	problems         *prometheus.Desc
 	fileOwnersMetric *prometheus.Desc
@@ -221,6 +255,7 @@ type problemCollector struct {
 func newProblemCollector(cfg config.Config, paths []string, minSeverity checks.Severity, maxProblems int) *problemCollector {
 	return &problemCollector{
 		cfg:        cfg,
+		reload:     make(chan chan error),
 		paths:      paths,
 		fileOwners: map[string]string{},
 		problem: prometheus.NewDesc(
@@ -246,20 +281,110 @@ func newProblemCollector(cfg config.Config, paths []string, minSeverity checks.S
 	}
 }
 
+func (c *problemCollector) config() config.Config {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.cfg
+}
+
+// requestReload asks the background worker to reload the configuration
+// and waits for the result.
+func (c *problemCollector) requestReload(ctx context.Context) error {
+	errc := make(chan error, 1)
+	select {
+	case c.reload <- errc:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reloadConfig loads the configuration file again and replaces the config
+// used for running checks. Prometheus servers with unchanged configuration
+// are kept, so their query cache survives the reload.
+// It must not be called while checks are running, since it will close
+// all Prometheus servers that were removed or modified.
+func (c *problemCollector) reloadConfig() error {
+	cfg, err := c.loadConfig()
+	if err != nil {
+		configLastReloadSuccessful.Set(0)
+		return err
+	}
+
+	old := c.config()
+	var (
+		keep    []*promapi.FailoverGroup
+		started []*promapi.FailoverGroup
+	)
+	for i, prom := range cfg.Prometheus {
+		if j := slices.IndexFunc(old.Prometheus, func(p config.PrometheusConfig) bool {
+			return p.Name == prom.Name
+		}); j >= 0 && reflect.DeepEqual(old.Prometheus[j], prom) && reflect.DeepEqual(old.Cache, cfg.Cache) {
+			cfg.PrometheusServers[i] = old.PrometheusServers[j]
+			keep = append(keep, old.PrometheusServers[j])
+			continue
+		}
+		started = append(started, cfg.PrometheusServers[i])
+	}
+
+	for _, prom := range old.PrometheusServers {
+		if !slices.Contains(keep, prom) {
+			log.Info().Str("name", prom.Name()).Msg("Stopping removed or modified Prometheus server")
+			prom.Close()
+		}
+	}
+	for _, prom := range started {
+		log.Info().Str("name", prom.Name()).Msg("Starting new or modified Prometheus server")
+		prom.StartWorkers()
+	}
+
+	c.lock.Lock()
+	c.cfg = cfg
+	c.lock.Unlock()
+
+	configLastReloadSuccessful.Set(1)
+	configLastReloadSuccessTime.SetToCurrentTime()
+	log.Info().Msg("Configuration reloaded")
+	return nil
+}
+
+func (c *problemCollector) handleReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		w.Header().Set("Allow", "POST, PUT")
+		http.Error(w, "Only POST or PUT requests allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := c.requestReload(r.Context()); err != nil {
+		log.Error().Err(err).Msg("Failed to reload configuration")
+		http.Error(w, fmt.Sprintf("failed to reload config: %s", err), http.StatusInternalServerError)
+	}
+}
+
 func (c *problemCollector) scan(ctx context.Context, workers int) error {
-	finder := discovery.NewGlobFinder(c.paths, c.cfg.Parser.CompileRelaxed())
+	cfg := c.config()
+	finder := discovery.NewGlobFinder(c.paths, cfg.Parser.CompileRelaxed())
 	// nolint: contextcheck
 	entries, err := finder.Find()
 	if err != nil {
 		return err
 	}
 
-	s := checkRules(ctx, workers, c.cfg, entries)
+	s := checkRules(ctx, workers, cfg, entries)
+	rules := newAPIRules(entries, s.Reports())
+	checkList := newAPIChecks(ctx, cfg, entries, s.Reports())
 
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	c.summary = &s
+	c.rules = rules
+	c.checks = checkList
+	c.lastRun = time.Now()
 
 	fileOwners := map[string]string{}
 	for _, entry := range entries {
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/config"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/output"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+const (
+	groupByFile     = "file"
+	groupByOwner    = "owner"
+	groupBySeverity = "severity"
+)
+
+//go:embed watch.html
+var webFS embed.FS
+
+var webTemplate = template.Must(template.New("watch.html").Funcs(template.FuncMap{
+	"docs":     reporter.CheckDocsURL,
+	"lines":    output.FormatLineRangeString,
+	"severity": func(s checks.Severity) string { return strings.ToLower(s.String()) },
+}).ParseFS(webFS, "watch.html"))
+
+type apiProblemsResponse struct {
+	LastRun  *time.Time            `json:"lastRun"`
+	Problems []reporter.JSONReport `json:"problems"`
+}
+
+type apiRulesResponse struct {
+	LastRun *time.Time `json:"lastRun"`
+	Rules   []apiRule  `json:"rules"`
+}
+
+type apiChecksResponse struct {
+	LastRun *time.Time `json:"lastRun"`
+	Checks  []apiCheck `json:"checks"`
+}
+
+type apiRule struct {
+	ReportedPath string `json:"reportedPath"`
+	SourcePath   string `json:"sourcePath"`
+	Group        string `json:"group,omitempty"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Owner        string `json:"owner"`
+	Lines        []int  `json:"lines"`
+	Content      string `json:"content"`
+	Problems     int    `json:"problems"`
+}
+
+type apiCheck struct {
+	Name     string `json:"name"`
+	Online   bool   `json:"online"`
+	Rules    int    `json:"rules"`
+	Problems int    `json:"problems"`
+	Docs     string `json:"docs"`
+}
+
+type webProblem struct {
+	Report  reporter.Report
+	Content string
+}
+
+type webGroup struct {
+	Name     string
+	Problems []webProblem
+}
+
+type webPage struct {
+	LastRun  *time.Time
+	GroupBy  string
+	GroupBys []string
+	Rules    int
+	Problems int
+	Groups   []webGroup
+}
+
+// newAPIRules returns the list of all rules found during last scan, together
+// with the number of problems reported for each of them.
+func newAPIRules(entries []discovery.Entry, reports []reporter.Report) []apiRule {
+	files := map[string][]string{}
+	rules := make([]apiRule, 0, len(entries))
+	for _, entry := range entries {
+		if entry.PathError != nil || entry.State == discovery.Removed {
+			continue
+		}
+		var group string
+		if entry.Rule.Group != nil {
+			group = entry.Rule.Group.GetName()
+		}
+		rule := apiRule{
+			ReportedPath: entry.ReportedPath,
+			SourcePath:   entry.SourcePath,
+			Group:        group,
+			Name:         entry.Rule.Name(),
+			Type:         string(entry.Rule.Type()),
+			Owner:        entry.Owner,
+			Lines:        entry.Rule.Lines(),
+			Content:      readLines(files, entry.SourcePath, entry.Rule.Lines()),
+		}
+		for _, report := range reports {
+			if report.SourcePath == entry.SourcePath && slices.Equal(report.Rule.Lines(), rule.Lines) {
+				rule.Problems++
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// newAPIChecks returns the list of all checks, together with the number
+// of rules each check was run on and the number of problems it reported.
+func newAPIChecks(ctx context.Context, cfg config.Config, entries []discovery.Entry, reports []reporter.Report) []apiCheck {
+	rules := map[string]int{}
+	for _, entry := range entries {
+		if entry.PathError != nil || entry.State == discovery.Removed || entry.Rule.Error.Err != nil {
+			continue
+		}
+		for _, check := range cfg.GetChecksForRule(ctx, entry.SourcePath, entry.Rule, entry.DisabledChecks) {
+			rules[check.Reporter()]++
+		}
+	}
+
+	problems := map[string]int{}
+	for _, report := range reports {
+		problems[report.Problem.Reporter]++
+	}
+
+	names := make([]string, len(checks.CheckNames))
+	copy(names, checks.CheckNames)
+	sort.Strings(names)
+
+	list := make([]apiCheck, 0, len(names))
+	for _, name := range names {
+		list = append(list, apiCheck{
+			Name:     name,
+			Online:   slices.Contains(checks.OnlineChecks, name),
+			Rules:    rules[name],
+			Problems: problems[name],
+			Docs:     reporter.CheckDocsURL(name),
+		})
+	}
+	return list
+}
+
+// readLines returns the content of given lines from a file, files are
+// read only once and stored in the cache map.
+func readLines(cache map[string][]string, path string, lines []int) string {
+	content, ok := cache[path]
+	if !ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Debug().Err(err).Str("path", path).Msg("Failed to read rule file")
+		}
+		content = strings.Split(string(data), "\n")
+		cache[path] = content
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	first, last := firstLine(lines), lines[0]
+	for _, line := range lines {
+		last = max(last, line)
+	}
+	if first < 1 || last > len(content) {
+		return ""
+	}
+	return strings.Join(content[first-1:last], "\n")
+}
+
+func (c *problemCollector) registerHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/api/v1/problems", c.handleProblems)
+	mux.HandleFunc("/api/v1/rules", c.handleRules)
+	mux.HandleFunc("/api/v1/checks", c.handleChecks)
+	mux.HandleFunc("/", c.handleIndex)
+}
+
+func (c *problemCollector) handleProblems(w http.ResponseWriter, _ *http.Request) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	resp := apiProblemsResponse{LastRun: c.lastRunTime(), Problems: []reporter.JSONReport{}}
+	if c.summary != nil {
+		for _, report := range c.summary.Reports() {
+			resp.Problems = append(resp.Problems, reporter.NewJSONReport(report))
+		}
+	}
+	writeJSON(w, resp)
+}
+
+func (c *problemCollector) handleRules(w http.ResponseWriter, _ *http.Request) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	resp := apiRulesResponse{LastRun: c.lastRunTime(), Rules: []apiRule{}}
+	if c.rules != nil {
+		resp.Rules = c.rules
+	}
+	writeJSON(w, resp)
+}
+
+func (c *problemCollector) handleChecks(w http.ResponseWriter, _ *http.Request) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	resp := apiChecksResponse{LastRun: c.lastRunTime(), Checks: []apiCheck{}}
+	if c.checks != nil {
+		resp.Checks = c.checks
+	}
+	writeJSON(w, resp)
+}
+
+func (c *problemCollector) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	groupBy := r.URL.Query().Get("group")
+	switch groupBy {
+	case "":
+		groupBy = groupByFile
+	case groupByFile, groupByOwner, groupBySeverity:
+	default:
+		http.Error(w, fmt.Sprintf("invalid group value: %q", groupBy), http.StatusBadRequest)
+		return
+	}
+
+	c.lock.Lock()
+	page := webPage{
+		LastRun:  c.lastRunTime(),
+		GroupBy:  groupBy,
+		GroupBys: []string{groupByFile, groupByOwner, groupBySeverity},
+		Rules:    len(c.rules),
+	}
+	if c.summary != nil {
+		page.Groups = groupReports(c.summary.Reports(), c.rules, groupBy)
+		page.Problems = len(c.summary.Reports())
+	}
+	c.lock.Unlock()
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := webTemplate.Execute(w, page); err != nil {
+		log.Error().Err(err).Msg("Failed to render web page")
+	}
+}
+
+func (c *problemCollector) lastRunTime() *time.Time {
+	if c.lastRun.IsZero() {
+		return nil
+	}
+	t := c.lastRun
+	return &t
+}
+
+func groupReports(reports []reporter.Report, rules []apiRule, groupBy string) []webGroup {
+	groups := []webGroup{}
+	index := map[string]int{}
+	for _, report := range reports {
+		var name string
+		switch groupBy {
+		case groupByOwner:
+			name = report.Owner
+			if name == "" {
+				name = "no owner"
+			}
+		case groupBySeverity:
+			name = report.Problem.Severity.String()
+		default:
+			name = report.ReportedPath
+		}
+
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, webGroup{Name: name})
+		}
+
+		problem := webProblem{Report: report}
+		for _, rule := range rules {
+			if rule.SourcePath == report.SourcePath && slices.Equal(rule.Lines, report.Rule.Lines()) {
+				problem.Content = rule.Content
+				break
+			}
+		}
+		groups[i].Problems = append(groups[i].Problems, problem)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groupBy == groupBySeverity {
+			return groups[i].Problems[0].Report.Problem.Severity > groups[j].Problems[0].Report.Problem.Severity
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	for _, group := range groups {
+		sort.SliceStable(group.Problems, func(i, j int) bool {
+			pi, pj := group.Problems[i].Report, group.Problems[j].Report
+			if pi.ReportedPath != pj.ReportedPath {
+				return pi.ReportedPath < pj.ReportedPath
+			}
+			return firstLine(pi.Problem.Lines) < firstLine(pj.Problem.Lines)
+		})
+	}
+	return groups
+}
+
+func firstLine(lines []int) int {
+	if len(lines) == 0 {
+		return 0
+	}
+	first := lines[0]
+	for _, line := range lines {
+		first = min(first, line)
+	}
+	return first
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error().Err(err).Msg("Failed to write JSON response")
+	}
+}
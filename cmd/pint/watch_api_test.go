@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/config"
+)
+
+func newTestCollector(t *testing.T) (*problemCollector, *httptest.Server) {
+	dir := t.TempDir()
+	rulesPath := path.Join(dir, "rules.yml")
+	err := os.WriteFile(rulesPath, []byte(`# pint file/owner alice
+- record: broken
+  expr: sum(foo) by(
+
+- record: ok
+  expr: sum(foo)
+
+- alert: missing
+  expr: up == 0
+  for: 1
+`), 0o644)
+	require.NoError(t, err)
+
+	cfg, err := config.Load("", false)
+	require.NoError(t, err)
+	cfg.Parser.Relaxed = []string{".*"}
+
+	collector := newProblemCollector(cfg, []string{rulesPath}, checks.Information, 0)
+	mux := http.NewServeMux()
+	collector.registerHandlers(mux)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return collector, srv
+}
+
+func getURL(t *testing.T, uri string) (int, string, string) {
+	resp, err := http.Get(uri)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, resp.Header.Get("Content-Type"), string(body)
+}
+
+func TestWatchAPIBeforeScan(t *testing.T) {
+	_, srv := newTestCollector(t)
+
+	for uri, expected := range map[string]string{
+		"/api/v1/problems": `{"lastRun":null,"problems":[]}`,
+		"/api/v1/rules":    `{"lastRun":null,"rules":[]}`,
+		"/api/v1/checks":   `{"lastRun":null,"checks":[]}`,
+	} {
+		code, contentType, body := getURL(t, srv.URL+uri)
+		require.Equal(t, http.StatusOK, code, uri)
+		require.Equal(t, "application/json", contentType, uri)
+		require.JSONEq(t, expected, body, uri)
+	}
+
+	code, _, body := getURL(t, srv.URL+"/")
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, body, "Checks are still running")
+}
+
+func TestWatchAPI(t *testing.T) {
+	collector, srv := newTestCollector(t)
+	ctx := context.WithValue(context.Background(), config.CommandKey, config.WatchCommand)
+	require.NoError(t, collector.scan(ctx, 1))
+
+	t.Run("problems", func(t *testing.T) {
+		code, _, body := getURL(t, srv.URL+"/api/v1/problems")
+		require.Equal(t, http.StatusOK, code)
+
+		var resp struct {
+			LastRun  *string `json:"lastRun"`
+			Problems []struct {
+				Owner string `json:"owner"`
+				Rule  struct {
+					Name string `json:"name"`
+				} `json:"rule"`
+				Problem struct {
+					Reporter string
+					Severity string
+				} `json:"problem"`
+			} `json:"problems"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(body), &resp))
+		require.NotNil(t, resp.LastRun)
+
+		reporters := map[string]string{}
+		for _, p := range resp.Problems {
+			require.Equal(t, "alice", p.Owner)
+			reporters[p.Problem.Reporter] = p.Rule.Name
+		}
+		require.Equal(t, map[string]string{
+			"promql/syntax": "broken",
+			"alerts/for":    "missing",
+		}, reporters)
+	})
+
+	t.Run("rules", func(t *testing.T) {
+		code, _, body := getURL(t, srv.URL+"/api/v1/rules")
+		require.Equal(t, http.StatusOK, code)
+
+		var resp apiRulesResponse
+		require.NoError(t, json.Unmarshal([]byte(body), &resp))
+		require.Len(t, resp.Rules, 3)
+		require.Equal(t, "broken", resp.Rules[0].Name)
+		require.Equal(t, 1, resp.Rules[0].Problems)
+		require.Equal(t, apiRule{
+			ReportedPath: collector.paths[0],
+			SourcePath:   collector.paths[0],
+			Name:         "ok",
+			Type:         "recording",
+			Owner:        "alice",
+			Lines:        []int{5, 6},
+			Content:      "- record: ok\n  expr: sum(foo)",
+		}, resp.Rules[1])
+		require.Equal(t, "missing", resp.Rules[2].Name)
+		require.Equal(t, 1, resp.Rules[2].Problems)
+	})
+
+	t.Run("checks", func(t *testing.T) {
+		code, _, body := getURL(t, srv.URL+"/api/v1/checks")
+		require.Equal(t, http.StatusOK, code)
+
+		var resp apiChecksResponse
+		require.NoError(t, json.Unmarshal([]byte(body), &resp))
+		require.Len(t, resp.Checks, len(checks.CheckNames))
+		found := map[string]apiCheck{}
+		for _, c := range resp.Checks {
+			found[c.Name] = c
+		}
+		require.Equal(t, apiCheck{
+			Name:     checks.AlertForCheckName,
+			Rules:    3,
+			Problems: 1,
+			Docs:     "https://cloudflare.github.io/pint/checks/alerts/for.html",
+		}, found[checks.AlertForCheckName])
+		require.Equal(t, 3, found[checks.SyntaxCheckName].Rules)
+		require.Equal(t, 1, found[checks.SyntaxCheckName].Problems)
+		require.True(t, found[checks.SeriesCheckName].Online)
+		require.Zero(t, found[checks.SeriesCheckName].Rules)
+	})
+
+	t.Run("index", func(t *testing.T) {
+		for _, group := range []string{"", "file", "owner", "severity"} {
+			code, contentType, body := getURL(t, srv.URL+"/?group="+group)
+			require.Equal(t, http.StatusOK, code)
+			require.Equal(t, "text/html; charset=utf-8", contentType)
+			require.Contains(t, body, "3 rule(s), 2 problem(s)")
+			require.Contains(t, body, `<a href="https://cloudflare.github.io/pint/checks/alerts/for.html">alerts/for</a>`)
+			require.Contains(t, body, "<pre>- alert: missing\n  expr: up == 0\n  for: 1</pre>")
+		}
+
+		_, _, body := getURL(t, srv.URL+"/?group=severity")
+		require.Contains(t, body, "<h2>Fatal</h2>")
+		require.Contains(t, body, "<h2>Bug</h2>")
+
+		_, _, body = getURL(t, srv.URL+"/?group=owner")
+		require.Contains(t, body, "<h2>alice</h2>")
+	})
+
+	t.Run("bad requests", func(t *testing.T) {
+		code, _, _ := getURL(t, srv.URL+"/?group=foo")
+		require.Equal(t, http.StatusBadRequest, code)
+
+		code, _, _ = getURL(t, srv.URL+"/foo")
+		require.Equal(t, http.StatusNotFound, code)
+	})
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/config"
+)
+
+func TestReloadConfig(t *testing.T) {
+	configPath := path.Join(t.TempDir(), ".pint.hcl")
+	writeConfig := func(content string) {
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0o644))
+	}
+	loadConfig := func() (config.Config, error) {
+		return config.Load(configPath, true)
+	}
+
+	writeConfig(`
+prometheus "same" {
+  uri = "http://127.0.0.1:1"
+}
+prometheus "modified" {
+  uri = "http://127.0.0.1:2"
+}
+prometheus "removed" {
+  uri = "http://127.0.0.1:3"
+}
+`)
+	cfg, err := loadConfig()
+	require.NoError(t, err)
+	for _, prom := range cfg.PrometheusServers {
+		prom.StartWorkers()
+	}
+
+	collector := newProblemCollector(cfg, nil, checks.Bug, 0)
+	collector.loadConfig = loadConfig
+	defer func() {
+		for _, prom := range collector.config().PrometheusServers {
+			prom.Close()
+		}
+	}()
+
+	writeConfig(`
+prometheus "same" {
+  uri = "http://127.0.0.1:1"
+}
+prometheus "modified" {
+  uri = "http://127.0.0.1:4"
+}
+prometheus "added" {
+  uri = "http://127.0.0.1:5"
+}
+`)
+	require.NoError(t, collector.reloadConfig())
+	require.Equal(t, float64(1), testutil.ToFloat64(configLastReloadSuccessful))
+
+	servers := collector.config().PrometheusServers
+	require.Len(t, servers, 3)
+	require.Same(t, cfg.PrometheusServers[0], servers[0])
+	require.NotSame(t, cfg.PrometheusServers[1], servers[1])
+	require.Equal(t, "modified", servers[1].Name())
+	require.Equal(t, "added", servers[2].Name())
+
+	writeConfig(`prometheus "bad" {}`)
+	require.EqualError(t, collector.reloadConfig(), "prometheus URI cannot be empty")
+	require.Equal(t, float64(0), testutil.ToFloat64(configLastReloadSuccessful))
+	require.Equal(t, servers, collector.config().PrometheusServers)
+}
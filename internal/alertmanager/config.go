@@ -0,0 +1,301 @@
+package alertmanager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the subset of Alertmanager configuration needed to tell where
+// alerts will be routed to.
+type Config struct {
+	Route        *Route        `yaml:"route"`
+	Receivers    []Receiver    `yaml:"receivers"`
+	InhibitRules []InhibitRule `yaml:"inhibit_rules"`
+}
+
+// Load reads Alertmanager configuration file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Alertmanager config file %q: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse decodes Alertmanager configuration and validates that all receivers
+// referenced by routes are defined.
+func Parse(data []byte) (*Config, error) {
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Route == nil {
+		return nil, errors.New("no route provided in config")
+	}
+	if cfg.Route.Receiver == "" {
+		return nil, errors.New("root route must specify a default receiver")
+	}
+	if len(cfg.Route.Matchers) > 0 {
+		return nil, errors.New("root route must not have any matchers")
+	}
+
+	names := make([]string, 0, len(cfg.Receivers))
+	for _, r := range cfg.Receivers {
+		if r.Name == "" {
+			return nil, errors.New("missing name in receiver")
+		}
+		if slices.Contains(names, r.Name) {
+			return nil, fmt.Errorf("notification config name %q is not unique", r.Name)
+		}
+		names = append(names, r.Name)
+	}
+	for _, route := range cfg.Route.all() {
+		if route.Receiver != "" && !slices.Contains(names, route.Receiver) {
+			return nil, fmt.Errorf("undefined receiver %q used in route", route.Receiver)
+		}
+	}
+	cfg.Route.inheritReceiver()
+
+	return &cfg, nil
+}
+
+// Receiver returns the receiver with given name.
+func (cfg Config) Receiver(name string) (Receiver, bool) {
+	for _, r := range cfg.Receivers {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Receiver{}, false
+}
+
+// Route is a single node of the routing tree.
+type Route struct {
+	Receiver string
+	Matchers Matchers
+	Continue bool
+	Routes   []*Route
+}
+
+func (r *Route) UnmarshalYAML(value *yaml.Node) error {
+	var raw struct {
+		Receiver string            `yaml:"receiver"`
+		Match    map[string]string `yaml:"match"`
+		MatchRE  map[string]string `yaml:"match_re"`
+		Matchers []string          `yaml:"matchers"`
+		Continue bool              `yaml:"continue"`
+		Routes   []*Route          `yaml:"routes"`
+	}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	matchers, err := newMatchers(raw.Match, raw.MatchRE, raw.Matchers)
+	if err != nil {
+		return err
+	}
+
+	r.Receiver = raw.Receiver
+	r.Matchers = matchers
+	r.Continue = raw.Continue
+	r.Routes = raw.Routes
+	return nil
+}
+
+// all returns this route and all of its sub-routes.
+func (r *Route) all() (routes []*Route) {
+	routes = append(routes, r)
+	for _, child := range r.Routes {
+		routes = append(routes, child.all()...)
+	}
+	return routes
+}
+
+// inheritReceiver sets the receiver on all sub-routes that don't have one
+// to the receiver of their parent route.
+func (r *Route) inheritReceiver() {
+	for _, child := range r.Routes {
+		if child.Receiver == "" {
+			child.Receiver = r.Receiver
+		}
+		child.inheritReceiver()
+	}
+}
+
+// Receiver is a named notification configuration.
+type Receiver struct {
+	Name string
+	// Integrations is the number of notification configs for this receiver.
+	// Receivers without any are often used to drop alerts.
+	Integrations int
+}
+
+func (r *Receiver) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]yaml.Node
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	for key, node := range raw {
+		if key == "name" {
+			if err := node.Decode(&r.Name); err != nil {
+				return err
+			}
+			continue
+		}
+		if strings.HasSuffix(key, "_configs") && node.Kind == yaml.SequenceNode {
+			r.Integrations += len(node.Content)
+		}
+	}
+	return nil
+}
+
+// InhibitRule mutes alerts matching target matchers when there's another
+// alert matching source matchers with equal values of all Equal labels.
+type InhibitRule struct {
+	SourceMatchers Matchers
+	TargetMatchers Matchers
+	Equal          []string
+}
+
+func (ir *InhibitRule) UnmarshalYAML(value *yaml.Node) error {
+	var raw struct {
+		SourceMatch    map[string]string `yaml:"source_match"`
+		SourceMatchRE  map[string]string `yaml:"source_match_re"`
+		SourceMatchers []string          `yaml:"source_matchers"`
+		TargetMatch    map[string]string `yaml:"target_match"`
+		TargetMatchRE  map[string]string `yaml:"target_match_re"`
+		TargetMatchers []string          `yaml:"target_matchers"`
+		Equal          []string          `yaml:"equal"`
+	}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	var err error
+	if ir.SourceMatchers, err = newMatchers(raw.SourceMatch, raw.SourceMatchRE, raw.SourceMatchers); err != nil {
+		return err
+	}
+	if ir.TargetMatchers, err = newMatchers(raw.TargetMatch, raw.TargetMatchRE, raw.TargetMatchers); err != nil {
+		return err
+	}
+	ir.Equal = raw.Equal
+	return nil
+}
+
+// Matchers is a list of label matchers that all must match.
+type Matchers []*labels.Matcher
+
+func (ms Matchers) String() string {
+	s := make([]string, 0, len(ms))
+	for _, m := range ms {
+		s = append(s, m.String())
+	}
+	return "{" + strings.Join(s, ", ") + "}"
+}
+
+func newMatchers(match, matchRE map[string]string, matchers []string) (ms Matchers, err error) {
+	for _, name := range sortedKeys(match) {
+		ms = append(ms, labels.MustNewMatcher(labels.MatchEqual, name, match[name]))
+	}
+	for _, name := range sortedKeys(matchRE) {
+		m, err := labels.NewMatcher(labels.MatchRegexp, name, matchRE[name])
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, m)
+	}
+	for _, s := range matchers {
+		parsed, err := ParseMatchers(s)
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, parsed...)
+	}
+	return ms, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var matcherRe = regexp.MustCompile(`^\s*([a-zA-Z_:][a-zA-Z0-9_:]*)\s*(=~|!~|!=|=)\s*(.*?)\s*$`)
+
+// ParseMatchers parses a string with one or more comma separated matchers,
+// optionally wrapped in curly braces, like `{severity="page", team=~"db|web"}`.
+// Values can be either quoted or unquoted.
+func ParseMatchers(s string) (ms Matchers, err error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = s[1 : len(s)-1]
+	}
+	for _, part := range splitMatchers(s) {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		parts := matcherRe.FindStringSubmatch(part)
+		if parts == nil {
+			return nil, fmt.Errorf("bad matcher format: %s", part)
+		}
+		value := parts[3]
+		if strings.HasPrefix(value, `"`) {
+			if value, err = strconv.Unquote(value); err != nil {
+				return nil, fmt.Errorf("invalid matcher value: %s", parts[3])
+			}
+		}
+		var mt labels.MatchType
+		switch parts[2] {
+		case "=":
+			mt = labels.MatchEqual
+		case "!=":
+			mt = labels.MatchNotEqual
+		case "=~":
+			mt = labels.MatchRegexp
+		case "!~":
+			mt = labels.MatchNotRegexp
+		}
+		m, err := labels.NewMatcher(mt, parts[1], value)
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, m)
+	}
+	return ms, nil
+}
+
+// splitMatchers splits a string on commas that are not inside quotes.
+func splitMatchers(s string) (parts []string) {
+	var inQuotes, escaped bool
+	var start int
+	for i, c := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\' && inQuotes:
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == ',' && !inQuotes:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
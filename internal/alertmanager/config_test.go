@@ -0,0 +1,156 @@
+package alertmanager_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/alertmanager"
+)
+
+func TestParse(t *testing.T) {
+	type testCaseT struct {
+		input string
+		err   string
+	}
+
+	testCases := []testCaseT{
+		{
+			input: "",
+			err:   "no route provided in config",
+		},
+		{
+			input: "route: {}\n",
+			err:   "root route must specify a default receiver",
+		},
+		{
+			input: "route:\n  receiver: foo\n  match:\n    foo: bar\nreceivers:\n- name: foo\n",
+			err:   "root route must not have any matchers",
+		},
+		{
+			input: "route:\n  receiver: foo\nreceivers:\n- name: bar\n",
+			err:   `undefined receiver "foo" used in route`,
+		},
+		{
+			input: "route:\n  receiver: foo\n  routes:\n  - receiver: bar\nreceivers:\n- name: foo\n",
+			err:   `undefined receiver "bar" used in route`,
+		},
+		{
+			input: "route:\n  receiver: foo\nreceivers:\n- name: foo\n- name: foo\n",
+			err:   `notification config name "foo" is not unique`,
+		},
+		{
+			input: "route:\n  receiver: foo\nreceivers:\n- email_configs: []\n",
+			err:   "missing name in receiver",
+		},
+		{
+			input: "route:\n  receiver: foo\n  routes:\n  - matchers: [\"foo\"]\nreceivers:\n- name: foo\n",
+			err:   "bad matcher format: foo",
+		},
+		{
+			input: "route:\n  receiver: foo\n  routes:\n  - match_re:\n      foo: \"(\"\nreceivers:\n- name: foo\n",
+			err:   "error parsing regexp: missing closing ): `^(?:()$`",
+		},
+		{
+			input: "route: []\n",
+			err:   "yaml: unmarshal errors:\n  line 1: cannot unmarshal !!seq into struct { Receiver string \"yaml:\\\"receiver\\\"\"; Match map[string]string \"yaml:\\\"match\\\"\"; MatchRE map[string]string \"yaml:\\\"match_re\\\"\"; Matchers []string \"yaml:\\\"matchers\\\"\"; Continue bool \"yaml:\\\"continue\\\"\"; Routes []*alertmanager.Route \"yaml:\\\"routes\\\"\" }",
+		},
+		{
+			input: "route:\n  receiver: foo\nreceivers:\n- name: foo\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := alertmanager.Parse([]byte(tc.input))
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := alertmanager.Parse([]byte(`
+route:
+  receiver: default
+  routes:
+  - matchers: ['severity="page"', 'team=~"db|web"']
+    receiver: pager
+    continue: true
+  - match:
+      severity: info
+    match_re:
+      team: db.*
+    routes:
+    - matchers: ["{env=dev, cluster!~\"a,b\"}"]
+      receiver: blackhole
+receivers:
+- name: default
+  email_configs:
+  - to: alerts@example.com
+- name: pager
+  pagerduty_configs:
+  - service_key: foo
+  - service_key: bar
+  webhook_configs:
+  - url: http://localhost
+- name: blackhole
+inhibit_rules:
+- source_match:
+    severity: page
+  target_matchers: [severity="info"]
+  equal: [cluster]
+`))
+	require.NoError(t, err)
+
+	require.Equal(t, "default", cfg.Route.Receiver)
+	require.Len(t, cfg.Route.Routes, 2)
+
+	require.Equal(t, "pager", cfg.Route.Routes[0].Receiver)
+	require.Equal(t, `{severity="page", team=~"db|web"}`, cfg.Route.Routes[0].Matchers.String())
+	require.True(t, cfg.Route.Routes[0].Continue)
+
+	require.Equal(t, "default", cfg.Route.Routes[1].Receiver)
+	require.Equal(t, `{severity="info", team=~"db.*"}`, cfg.Route.Routes[1].Matchers.String())
+	require.False(t, cfg.Route.Routes[1].Continue)
+	require.Len(t, cfg.Route.Routes[1].Routes, 1)
+	require.Equal(t, "blackhole", cfg.Route.Routes[1].Routes[0].Receiver)
+	require.Equal(t, `{env="dev", cluster!~"a,b"}`, cfg.Route.Routes[1].Routes[0].Matchers.String())
+
+	for name, integrations := range map[string]int{"default": 1, "pager": 3, "blackhole": 0} {
+		r, ok := cfg.Receiver(name)
+		require.True(t, ok, name)
+		require.Equal(t, name, r.Name)
+		require.Equal(t, integrations, r.Integrations, name)
+	}
+	_, ok := cfg.Receiver("missing")
+	require.False(t, ok)
+
+	require.Len(t, cfg.InhibitRules, 1)
+	require.Equal(t, `{severity="page"}`, cfg.InhibitRules[0].SourceMatchers.String())
+	require.Equal(t, `{severity="info"}`, cfg.InhibitRules[0].TargetMatchers.String())
+	require.Equal(t, []string{"cluster"}, cfg.InhibitRules[0].Equal)
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := alertmanager.Load(path.Join(dir, "missing.yml"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	p := path.Join(dir, "bad.yml")
+	require.NoError(t, os.WriteFile(p, []byte("route: {}\n"), 0o644))
+	_, err = alertmanager.Load(p)
+	require.EqualError(t, err, `failed to parse Alertmanager config file "`+p+`": root route must specify a default receiver`)
+
+	p = path.Join(dir, "good.yml")
+	require.NoError(t, os.WriteFile(p, []byte("route:\n  receiver: foo\nreceivers:\n- name: foo\n"), 0o644))
+	cfg, err := alertmanager.Load(p)
+	require.NoError(t, err)
+	require.Equal(t, "foo", cfg.Route.Receiver)
+}
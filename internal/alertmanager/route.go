@@ -0,0 +1,70 @@
+package alertmanager
+
+// MatchResult tells if an alert matches a set of matchers.
+type MatchResult uint8
+
+const (
+	NoMatch MatchResult = iota
+	MaybeMatch
+	AlwaysMatch
+)
+
+// Alert describes labels of alerts generated by a single alerting rule.
+// Not all labels are known before the alert fires, so matching an Alert
+// against matchers can give an uncertain result.
+type Alert struct {
+	// Labels with values known upfront.
+	Labels map[string]string
+	// Dynamic returns true if given label might be present on alerts
+	// but its value is only known when the alert fires.
+	Dynamic func(name string) bool
+}
+
+// Match evaluates all matchers against alert labels.
+func (ms Matchers) Match(alert Alert) MatchResult {
+	result := AlwaysMatch
+	for _, m := range ms {
+		if v, ok := alert.Labels[m.Name]; ok {
+			if !m.Matches(v) {
+				return NoMatch
+			}
+			continue
+		}
+		if alert.Dynamic != nil && alert.Dynamic(m.Name) {
+			result = MaybeMatch
+			continue
+		}
+		if !m.Matches("") {
+			return NoMatch
+		}
+	}
+	return result
+}
+
+// Match returns the list of routes that will receive given alert.
+// If alert labels are not known well enough to tell which routes
+// will receive it then ok will be false.
+func (r *Route) Match(alert Alert) (routes []*Route, ok bool) {
+	for _, child := range r.Routes {
+		switch child.Matchers.Match(alert) {
+		case NoMatch:
+			continue
+		case MaybeMatch:
+			return nil, false
+		case AlwaysMatch:
+		}
+
+		matched, ok := child.Match(alert)
+		if !ok {
+			return nil, false
+		}
+		routes = append(routes, matched...)
+		if !child.Continue {
+			break
+		}
+	}
+	if len(routes) == 0 {
+		routes = append(routes, r)
+	}
+	return routes, true
+}
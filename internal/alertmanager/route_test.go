@@ -0,0 +1,177 @@
+package alertmanager_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/alertmanager"
+)
+
+func TestMatchersMatch(t *testing.T) {
+	type testCaseT struct {
+		matchers string
+		alert    alertmanager.Alert
+		result   alertmanager.MatchResult
+	}
+
+	dynamic := func(names ...string) func(string) bool {
+		return func(name string) bool {
+			for _, n := range names {
+				if n == name {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	testCases := []testCaseT{
+		{
+			matchers: "",
+			result:   alertmanager.AlwaysMatch,
+		},
+		{
+			matchers: `severity="page"`,
+			alert:    alertmanager.Alert{Labels: map[string]string{"severity": "page"}},
+			result:   alertmanager.AlwaysMatch,
+		},
+		{
+			matchers: `severity="page"`,
+			alert:    alertmanager.Alert{Labels: map[string]string{"severity": "info"}},
+			result:   alertmanager.NoMatch,
+		},
+		{
+			matchers: `severity="page"`,
+			alert:    alertmanager.Alert{},
+			result:   alertmanager.NoMatch,
+		},
+		{
+			matchers: `severity!="page"`,
+			alert:    alertmanager.Alert{},
+			result:   alertmanager.AlwaysMatch,
+		},
+		{
+			matchers: `severity="page"`,
+			alert:    alertmanager.Alert{Dynamic: dynamic("severity")},
+			result:   alertmanager.MaybeMatch,
+		},
+		{
+			matchers: `severity="page", team="db"`,
+			alert:    alertmanager.Alert{Labels: map[string]string{"team": "web"}, Dynamic: dynamic("severity")},
+			result:   alertmanager.NoMatch,
+		},
+		{
+			matchers: `severity="page", team=~"db|web"`,
+			alert:    alertmanager.Alert{Labels: map[string]string{"team": "web"}, Dynamic: dynamic("severity")},
+			result:   alertmanager.MaybeMatch,
+		},
+		{
+			matchers: `severity="page", team=~"db|web"`,
+			alert:    alertmanager.Alert{Labels: map[string]string{"team": "web", "severity": "page"}, Dynamic: dynamic("severity")},
+			result:   alertmanager.AlwaysMatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.matchers, func(t *testing.T) {
+			ms, err := alertmanager.ParseMatchers(tc.matchers)
+			require.NoError(t, err)
+			require.Equal(t, tc.result, ms.Match(tc.alert))
+		})
+	}
+}
+
+func TestRouteMatch(t *testing.T) {
+	cfg, err := alertmanager.Parse([]byte(`
+route:
+  receiver: default
+  routes:
+  - matchers: [severity="page"]
+    receiver: pager
+    continue: true
+  - matchers: [team="db"]
+    receiver: db
+    routes:
+    - matchers: [env="dev"]
+      receiver: blackhole
+  - matchers: [team=~"db|web"]
+    receiver: web
+receivers:
+- name: default
+- name: pager
+- name: db
+- name: web
+- name: blackhole
+`))
+	require.NoError(t, err)
+
+	type testCaseT struct {
+		description string
+		alert       alertmanager.Alert
+		receivers   []string
+		ok          bool
+	}
+
+	testCases := []testCaseT{
+		{
+			description: "no labels",
+			alert:       alertmanager.Alert{},
+			receivers:   []string{"default"},
+			ok:          true,
+		},
+		{
+			description: "single route",
+			alert:       alertmanager.Alert{Labels: map[string]string{"team": "web"}},
+			receivers:   []string{"web"},
+			ok:          true,
+		},
+		{
+			description: "first matching route wins",
+			alert:       alertmanager.Alert{Labels: map[string]string{"team": "db"}},
+			receivers:   []string{"db"},
+			ok:          true,
+		},
+		{
+			description: "nested route",
+			alert:       alertmanager.Alert{Labels: map[string]string{"team": "db", "env": "dev"}},
+			receivers:   []string{"blackhole"},
+			ok:          true,
+		},
+		{
+			description: "continue",
+			alert:       alertmanager.Alert{Labels: map[string]string{"team": "web", "severity": "page"}},
+			receivers:   []string{"pager", "web"},
+			ok:          true,
+		},
+		{
+			description: "dynamic label",
+			alert: alertmanager.Alert{
+				Labels:  map[string]string{"team": "web"},
+				Dynamic: func(name string) bool { return name == "severity" },
+			},
+			ok: false,
+		},
+		{
+			description: "dynamic label on a route that's never reached",
+			alert: alertmanager.Alert{
+				Labels:  map[string]string{"team": "db"},
+				Dynamic: func(name string) bool { return name == "foo" },
+			},
+			receivers: []string{"db"},
+			ok:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			routes, ok := cfg.Route.Match(tc.alert)
+			require.Equal(t, tc.ok, ok)
+			var receivers []string
+			for _, r := range routes {
+				receivers = append(receivers, r.Receiver)
+			}
+			require.Equal(t, tc.receivers, receivers)
+		})
+	}
+}
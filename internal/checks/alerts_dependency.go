@@ -0,0 +1,96 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/graph"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+const (
+	AlertsDependencyCheckName = "alerts/dependency"
+)
+
+// ServersForRule returns all Prometheus servers that a rule from given path
+// is evaluated on.
+type ServersForRule func(path string, rule parser.Rule) []*promapi.FailoverGroup
+
+func NewAlertsDependencyCheck(prom *promapi.FailoverGroup, servers ServersForRule) AlertsDependencyCheck {
+	return AlertsDependencyCheck{prom: prom, servers: servers}
+}
+
+type AlertsDependencyCheck struct {
+	prom    *promapi.FailoverGroup
+	servers ServersForRule
+}
+
+func (c AlertsDependencyCheck) Meta() CheckMeta {
+	return CheckMeta{IsOnline: false}
+}
+
+func (c AlertsDependencyCheck) String() string {
+	return fmt.Sprintf("%s(%s)", AlertsDependencyCheckName, c.prom.Name())
+}
+
+func (c AlertsDependencyCheck) Reporter() string {
+	return AlertsDependencyCheckName
+}
+
+func (c AlertsDependencyCheck) Check(ctx context.Context, path string, rule parser.Rule, entries []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil || rule.AlertingRule.Expr.SyntaxError != nil {
+		return nil
+	}
+
+	g := graph.FromContext(ctx, entries)
+	node, ok := g.Find(path, rule)
+	if !ok {
+		return nil
+	}
+
+	// The same recording rule might be defined in multiple files, it's enough
+	// if any of them is evaluated on the same Prometheus server as this alert.
+	var names []string
+	defs := map[string][]graph.Node{}
+	for _, dep := range g.Dependencies(node.ID) {
+		if !slices.Contains(names, dep.Name) {
+			names = append(names, dep.Name)
+		}
+		defs[dep.Name] = append(defs[dep.Name], dep)
+	}
+
+	for _, name := range names {
+		var isEvaluated bool
+		for _, dep := range defs[name] {
+			if c.isEvaluatedOn(dep) {
+				isEvaluated = true
+				break
+			}
+		}
+		if isEvaluated {
+			continue
+		}
+		problems = append(problems, Problem{
+			Fragment: rule.AlertingRule.Expr.Value.Value,
+			Lines:    rule.AlertingRule.Expr.Lines(),
+			Reporter: c.Reporter(),
+			Text:     fmt.Sprintf("this alert uses `%s` recording rule defined at %s, but that rule is not evaluated on %q Prometheus server, so this alert will never see its results", name, defs[name][0].String(), c.prom.Name()),
+			Severity: Bug,
+		})
+	}
+
+	return problems
+}
+
+func (c AlertsDependencyCheck) isEvaluatedOn(dep graph.Node) bool {
+	for _, prom := range c.servers(dep.Path, dep.Rule) {
+		if prom.Name() == c.prom.Name() {
+			return true
+		}
+	}
+	return false
+}
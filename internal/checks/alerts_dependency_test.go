@@ -0,0 +1,164 @@
+package checks_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func textDependency(name, path, prom string) string {
+	return fmt.Sprintf("this alert uses `%s` recording rule defined at %s, but that rule is not evaluated on %q Prometheus server, so this alert will never see its results", name, path, prom)
+}
+
+func newDependencyProm(uri string) *promapi.FailoverGroup {
+	return promapi.NewFailoverGroup(
+		"prom",
+		[]*promapi.Prometheus{
+			promapi.NewPrometheus("prom", uri, map[string]string{}, time.Second, 4, 100, nil),
+		},
+		true,
+		"up",
+		[]*regexp.Regexp{regexp.MustCompile("^fake.yml$")},
+		nil,
+		nil,
+	)
+}
+
+func newDependencyCheck(prom *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewAlertsDependencyCheck(prom, func(path string, _ parser.Rule) []*promapi.FailoverGroup {
+		if prom.IsEnabledForPath(path) {
+			return []*promapi.FailoverGroup{prom}
+		}
+		return nil
+	})
+}
+
+func mustParseContentAt(path, content string) []discovery.Entry {
+	entries := mustParseContent(content)
+	for i := range entries {
+		entries[i].ReportedPath = path
+		entries[i].SourcePath = path
+	}
+	return entries
+}
+
+func concatEntries(entries ...[]discovery.Entry) (all []discovery.Entry) {
+	for _, e := range entries {
+		all = append(all, e...)
+	}
+	return all
+}
+
+func TestAlertsDependencyCheck(t *testing.T) {
+	const alert = "- alert: foo\n  expr: sum(job:up:sum{job=\"foo\"}) == 0\n"
+
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: foo\n  expr: sum(foo) without(\n",
+			checker:     newDependencyCheck,
+			prometheus:  newDependencyProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: sum(job:up:sum)\n",
+			checker:     newDependencyCheck,
+			prometheus:  newDependencyProm,
+			problems:    noProblems,
+			entries: concatEntries(
+				mustParseContent("- record: foo\n  expr: sum(job:up:sum)\n"),
+				mustParseContentAt("other.yml", "- record: job:up:sum\n  expr: sum(up) by(job)\n"),
+			),
+		},
+		{
+			description: "ignores unknown rules",
+			content:     alert,
+			checker:     newDependencyCheck,
+			prometheus:  newDependencyProm,
+			problems:    noProblems,
+			entries:     mustParseContentAt("other.yml", "- record: job:up:sum\n  expr: sum(up) by(job)\n"),
+		},
+		{
+			description: "ignores alerts without dependencies",
+			content:     alert,
+			checker:     newDependencyCheck,
+			prometheus:  newDependencyProm,
+			problems:    noProblems,
+			entries: concatEntries(
+				mustParseContent(alert),
+				mustParseContentAt("other.yml", "- record: job:up:max\n  expr: max(up) by(job)\n"),
+			),
+		},
+		{
+			description: "recording rule in the same file",
+			content:     alert,
+			checker:     newDependencyCheck,
+			prometheus:  newDependencyProm,
+			problems:    noProblems,
+			entries:     mustParseContent(alert + "- record: job:up:sum\n  expr: sum(up) by(job)\n"),
+		},
+		{
+			description: "recording rule defined in multiple files",
+			content:     alert,
+			checker:     newDependencyCheck,
+			prometheus:  newDependencyProm,
+			problems:    noProblems,
+			entries: concatEntries(
+				mustParseContentAt("other.yml", "- record: job:up:sum\n  expr: sum(up) by(job)\n"),
+				mustParseContent(alert+"- record: job:up:sum\n  expr: sum(up) by(job)\n"),
+			),
+		},
+		{
+			description: "recording rule with mismatched labels",
+			content:     alert,
+			checker:     newDependencyCheck,
+			prometheus:  newDependencyProm,
+			problems:    noProblems,
+			entries: concatEntries(
+				mustParseContent(alert),
+				mustParseContentAt("other.yml", "- record: job:up:sum\n  expr: sum(up)\n  labels:\n    job: bar\n"),
+			),
+		},
+		{
+			description: "recording rule evaluated on all servers",
+			content:     alert,
+			checker:     newDependencyCheck,
+			prometheus:  newSimpleProm,
+			problems:    noProblems,
+			entries: concatEntries(
+				mustParseContent(alert),
+				mustParseContentAt("other.yml", "- record: job:up:sum\n  expr: sum(up) by(job)\n"),
+			),
+		},
+		{
+			description: "recording rule evaluated on a different server",
+			content:     alert,
+			checker:     newDependencyCheck,
+			prometheus:  newDependencyProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: `sum(job:up:sum{job="foo"}) == 0`,
+						Lines:    []int{2},
+						Reporter: checks.AlertsDependencyCheckName,
+						Text:     textDependency("job:up:sum", "other.yml:1", "prom"),
+						Severity: checks.Bug,
+					},
+				}
+			},
+			entries: concatEntries(
+				mustParseContent(alert),
+				mustParseContentAt("other.yml", "- record: job:up:sum\n  expr: sum(up) by(job)\n- record: job:up:sum\n  expr: sum(up) by(job)\n  labels:\n    job: foo\n"),
+			),
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,243 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"golang.org/x/exp/slices"
+
+	"github.com/cloudflare/pint/internal/alertmanager"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	RouteCheckName = "alerts/route"
+)
+
+// NewRouteCheck creates a check that evaluates alerts against Alertmanager
+// routing tree and inhibit rules.
+func NewRouteCheck(path string, cfg *alertmanager.Config) RouteCheck {
+	return RouteCheck{path: path, cfg: cfg}
+}
+
+type RouteCheck struct {
+	path string
+	cfg  *alertmanager.Config
+}
+
+func (c RouteCheck) Meta() CheckMeta {
+	return CheckMeta{IsOnline: false}
+}
+
+func (c RouteCheck) String() string {
+	return RouteCheckName
+}
+
+func (c RouteCheck) Reporter() string {
+	return RouteCheckName
+}
+
+func (c RouteCheck) Check(_ context.Context, _ string, rule parser.Rule, entries []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil || rule.AlertingRule.Expr.SyntaxError != nil {
+		return nil
+	}
+
+	lines := rule.AlertingRule.Alert.Lines()
+	if rule.AlertingRule.Labels != nil {
+		lines = append(lines, rule.AlertingRule.Labels.Lines()...)
+	}
+
+	alert := newRouteAlert(rule.AlertingRule)
+	if routes, ok := c.cfg.Route.Match(alert); ok {
+		if len(routes) == 1 && routes[0] == c.cfg.Route && len(c.cfg.Route.Routes) > 0 {
+			problems = append(problems, Problem{
+				Fragment: rule.AlertingRule.Alert.Value.Value,
+				Lines:    lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("this alert doesn't match any route in Alertmanager config at %s and will be sent to the default `%s` receiver",
+					c.path, c.cfg.Route.Receiver),
+				Severity: Warning,
+			})
+		}
+
+		receivers := make([]string, 0, len(routes))
+		for _, route := range routes {
+			if r, ok := c.cfg.Receiver(route.Receiver); ok && r.Integrations > 0 {
+				receivers = nil
+				break
+			}
+			receivers = append(receivers, "`"+route.Receiver+"`")
+		}
+		if len(receivers) > 0 {
+			noun := "receiver"
+			if len(receivers) > 1 {
+				noun = "receivers"
+			}
+			problems = append(problems, Problem{
+				Fragment: rule.AlertingRule.Alert.Value.Value,
+				Lines:    lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("Alertmanager config at %s routes this alert to %s %s with no notification integrations configured, notifications for this alert will never be sent",
+					c.path, strings.Join(receivers, ", "), noun),
+				Severity: Warning,
+			})
+		}
+	}
+
+	for _, ir := range c.cfg.InhibitRules {
+		if ir.TargetMatchers.Match(alert) == alertmanager.AlwaysMatch {
+			sources := matchingAlerts(entries, ir.SourceMatchers)
+			if len(sources) == 0 {
+				problems = append(problems, Problem{
+					Fragment: rule.AlertingRule.Alert.Value.Value,
+					Lines:    lines,
+					Reporter: c.Reporter(),
+					Text: fmt.Sprintf("Alertmanager config at %s has an inhibit rule that mutes this alert when there's another alert matching `%s`, but none of the alerting rules in checked files can ever match it",
+						c.path, ir.SourceMatchers),
+					Severity: Information,
+				})
+			}
+			for _, name := range ir.Equal {
+				if reason := equalLabelMismatch(rule.AlertingRule, sources, name); reason != "" {
+					problems = append(problems, Problem{
+						Fragment: rule.AlertingRule.Alert.Value.Value,
+						Lines:    lines,
+						Reporter: c.Reporter(),
+						Text: fmt.Sprintf("Alertmanager config at %s has an inhibit rule that mutes this alert when there's another alert matching `%s` with equal `%s` label, but %s, so this alert will never be muted",
+							c.path, ir.SourceMatchers, name, reason),
+						Severity: Information,
+					})
+				}
+			}
+		}
+		if ir.SourceMatchers.Match(alert) == alertmanager.AlwaysMatch {
+			targets := matchingAlerts(entries, ir.TargetMatchers)
+			if len(targets) == 0 {
+				problems = append(problems, Problem{
+					Fragment: rule.AlertingRule.Alert.Value.Value,
+					Lines:    lines,
+					Reporter: c.Reporter(),
+					Text: fmt.Sprintf("Alertmanager config at %s has an inhibit rule that uses this alert to mute alerts matching `%s`, but none of the alerting rules in checked files can ever match it",
+						c.path, ir.TargetMatchers),
+					Severity: Information,
+				})
+			}
+			for _, name := range ir.Equal {
+				if reason := equalLabelMismatch(rule.AlertingRule, targets, name); reason != "" {
+					problems = append(problems, Problem{
+						Fragment: rule.AlertingRule.Alert.Value.Value,
+						Lines:    lines,
+						Reporter: c.Reporter(),
+						Text: fmt.Sprintf("Alertmanager config at %s has an inhibit rule that uses this alert to mute alerts matching `%s` with equal `%s` label, but %s, so this alert will never mute them",
+							c.path, ir.TargetMatchers, name, reason),
+						Severity: Information,
+					})
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// newRouteAlert returns alert labels known before the alert fires.
+// Labels with templated values or labels that might be present on query
+// results are dynamic.
+func newRouteAlert(rule *parser.AlertingRule) alertmanager.Alert {
+	alert := alertmanager.Alert{
+		Labels: map[string]string{labels.AlertName: rule.Alert.Value.Value},
+	}
+
+	templated := map[string]struct{}{}
+	if rule.Labels != nil {
+		for _, l := range rule.Labels.Items {
+			if strings.Contains(l.Value.Value, "{{") {
+				templated[l.Key.Value] = struct{}{}
+				continue
+			}
+			alert.Labels[l.Key.Value] = l.Value.Value
+		}
+	}
+
+	var ls utils.LabelSet
+	if rule.Expr.SyntaxError == nil && rule.Expr.Query != nil {
+		ls = utils.InferLabels(rule.Expr.Query)
+	}
+	alert.Dynamic = func(name string) bool {
+		if _, ok := templated[name]; ok {
+			return true
+		}
+		return ls.CanHave(name)
+	}
+	return alert
+}
+
+// matchingAlerts returns all alerting rules from entries that might
+// generate alerts matching given matchers.
+func matchingAlerts(entries []discovery.Entry, ms alertmanager.Matchers) (rules []*parser.AlertingRule) {
+	for _, entry := range entries {
+		if entry.State == discovery.Removed || entry.PathError != nil || entry.Rule.Error.Err != nil {
+			continue
+		}
+		if entry.Rule.AlertingRule == nil {
+			continue
+		}
+		if ms.Match(newRouteAlert(entry.Rule.AlertingRule)) != alertmanager.NoMatch {
+			rules = append(rules, entry.Rule.AlertingRule)
+		}
+	}
+	return rules
+}
+
+// equalLabelMismatch checks if given label can have equal values on alerts
+// generated by the rule and by any of the other rules.
+// Alertmanager treats a missing label as a label with an empty value, so
+// the only case where values can never be equal is when one side always
+// has the label while the other side never has it.
+// It returns the reason why values can't be equal or an empty string.
+func equalLabelMismatch(rule *parser.AlertingRule, others []*parser.AlertingRule, name string) string {
+	if len(others) == 0 {
+		return ""
+	}
+
+	always, possible := alertLabelPresence(rule, name)
+	othersAlways, othersPossible := true, false
+	for _, other := range others {
+		a, p := alertLabelPresence(other, name)
+		othersAlways = othersAlways && a
+		othersPossible = othersPossible || p
+	}
+
+	switch {
+	case always && !othersPossible:
+		return "this alert always has it while none of the alerting rules on the other side can have it"
+	case !possible && othersAlways:
+		return "this alert never has it while all alerting rules on the other side always have it"
+	}
+	return ""
+}
+
+// alertLabelPresence returns two booleans, first one is true if alerts generated
+// by given rule will always have given label, second one is true if they might have it.
+func alertLabelPresence(rule *parser.AlertingRule, name string) (always, possible bool) {
+	if name == labels.AlertName {
+		return true, true
+	}
+	if rule.Labels != nil {
+		if val := rule.Labels.GetValue(name); val != nil {
+			if strings.Contains(val.Value, "{{") {
+				return false, true
+			}
+			return val.Value != "", val.Value != ""
+		}
+	}
+	if rule.Expr.SyntaxError != nil || rule.Expr.Query == nil {
+		return false, true
+	}
+	ls := utils.InferLabels(rule.Expr.Query)
+	return slices.Contains(ls.Guaranteed, name), ls.CanHave(name)
+}
@@ -0,0 +1,313 @@
+package checks_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/pint/internal/alertmanager"
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+const testAlertmanagerConfig = `
+route:
+  receiver: default
+  routes:
+  - matchers: [team="db"]
+    receiver: db
+    routes:
+    - matchers: [env="dev"]
+      receiver: blackhole
+  - matchers: [severity="page"]
+    receiver: pager
+    continue: true
+  - matchers: [severity=~"page|ticket"]
+    receiver: devnull
+receivers:
+- name: default
+  email_configs:
+  - to: alerts@example.com
+- name: db
+  email_configs:
+  - to: db@example.com
+- name: pager
+- name: devnull
+- name: blackhole
+inhibit_rules:
+- source_matchers: [alertname="ClusterDown"]
+  target_matchers: [severity="page"]
+  equal: [cluster]
+`
+
+func newRouteCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	cfg, err := alertmanager.Parse([]byte(testAlertmanagerConfig))
+	if err != nil {
+		panic(err)
+	}
+	return checks.NewRouteCheck("alertmanager.yml", cfg)
+}
+
+func routeDefaultText() string {
+	return "this alert doesn't match any route in Alertmanager config at alertmanager.yml and will be sent to the default `default` receiver"
+}
+
+func routeBlackholeText(receivers, noun string) string {
+	return fmt.Sprintf("Alertmanager config at alertmanager.yml routes this alert to %s %s with no notification integrations configured, notifications for this alert will never be sent", receivers, noun)
+}
+
+func routeInhibitTargetText(matchers string) string {
+	return fmt.Sprintf("Alertmanager config at alertmanager.yml has an inhibit rule that mutes this alert when there's another alert matching `%s`, but none of the alerting rules in checked files can ever match it", matchers)
+}
+
+func routeInhibitSourceText(matchers string) string {
+	return fmt.Sprintf("Alertmanager config at alertmanager.yml has an inhibit rule that uses this alert to mute alerts matching `%s`, but none of the alerting rules in checked files can ever match it", matchers)
+}
+
+func routeInhibitTargetEqualText(matchers, name, reason string) string {
+	return fmt.Sprintf("Alertmanager config at alertmanager.yml has an inhibit rule that mutes this alert when there's another alert matching `%s` with equal `%s` label, but %s, so this alert will never be muted", matchers, name, reason)
+}
+
+func routeInhibitSourceEqualText(matchers, name, reason string) string {
+	return fmt.Sprintf("Alertmanager config at alertmanager.yml has an inhibit rule that uses this alert to mute alerts matching `%s` with equal `%s` label, but %s, so this alert will never mute them", matchers, name, reason)
+}
+
+func TestRouteCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: foo\n  expr: sum(foo) without(\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "matches a route",
+			content:     "- alert: foo\n  expr: sum(up) == 0\n  labels:\n    team: db\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "falls through to the default receiver",
+			content:     "- alert: foo\n  expr: sum(up) == 0\n  labels:\n    team: web\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "foo",
+						Lines:    []int{1, 3, 4},
+						Reporter: checks.RouteCheckName,
+						Text:     routeDefaultText(),
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "no labels",
+			content:     "- alert: foo\n  expr: vector(1)\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "foo",
+						Lines:    []int{1},
+						Reporter: checks.RouteCheckName,
+						Text:     routeDefaultText(),
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "route label might be present on query results",
+			content:     "- alert: foo\n  expr: up == 0\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "route label is templated",
+			content:     "- alert: foo\n  expr: sum(up) == 0\n  labels:\n    team: '{{ $labels.job }}'\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "routed to receiver without integrations",
+			content:     "- alert: foo\n  expr: sum(up) by(team) == 0\n  labels:\n    team: db\n    env: dev\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "foo",
+						Lines:    []int{1, 3, 4, 5},
+						Reporter: checks.RouteCheckName,
+						Text:     routeBlackholeText("`blackhole`", "receiver"),
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "routed to multiple receivers without integrations",
+			content:     "- alert: foo\n  expr: sum(up) == 0\n  labels:\n    severity: page\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			entries: concatEntries(
+				mustParseContent("- alert: foo\n  expr: sum(up) == 0\n  labels:\n    severity: page\n"),
+				mustParseContentAt("other.yml", "- alert: ClusterDown\n  expr: up == 0\n"),
+			),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "foo",
+						Lines:    []int{1, 3, 4},
+						Reporter: checks.RouteCheckName,
+						Text:     routeBlackholeText("`pager`, `devnull`", "receivers"),
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "inhibit rule source never matches",
+			content:     "- alert: foo\n  expr: sum(up) == 0\n  labels:\n    team: db\n    severity: page\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			entries: concatEntries(
+				mustParseContent("- alert: foo\n  expr: sum(up) == 0\n  labels:\n    team: db\n    severity: page\n"),
+				mustParseContentAt("other.yml", "- alert: NodeDown\n  expr: up == 0\n  labels:\n    severity: '{{ $labels.severity }}'\n"),
+			),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "foo",
+						Lines:    []int{1, 3, 4, 5},
+						Reporter: checks.RouteCheckName,
+						Text:     routeInhibitTargetText(`{alertname="ClusterDown"}`),
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "inhibit rule source matches",
+			content:     "- alert: foo\n  expr: sum(up) == 0\n  labels:\n    team: db\n    severity: page\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			entries: concatEntries(
+				mustParseContent("- alert: foo\n  expr: sum(up) == 0\n  labels:\n    team: db\n    severity: page\n"),
+				mustParseContentAt("other.yml", "- alert: ClusterDown\n  expr: up == 0\n"),
+			),
+			problems: noProblems,
+		},
+		{
+			description: "inhibit rule target never matches",
+			content:     "- alert: ClusterDown\n  expr: sum(up) == 0\n  labels:\n    team: db\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			entries: concatEntries(
+				mustParseContent("- alert: ClusterDown\n  expr: sum(up) == 0\n  labels:\n    team: db\n"),
+				mustParseContentAt("other.yml", "- alert: bar\n  expr: sum(up) by(job) == 0\n  labels:\n    severity: ticket\n"),
+			),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "ClusterDown",
+						Lines:    []int{1, 3, 4},
+						Reporter: checks.RouteCheckName,
+						Text:     routeInhibitSourceText(`{severity="page"}`),
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "inhibit rule target might match",
+			content:     "- alert: ClusterDown\n  expr: sum(up) == 0\n  labels:\n    team: db\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			entries: concatEntries(
+				mustParseContent("- alert: ClusterDown\n  expr: sum(up) == 0\n  labels:\n    team: db\n"),
+				mustParseContentAt("other.yml", "- alert: bar\n  expr: up == 0\n"),
+			),
+			problems: noProblems,
+		},
+		{
+			description: "inhibit rule equal label only on target",
+			content:     "- alert: foo\n  expr: sum(up) == 0\n  labels:\n    team: db\n    severity: page\n    cluster: a\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			entries: concatEntries(
+				mustParseContent("- alert: foo\n  expr: sum(up) == 0\n  labels:\n    team: db\n    severity: page\n    cluster: a\n"),
+				mustParseContentAt("other.yml", "- alert: ClusterDown\n  expr: sum(up) by(job) == 0\n"),
+			),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "foo",
+						Lines:    []int{1, 3, 4, 5, 6},
+						Reporter: checks.RouteCheckName,
+						Text:     routeInhibitTargetEqualText(`{alertname="ClusterDown"}`, "cluster", "this alert always has it while none of the alerting rules on the other side can have it"),
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "inhibit rule equal label only on target / source",
+			content:     "- alert: ClusterDown\n  expr: sum(up) by(job) == 0\n  labels:\n    team: db\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			entries: concatEntries(
+				mustParseContent("- alert: ClusterDown\n  expr: sum(up) by(job) == 0\n  labels:\n    team: db\n"),
+				mustParseContentAt("other.yml", "- alert: foo\n  expr: sum(up) == 0\n  labels:\n    severity: page\n    cluster: a\n"),
+			),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "ClusterDown",
+						Lines:    []int{1, 3, 4},
+						Reporter: checks.RouteCheckName,
+						Text:     routeInhibitSourceEqualText(`{severity="page"}`, "cluster", "this alert never has it while all alerting rules on the other side always have it"),
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "inhibit rule equal label might be on both sides",
+			content:     "- alert: foo\n  expr: sum(up) == 0\n  labels:\n    team: db\n    severity: page\n    cluster: a\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			entries: concatEntries(
+				mustParseContent("- alert: foo\n  expr: sum(up) == 0\n  labels:\n    team: db\n    severity: page\n    cluster: a\n"),
+				mustParseContentAt("other.yml", "- alert: ClusterDown\n  expr: sum(up) by(cluster) == 0\n"),
+			),
+			problems: noProblems,
+		},
+		{
+			description: "inhibit rule equal label missing on both sides",
+			content:     "- alert: foo\n  expr: sum(up) == 0\n  labels:\n    team: db\n    severity: page\n",
+			checker:     newRouteCheck,
+			prometheus:  noProm,
+			entries: concatEntries(
+				mustParseContent("- alert: foo\n  expr: sum(up) == 0\n  labels:\n    team: db\n    severity: page\n"),
+				mustParseContentAt("other.yml", "- alert: ClusterDown\n  expr: sum(up) by(job) == 0\n"),
+			),
+			problems: noProblems,
+		},
+	}
+	runTests(t, testCases)
+}
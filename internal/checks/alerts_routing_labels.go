@@ -0,0 +1,81 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+const (
+	RoutingLabelsCheckName = "alerts/routing-labels"
+)
+
+// NewRoutingLabelsCheck creates a check that reports alerts that will never
+// have some of the labels required by alert routing.
+func NewRoutingLabelsCheck(labels []string, severity Severity) RoutingLabelsCheck {
+	return RoutingLabelsCheck{labels: labels, severity: severity}
+}
+
+type RoutingLabelsCheck struct {
+	labels   []string
+	severity Severity
+}
+
+func (c RoutingLabelsCheck) Meta() CheckMeta {
+	return CheckMeta{IsOnline: false}
+}
+
+func (c RoutingLabelsCheck) String() string {
+	return RoutingLabelsCheckName
+}
+
+func (c RoutingLabelsCheck) Reporter() string {
+	return RoutingLabelsCheckName
+}
+
+func (c RoutingLabelsCheck) Check(_ context.Context, _ string, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+	if rule.AlertingRule == nil || rule.AlertingRule.Expr.SyntaxError != nil {
+		return nil
+	}
+
+	ls := utils.InferLabels(rule.AlertingRule.Expr.Query)
+	for _, name := range c.labels {
+		if rule.AlertingRule.Labels != nil && rule.AlertingRule.Labels.GetValue(name) != nil {
+			continue
+		}
+		if ls.CanHave(name) {
+			continue
+		}
+		node := labelRemover(rule.AlertingRule.Expr.Query, name)
+		problems = append(problems, Problem{
+			Fragment: node.Expr,
+			Lines:    rule.AlertingRule.Expr.Lines(),
+			Reporter: c.Reporter(),
+			Text: fmt.Sprintf("`%s` label is required for alert routing but this alert will never have it, it's not set in `labels` and `%s` removes it from query results",
+				name, node.Expr),
+			Severity: c.severity,
+		})
+	}
+	return problems
+}
+
+// labelRemover returns the innermost part of the query that removes given
+// label from query results.
+func labelRemover(node *parser.PromQLNode, name string) *parser.PromQLNode {
+	for _, child := range node.Children {
+		switch child.Node.Type() {
+		case promParser.ValueTypeVector, promParser.ValueTypeMatrix:
+		default:
+			continue
+		}
+		if !utils.InferLabels(child).CanHave(name) {
+			return labelRemover(child, name)
+		}
+	}
+	return node
+}
@@ -0,0 +1,149 @@
+package checks_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newRoutingLabelsCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewRoutingLabelsCheck([]string{"team", "severity"}, checks.Bug)
+}
+
+func routingLabelText(name, expr string) string {
+	return fmt.Sprintf("`%s` label is required for alert routing but this alert will never have it, it's not set in `labels` and `%s` removes it from query results", name, expr)
+}
+
+func TestRoutingLabelsCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- alert: foo\n  expr: sum(foo) without(\n",
+			checker:     newRoutingLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "ignores recording rules",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newRoutingLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "labels might be present on query results",
+			content:     "- alert: foo\n  expr: up == 0\n",
+			checker:     newRoutingLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "labels kept by aggregation",
+			content:     "- alert: foo\n  expr: sum(up) by(team, severity) == 0\n",
+			checker:     newRoutingLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "labels set on the rule",
+			content:     "- alert: foo\n  expr: sum(up) == 0\n  labels:\n    team: foo\n    severity: page\n",
+			checker:     newRoutingLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "labels removed by aggregation",
+			content:     "- alert: foo\n  expr: sum(up) by(job) == 0\n  labels:\n    severity: page\n",
+			checker:     newRoutingLabelsCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "sum by (job) (up)",
+						Lines:    []int{2},
+						Reporter: checks.RoutingLabelsCheckName,
+						Text:     routingLabelText("team", "sum by (job) (up)"),
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "labels removed by without",
+			content:     "- alert: foo\n  expr: (sum(up) without(team, severity) > 0) == 0\n",
+			checker:     newRoutingLabelsCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "sum without (team, severity) (up)",
+						Lines:    []int{2},
+						Reporter: checks.RoutingLabelsCheckName,
+						Text:     routingLabelText("team", "sum without (team, severity) (up)"),
+						Severity: checks.Bug,
+					},
+					{
+						Fragment: "sum without (team, severity) (up)",
+						Lines:    []int{2},
+						Reporter: checks.RoutingLabelsCheckName,
+						Text:     routingLabelText("severity", "sum without (team, severity) (up)"),
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "labels removed by absent",
+			content:     "- alert: foo\n  expr: absent(up{job=\"foo\", team=~\"a|b\"})\n  labels:\n    severity: page\n",
+			checker:     newRoutingLabelsCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: `absent(up{job="foo", team=~"a|b"})`,
+						Lines:    []int{2},
+						Reporter: checks.RoutingLabelsCheckName,
+						Text:     routingLabelText("team", `absent(up{job="foo", team=~"a|b"})`),
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "labels added by group_left",
+			content:     "- alert: foo\n  expr: (sum(up) by(job) == 0) * on(job) group_left(team, severity) job_info\n",
+			checker:     newRoutingLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "labels added by label_replace",
+			content:     "- alert: foo\n  expr: label_replace(label_replace(sum(up) by(job), \"team\", \"$1\", \"job\", \"(.+)\"), \"severity\", \"page\", \"\", \"\") == 0\n",
+			checker:     newRoutingLabelsCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "custom severity",
+			content:     "- alert: foo\n  expr: vector(1)\n  labels:\n    team: foo\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewRoutingLabelsCheck([]string{"team", "severity"}, checks.Warning)
+			},
+			prometheus: noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "vector(1)",
+						Lines:    []int{2},
+						Reporter: checks.RoutingLabelsCheckName,
+						Text:     routingLabelText("severity", "vector(1)"),
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+	}
+	runTests(t, testCases)
+}
@@ -101,6 +101,7 @@ func (c TemplateCheck) Check(ctx context.Context, _ string, rule parser.Rule, _
 
 	aggrs := utils.HasOuterAggregation(rule.AlertingRule.Expr.Query)
 	absentCalls := utils.HasOuterAbsent(rule.AlertingRule.Expr.Query)
+	labelSet := utils.InferLabels(rule.AlertingRule.Expr.Query)
 
 	var safeLabels []string
 	for _, be := range binaryExprs(rule.AlertingRule.Expr.Query) {
@@ -143,14 +144,18 @@ func (c TemplateCheck) Check(ctx context.Context, _ string, rule parser.Rule, _
 				})
 			}
 			// check value
-			for _, msg := range checkForValueInLabels(label.Key.Value, label.Value.Value) {
-				problems = append(problems, Problem{
-					Fragment: fmt.Sprintf("%s: %s", label.Key.Value, label.Value.Value),
-					Lines:    label.Lines(),
-					Reporter: c.Reporter(),
-					Text:     msg,
-					Severity: Bug,
-				})
+			if msgs := checkForValueInLabels(label.Key.Value, label.Value.Value); len(msgs) > 0 {
+				fixes := removeValueFixes(label)
+				for _, msg := range msgs {
+					problems = append(problems, Problem{
+						Fragment: fmt.Sprintf("%s: %s", label.Key.Value, label.Value.Value),
+						Lines:    label.Lines(),
+						Reporter: c.Reporter(),
+						Text:     msg,
+						Severity: Bug,
+						Fixes:    fixes,
+					})
+				}
 			}
 
 			for _, aggr := range aggrs {
@@ -179,6 +184,20 @@ func (c TemplateCheck) Check(ctx context.Context, _ string, rule parser.Rule, _
 					})
 				}
 			}
+
+			for _, name := range checkInferredLabels(label.Key.Value, label.Value.Value, labelSet) {
+				fragment := fmt.Sprintf("%s: %s", label.Key.Value, label.Value.Value)
+				if hasLabelProblem(problems, fragment, name) {
+					continue
+				}
+				problems = append(problems, Problem{
+					Fragment: fragment,
+					Lines:    mergeLines(label.Lines(), rule.AlertingRule.Expr.Lines()),
+					Reporter: c.Reporter(),
+					Text:     fmt.Sprintf(msgAggregation, name),
+					Severity: Bug,
+				})
+			}
 		}
 	}
 
@@ -228,14 +247,34 @@ func (c TemplateCheck) Check(ctx context.Context, _ string, rule parser.Rule, _
 				}
 			}
 
+			for _, name := range checkInferredLabels(annotation.Key.Value, annotation.Value.Value, labelSet) {
+				fragment := fmt.Sprintf("%s: %s", annotation.Key.Value, annotation.Value.Value)
+				if hasLabelProblem(problems, fragment, name) {
+					continue
+				}
+				problems = append(problems, Problem{
+					Fragment: fragment,
+					Lines:    mergeLines(annotation.Lines(), rule.AlertingRule.Expr.Lines()),
+					Reporter: c.Reporter(),
+					Text:     fmt.Sprintf(msgAggregation, name),
+					Severity: Bug,
+				})
+			}
+
 			if hasValue(annotation.Key.Value, annotation.Value.Value) && !hasHumanize(annotation.Key.Value, annotation.Value.Value) {
-				for _, problem := range c.checkHumanizeIsNeeded(rule.AlertingRule.Expr.Query) {
+				humanizeProblems := c.checkHumanizeIsNeeded(rule.AlertingRule.Expr.Query)
+				var fixes []TextEdit
+				if len(humanizeProblems) > 0 {
+					fixes = humanizeValueFixes(annotation)
+				}
+				for _, problem := range humanizeProblems {
 					problems = append(problems, Problem{
 						Fragment: problem.expr,
 						Lines:    mergeLines(annotation.Lines(), rule.AlertingRule.Expr.Lines()),
 						Reporter: c.Reporter(),
 						Text:     problem.text,
 						Severity: problem.severity,
+						Fixes:    fixes,
 					})
 				}
 			}
@@ -256,6 +295,111 @@ func (c TemplateCheck) checkHumanizeIsNeeded(node *parser.PromQLNode) (problems
 	return problems
 }
 
+// humanizeValueFixes returns edits piping every {{ $value }}, {{ .Value }}
+// and their aliases used in an annotation to humanize.
+func humanizeValueFixes(annotation *parser.YamlKeyValue) (fixes []TextEdit) {
+	prefix := strings.Join(templateDefs, "")
+	t, err := textTemplate.
+		New(annotation.Key.Value).
+		Funcs(templateFuncMap).
+		Option("missingkey=zero").
+		Parse(prefix + annotation.Value.Value)
+	if err != nil {
+		return nil
+	}
+	valueVars := aliasesForTemplate(t).varAliases(".Value")
+	for _, node := range t.Root.Nodes {
+		n, ok := node.(*parse.ActionNode)
+		if !ok || len(n.Pipe.Decl) > 0 || len(n.Pipe.Cmds) != 1 || len(n.Pipe.Cmds[0].Args) != 1 {
+			continue
+		}
+		arg := n.Pipe.Cmds[0].Args[0]
+		switch a := arg.(type) {
+		case *parse.VariableNode:
+			ok = len(a.Ident) == 1 && slices.Contains(valueVars, a.Ident[0])
+		case *parse.FieldNode:
+			ok = a.String() == ".Value"
+		default:
+			ok = false
+		}
+		if !ok {
+			continue
+		}
+		end := int(arg.Position()) - len(prefix) + len(arg.String())
+		if end <= 0 || end > len(annotation.Value.Value) {
+			continue
+		}
+		if fix, ok := nodeEdit(annotation.Value, end, end, " | humanize"); ok {
+			fixes = append(fixes, fix)
+		}
+	}
+	return fixes
+}
+
+// removeValueFixes returns edits removing every {{ ... }} action using
+// $value, .Value or their aliases from a label value.
+// If any other template node, like {{ if }} block, is using the value then
+// no edits are returned, since these can't be removed safely.
+func removeValueFixes(label *parser.YamlKeyValue) (fixes []TextEdit) {
+	prefix := strings.Join(templateDefs, "")
+	text := prefix + label.Value.Value
+	t, err := textTemplate.
+		New(label.Key.Value).
+		Funcs(templateFuncMap).
+		Option("missingkey=zero").
+		Parse(text)
+	if err != nil {
+		return nil
+	}
+	aliases := aliasesForTemplate(t)
+	nodes := t.Root.Nodes
+	for i, node := range nodes {
+		if int(node.Position()) < len(prefix) {
+			continue
+		}
+		if _, ok := containsAliasedNode(aliases, node, ".Value"); !ok && !declaresAlias(aliases, node, ".Value") {
+			continue
+		}
+		if _, ok := node.(*parse.ActionNode); !ok {
+			return nil
+		}
+		start := strings.LastIndex(text[:node.Position()], "{{")
+		end := len(text)
+		if i < len(nodes)-1 {
+			next := nodes[i+1]
+			end = int(next.Position())
+			if _, ok := next.(*parse.TextNode); !ok {
+				end = strings.LastIndex(text[:end], "{{")
+			}
+		}
+		if start < len(prefix) || end <= start {
+			return nil
+		}
+		fix, ok := nodeEdit(label.Value, start-len(prefix), end-len(prefix), "")
+		if !ok {
+			return nil
+		}
+		fixes = append(fixes, fix)
+	}
+	return fixes
+}
+
+// declaresAlias returns true if node is an action declaring a variable
+// that is an alias of given variable, like {{ $foo := $value }}.
+func declaresAlias(am aliasMap, node parse.Node, alias string) bool {
+	n, ok := node.(*parse.ActionNode)
+	if !ok {
+		return false
+	}
+	valAliases := am.varAliases(alias)
+	for _, d := range n.Pipe.Decl {
+		if len(d.Ident) == 1 && slices.Contains(valAliases, d.Ident[0]) {
+			return true
+		}
+	}
+	return false
+}
+
 func queryFunc(_ context.Context, expr string, _ time.Time) (promql.Vector, error) {
 	if _, err := promParser.ParseExpr(expr); err != nil {
 		return nil, err
@@ -500,6 +644,52 @@ func checkMetricLabels(msg, name, text string, metricLabels []string, excludeLab
 	return msgs
 }
 
+// checkInferredLabels returns names of all labels used in given template
+// that will never be present on the query results.
+func checkInferredLabels(name, text string, ls utils.LabelSet) (names []string) {
+	t, err := textTemplate.
+		New(name).
+		Funcs(templateFuncMap).
+		Option("missingkey=zero").
+		Parse(strings.Join(append(templateDefs, text), ""))
+	if err != nil {
+		// no need to double report errors
+		return nil
+	}
+
+	aliases := aliasMap{aliases: map[string]map[string]struct{}{}}
+	vars := [][]string{}
+	for _, node := range t.Root.Nodes {
+		getAliases(node, &aliases)
+		vars = append(vars, getVariables(node)...)
+	}
+
+	labelsAliases := aliases.varAliases(".Labels")
+	for _, v := range vars {
+		if len(v) < 2 || !slices.Contains(labelsAliases, v[0]) {
+			continue
+		}
+		if !ls.CanHave(v[1]) && !slices.Contains(names, v[1]) {
+			names = append(names, v[1])
+		}
+	}
+	return names
+}
+
+// hasLabelProblem returns true if a problem about missing label was already
+// reported for given fragment.
+func hasLabelProblem(problems []Problem, fragment, name string) bool {
+	for _, p := range problems {
+		if p.Fragment != fragment {
+			continue
+		}
+		if p.Text == fmt.Sprintf(msgAggregation, name) || p.Text == fmt.Sprintf(msgAbsent, name) {
+			return true
+		}
+	}
+	return false
+}
+
 func absentLabels(f utils.PromQLFragment) []string {
 	labelMap := map[string]struct{}{}
 
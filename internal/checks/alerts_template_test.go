@@ -154,6 +154,7 @@ func TestTemplateCheck(t *testing.T) {
 						Reporter: checks.TemplateCheckName,
 						Text:     "using $value in labels will generate a new alert on every value change, move it to annotations",
 						Severity: checks.Bug,
+						Fixes:    []checks.TextEdit{{Line: 5, Column: 11, EndLine: 5, EndColumn: 21, Text: ""}},
 					},
 				}
 			},
@@ -171,6 +172,7 @@ func TestTemplateCheck(t *testing.T) {
 						Reporter: checks.TemplateCheckName,
 						Text:     "using .Value in labels will generate a new alert on every value change, move it to annotations",
 						Severity: checks.Bug,
+						Fixes:    []checks.TextEdit{{Line: 4, Column: 11, EndLine: 4, EndColumn: 23, Text: ""}},
 					},
 					{
 						Fragment: "baz: {{$value}}",
@@ -178,6 +180,7 @@ func TestTemplateCheck(t *testing.T) {
 						Reporter: checks.TemplateCheckName,
 						Text:     "using $value in labels will generate a new alert on every value change, move it to annotations",
 						Severity: checks.Bug,
+						Fixes:    []checks.TextEdit{{Line: 5, Column: 11, EndLine: 5, EndColumn: 21, Text: ""}},
 					},
 				}
 			},
@@ -195,6 +198,7 @@ func TestTemplateCheck(t *testing.T) {
 						Reporter: checks.TemplateCheckName,
 						Text:     "using $value in labels will generate a new alert on every value change, move it to annotations",
 						Severity: checks.Bug,
+						Fixes:    []checks.TextEdit{{Line: 6, Column: 14, EndLine: 6, EndColumn: 48, Text: ""}},
 					},
 				}
 			},
@@ -212,6 +216,7 @@ func TestTemplateCheck(t *testing.T) {
 						Reporter: checks.TemplateCheckName,
 						Text:     "using $value in labels will generate a new alert on every value change, move it to annotations",
 						Severity: checks.Bug,
+						Fixes:    []checks.TextEdit{{Line: 6, Column: 14, EndLine: 6, EndColumn: 43, Text: ""}},
 					},
 				}
 			},
@@ -229,6 +234,7 @@ func TestTemplateCheck(t *testing.T) {
 						Reporter: checks.TemplateCheckName,
 						Text:     "using .Value in labels will generate a new alert on every value change, move it to annotations",
 						Severity: checks.Bug,
+						Fixes:    []checks.TextEdit{{Line: 5, Column: 17, EndLine: 5, EndColumn: 29, Text: ""}},
 					},
 				}
 			},
@@ -246,6 +252,7 @@ func TestTemplateCheck(t *testing.T) {
 						Reporter: checks.TemplateCheckName,
 						Text:     "using .Value in labels will generate a new alert on every value change, move it to annotations",
 						Severity: checks.Bug,
+						Fixes:    []checks.TextEdit{{Line: 5, Column: 11, EndLine: 5, EndColumn: 32, Text: ""}},
 					},
 				}
 			},
@@ -263,6 +270,7 @@ func TestTemplateCheck(t *testing.T) {
 						Reporter: checks.TemplateCheckName,
 						Text:     "using $foo in labels will generate a new alert on every value change, move it to annotations",
 						Severity: checks.Bug,
+						Fixes:    []checks.TextEdit{{Line: 5, Column: 11, EndLine: 5, EndColumn: 31, Text: ""}, {Line: 5, Column: 31, EndLine: 5, EndColumn: 41, Text: ""}},
 					},
 				}
 			},
@@ -280,6 +288,7 @@ func TestTemplateCheck(t *testing.T) {
 						Reporter: checks.TemplateCheckName,
 						Text:     "using $foo in labels will generate a new alert on every value change, move it to annotations",
 						Severity: checks.Bug,
+						Fixes:    []checks.TextEdit{{Line: 5, Column: 11, EndLine: 5, EndColumn: 31, Text: ""}, {Line: 5, Column: 31, EndLine: 5, EndColumn: 41, Text: ""}},
 					},
 				}
 			},
@@ -407,7 +416,7 @@ func TestTemplateCheck(t *testing.T) {
 			description: "annotation label missing from metrics (group_left)",
 			content: `
 - alert: Foo Is Down
-  expr: count(build_info) by (instance, version) != ignoring(package) group_left(foo) count(package_installed) by (instance, version, package)
+  expr: count(build_info) by (instance, version) != ignoring(package) group_left(foo) count(package_installed) by (instance, version, package, foo)
   annotations:
     summary: '{{ $labels.instance }} on {{ .Labels.foo }} is down'
     help: '{{ $labels.ixtance }}'
@@ -426,6 +435,94 @@ func TestTemplateCheck(t *testing.T) {
 				}
 			},
 		},
+		{
+			description: "annotation label missing from metrics (group_left without label)",
+			content: `
+- alert: Foo Is Down
+  expr: count(build_info) by (instance, version) != ignoring(package) group_left(foo) count(package_installed) by (instance, version, package)
+  annotations:
+    summary: '{{ $labels.instance }} on {{ .Labels.foo }} is down'
+`,
+			checker:    newTemplateCheck,
+			prometheus: noProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: `summary: {{ $labels.instance }} on {{ .Labels.foo }} is down`,
+						Lines:    []int{3, 5},
+						Reporter: checks.TemplateCheckName,
+						Text:     `template is using "foo" label but the query removes it`,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "annotation label removed by on()",
+			content: `
+- alert: Foo Is Down
+  expr: up{job="foo"} == on(job) bar
+  annotations:
+    summary: '{{ $labels.instance }} on {{ .Labels.job }} is down'
+`,
+			checker:    newTemplateCheck,
+			prometheus: noProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: `summary: {{ $labels.instance }} on {{ .Labels.job }} is down`,
+						Lines:    []int{3, 5},
+						Reporter: checks.TemplateCheckName,
+						Text:     `template is using "instance" label but the query removes it`,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "label removed by ignoring()",
+			content: `
+- alert: Foo Is Down
+  expr: up / ignoring(instance) bar
+  labels:
+    instance: '{{ $labels.instance }}'
+`,
+			checker:    newTemplateCheck,
+			prometheus: noProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: `instance: {{ $labels.instance }}`,
+						Lines:    []int{3, 5},
+						Reporter: checks.TemplateCheckName,
+						Text:     `template is using "instance" label but the query removes it`,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
+		{
+			description: "annotation label missing from vector()",
+			content: `
+- alert: Foo Is Down
+  expr: vector(1) > 0
+  annotations:
+    summary: '{{ $labels.instance }} is down'
+`,
+			checker:    newTemplateCheck,
+			prometheus: noProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: `summary: {{ $labels.instance }} is down`,
+						Lines:    []int{3, 5},
+						Reporter: checks.TemplateCheckName,
+						Text:     `template is using "instance" label but the query removes it`,
+						Severity: checks.Bug,
+					},
+				}
+			},
+		},
 		{
 			description: "don't trigger for label_replace() provided labels",
 			content: `
@@ -501,7 +598,7 @@ func TestTemplateCheck(t *testing.T) {
 			},
 		},
 		{
-			description: "annotation label present on metrics (absent(sum))",
+			description: "annotation label removed by absent(sum)",
 			content: `
 - alert: Foo Is Missing
   expr: absent(sum(foo) by(job, instance))
@@ -510,7 +607,24 @@ func TestTemplateCheck(t *testing.T) {
 `,
 			checker:    newTemplateCheck,
 			prometheus: noProm,
-			problems:   noProblems,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: `summary: {{ $labels.instance }} on {{ .Labels.job }} is missing`,
+						Lines:    []int{3, 5},
+						Reporter: checks.TemplateCheckName,
+						Text:     `template is using "instance" label but the query removes it`,
+						Severity: checks.Bug,
+					},
+					{
+						Fragment: `summary: {{ $labels.instance }} on {{ .Labels.job }} is missing`,
+						Lines:    []int{3, 5},
+						Reporter: checks.TemplateCheckName,
+						Text:     `template is using "job" label but the query removes it`,
+						Severity: checks.Bug,
+					},
+				}
+			},
 		},
 		{
 			description: "annotation label missing from metrics (absent(sum))",
@@ -531,6 +645,13 @@ func TestTemplateCheck(t *testing.T) {
 						Text:     `template is using "instance" label but the query removes it`,
 						Severity: checks.Bug,
 					},
+					{
+						Fragment: `summary: {{ $labels.instance }} on {{ .Labels.job }} is missing`,
+						Lines:    []int{3, 5},
+						Reporter: checks.TemplateCheckName,
+						Text:     `template is using "job" label but the query removes it`,
+						Severity: checks.Bug,
+					},
 				}
 			},
 		},
@@ -607,7 +728,24 @@ func TestTemplateCheck(t *testing.T) {
 `,
 			checker:    newTemplateCheck,
 			prometheus: noProm,
-			problems:   noProblems,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: `summary: {{ .Labels.job }} in cluster {{$labels.cluster}}/{{ $labels.env }} is missing`,
+						Lines:    []int{3, 5},
+						Reporter: checks.TemplateCheckName,
+						Text:     `template is using "cluster" label but the query removes it`,
+						Severity: checks.Bug,
+					},
+					{
+						Fragment: `summary: {{ .Labels.job }} in cluster {{$labels.cluster}}/{{ $labels.env }} is missing`,
+						Lines:    []int{3, 5},
+						Reporter: checks.TemplateCheckName,
+						Text:     `template is using "env" label but the query removes it`,
+						Severity: checks.Bug,
+					},
+				}
+			},
 		},
 		{
 			description: "bar * on() group_right(...) absent()",
@@ -631,7 +769,24 @@ func TestTemplateCheck(t *testing.T) {
 `,
 			checker:    newTemplateCheck,
 			prometheus: noProm,
-			problems:   noProblems,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: `summary: {{ .Labels.job }} in cluster {{$labels.cluster}}/{{ $labels.env }} is missing`,
+						Lines:    []int{3, 5},
+						Reporter: checks.TemplateCheckName,
+						Text:     `template is using "cluster" label but the query removes it`,
+						Severity: checks.Bug,
+					},
+					{
+						Fragment: `summary: {{ .Labels.job }} in cluster {{$labels.cluster}}/{{ $labels.env }} is missing`,
+						Lines:    []int{3, 5},
+						Reporter: checks.TemplateCheckName,
+						Text:     `template is using "env" label but the query removes it`,
+						Severity: checks.Bug,
+					},
+				}
+			},
 		},
 		{
 			description: "foo and on() absent(bar)",
@@ -663,6 +818,7 @@ func TestTemplateCheck(t *testing.T) {
 						Reporter: checks.TemplateCheckName,
 						Text:     humanizeText("rate(errors[2m])"),
 						Severity: checks.Information,
+						Fixes:    []checks.TextEdit{{Line: 5, Column: 31, EndLine: 5, EndColumn: 31, Text: " | humanize"}},
 					},
 				}
 			},
@@ -685,6 +841,7 @@ func TestTemplateCheck(t *testing.T) {
 						Reporter: checks.TemplateCheckName,
 						Text:     humanizeText("rate(errors[2m])"),
 						Severity: checks.Information,
+						Fixes:    []checks.TextEdit{{Line: 5, Column: 68, EndLine: 5, EndColumn: 68, Text: " | humanize"}},
 					},
 				}
 			},
@@ -707,6 +864,7 @@ func TestTemplateCheck(t *testing.T) {
 						Reporter: checks.TemplateCheckName,
 						Text:     humanizeText("irate(errors[2m])"),
 						Severity: checks.Information,
+						Fixes:    []checks.TextEdit{{Line: 5, Column: 31, EndLine: 5, EndColumn: 31, Text: " | humanize"}},
 					},
 				}
 			},
@@ -729,6 +887,7 @@ func TestTemplateCheck(t *testing.T) {
 						Reporter: checks.TemplateCheckName,
 						Text:     humanizeText("deriv(errors[2m])"),
 						Severity: checks.Information,
+						Fixes:    []checks.TextEdit{{Line: 5, Column: 31, EndLine: 5, EndColumn: 31, Text: " | humanize"}},
 					},
 				}
 			},
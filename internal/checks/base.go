@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
 	"github.com/cloudflare/pint/internal/discovery"
 	"github.com/cloudflare/pint/internal/parser"
 	"github.com/cloudflare/pint/internal/promapi"
@@ -35,6 +37,13 @@ var (
 		RuleLinkCheckName,
 		RejectCheckName,
 		OffsetCheckName,
+		GroupIntervalCheckName,
+		RuleCycleCheckName,
+		RuleOrphanCheckName,
+		AlertsDependencyCheckName,
+		RoutingLabelsCheckName,
+		RouteCheckName,
+		CommentsCheckName,
 	}
 	OnlineChecks = []string{
 		AlertsCheckName,
@@ -47,6 +56,7 @@ var (
 		SeriesCheckName,
 		RuleDuplicateCheckName,
 		RuleLinkCheckName,
+		GroupIntervalCheckName,
 	}
 )
 
@@ -108,6 +118,8 @@ type Problem struct {
 	Reporter string
 	Text     string
 	Severity Severity
+	Fixes    []TextEdit `json:",omitempty"`
+	Range    *TextRange `json:",omitempty"`
 }
 
 func (p Problem) LineRange() (int, int) {
@@ -129,6 +141,8 @@ type exprProblem struct {
 	expr     string
 	text     string
 	severity Severity
+	fixes    []TextEdit
+	node     promParser.Node
 }
 
 func textAndSeverityFromError(err error, reporter, prom string, s Severity) (text string, severity Severity) {
@@ -0,0 +1,120 @@
+package checks
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/cloudflare/pint/internal/output"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+// TextEdit is a single text replacement that fixes a reported problem.
+// Line and column numbers start at 1, end position is exclusive.
+type TextEdit struct {
+	Line      int
+	Column    int
+	EndLine   int
+	EndColumn int
+	Text      string
+}
+
+// overlaps returns true if other edit, which must not start before this one,
+// begins before this edit ends.
+func (te TextEdit) overlaps(other TextEdit) bool {
+	return other.Line < te.EndLine || (other.Line == te.EndLine && other.Column < te.EndColumn)
+}
+
+// exprEdit returns a TextEdit replacing bytes between start and end offsets
+// of the query string with given text.
+func exprEdit(expr parser.PromQLExpr, start, end int, text string) (te TextEdit, ok bool) {
+	return nodeEdit(expr.Value, start, end, text)
+}
+
+// nodeEdit returns a TextEdit replacing bytes between start and end offsets
+// of the YAML node value with given text.
+// Some scalars, like double quoted strings with escape sequences, cannot be
+// mapped back to the file and so no edit can be safely made.
+func nodeEdit(node *parser.YamlNode, start, end int, text string) (te TextEdit, ok bool) {
+	begin, ok := node.PositionAt(start)
+	if ok {
+		var finish parser.TextPosition
+		if finish, ok = node.PositionAt(end); ok {
+			return TextEdit{
+				Line:      begin.Line,
+				Column:    begin.Column,
+				EndLine:   finish.Line,
+				EndColumn: finish.Column,
+				Text:      text,
+			}, true
+		}
+	}
+	log.Debug().
+		Str("value", node.Value).
+		Str("lines", output.FormatLineRangeString(node.Position.Lines)).
+		Msg("Skipping fix because this YAML value cannot be safely edited")
+	return te, false
+}
+
+// ApplyTextEdits applies all edits to given content and returns modified
+// content and the list of edits that were applied. Duplicated edits are
+// applied only once and edits overlapping with other edits are skipped.
+func ApplyTextEdits(content []byte, edits []TextEdit) ([]byte, []TextEdit) {
+	sorted := make([]TextEdit, 0, len(edits))
+	for _, edit := range edits {
+		var isDup bool
+		for _, s := range sorted {
+			if s == edit {
+				isDup = true
+				break
+			}
+		}
+		if !isDup {
+			sorted = append(sorted, edit)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Line != sorted[j].Line {
+			return sorted[i].Line < sorted[j].Line
+		}
+		return sorted[i].Column < sorted[j].Column
+	})
+
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	offset := func(line, column int) int {
+		if line < 1 || line > len(lines) || column < 1 || column > len(lines[line-1])+1 {
+			return -1
+		}
+		var o int
+		for _, l := range lines[:line-1] {
+			o += len(l)
+		}
+		return o + column - 1
+	}
+
+	applied := make([]TextEdit, 0, len(sorted))
+	for _, edit := range sorted {
+		if offset(edit.Line, edit.Column) < 0 || offset(edit.EndLine, edit.EndColumn) < offset(edit.Line, edit.Column) {
+			continue
+		}
+		if len(applied) > 0 && applied[len(applied)-1].overlaps(edit) {
+			continue
+		}
+		applied = append(applied, edit)
+	}
+
+	// Apply edits from the end of the file so that earlier offsets remain valid.
+	out := content
+	for i := len(applied) - 1; i >= 0; i-- {
+		edit := applied[i]
+		start, end := offset(edit.Line, edit.Column), offset(edit.EndLine, edit.EndColumn)
+		buf := make([]byte, 0, len(out)-(end-start)+len(edit.Text))
+		buf = append(buf, out[:start]...)
+		buf = append(buf, edit.Text...)
+		buf = append(buf, out[end:]...)
+		out = buf
+	}
+
+	return out, applied
+}
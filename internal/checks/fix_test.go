@@ -0,0 +1,70 @@
+package checks_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+func TestApplyTextEdits(t *testing.T) {
+	type testCaseT struct {
+		content string
+		edits   []checks.TextEdit
+		output  string
+		applied int
+	}
+
+	testCases := []testCaseT{
+		{
+			content: "- record: foo\n  expr: foo{job=~\"bar\"}\n",
+			output:  "- record: foo\n  expr: foo{job=~\"bar\"}\n",
+		},
+		{
+			content: "- record: foo\n  expr: foo{job=~\"bar\"}\n",
+			edits:   []checks.TextEdit{{Line: 2, Column: 16, EndLine: 2, EndColumn: 18, Text: "="}},
+			output:  "- record: foo\n  expr: foo{job=\"bar\"}\n",
+			applied: 1,
+		},
+		{
+			content: "- record: foo\n  expr: foo{job=~\"bar\"} / foo{job=~\"bar\"}\n",
+			edits: []checks.TextEdit{
+				{Line: 2, Column: 34, EndLine: 2, EndColumn: 36, Text: "="},
+				{Line: 2, Column: 16, EndLine: 2, EndColumn: 18, Text: "="},
+				{Line: 2, Column: 16, EndLine: 2, EndColumn: 18, Text: "="},
+			},
+			output:  "- record: foo\n  expr: foo{job=\"bar\"} / foo{job=\"bar\"}\n",
+			applied: 2,
+		},
+		{
+			content: "- record: foo\n  expr: sum(foo) by(job)\n",
+			edits: []checks.TextEdit{
+				{Line: 2, Column: 17, EndLine: 2, EndColumn: 25, Text: ""},
+				{Line: 2, Column: 21, EndLine: 2, EndColumn: 24, Text: "instance"},
+			},
+			output:  "- record: foo\n  expr: sum(foo)\n",
+			applied: 1,
+		},
+		{
+			content: "- record: foo\n  expr: |\n    sum(foo)\n    by(job)\n",
+			edits:   []checks.TextEdit{{Line: 3, Column: 13, EndLine: 4, EndColumn: 12, Text: ""}},
+			output:  "- record: foo\n  expr: |\n    sum(foo)\n",
+			applied: 1,
+		},
+		{
+			content: "- record: foo\n  expr: sum(foo)\n",
+			edits:   []checks.TextEdit{{Line: 5, Column: 1, EndLine: 5, EndColumn: 2, Text: ""}},
+			output:  "- record: foo\n  expr: sum(foo)\n",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i+1), func(t *testing.T) {
+			output, applied := checks.ApplyTextEdits([]byte(tc.content), tc.edits)
+			require.Equal(t, tc.output, string(output))
+			require.Len(t, applied, tc.applied)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/common/model"
+	promParser "github.com/prometheus/prometheus/promql/parser"
+	"golang.org/x/exp/slices"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/output"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+const (
+	GroupIntervalCheckName = "group/interval"
+)
+
+func NewGroupIntervalCheck(prom *promapi.FailoverGroup) GroupIntervalCheck {
+	return GroupIntervalCheck{prom: prom, minIntervals: 2}
+}
+
+type GroupIntervalCheck struct {
+	prom         *promapi.FailoverGroup
+	minIntervals int
+}
+
+func (c GroupIntervalCheck) Meta() CheckMeta {
+	return CheckMeta{IsOnline: true}
+}
+
+func (c GroupIntervalCheck) String() string {
+	return fmt.Sprintf("%s(%s)", GroupIntervalCheckName, c.prom.Name())
+}
+
+func (c GroupIntervalCheck) Reporter() string {
+	return GroupIntervalCheckName
+}
+
+func (c GroupIntervalCheck) Check(ctx context.Context, path string, rule parser.Rule, entries []discovery.Entry) (problems []Problem) {
+	if rule.Error.Err != nil {
+		return nil
+	}
+
+	expr := rule.Expr()
+	if expr.SyntaxError != nil {
+		return nil
+	}
+
+	cfg, err := c.prom.Config(ctx)
+	if err != nil {
+		text, severity := textAndSeverityFromError(err, c.Reporter(), c.prom.Name(), Warning)
+		problems = append(problems, Problem{
+			Fragment: expr.Value.Value,
+			Lines:    expr.Lines(),
+			Reporter: c.Reporter(),
+			Text:     text,
+			Severity: severity,
+		})
+		return problems
+	}
+
+	interval := cfg.Config.Global.EvaluationInterval
+	source := fmt.Sprintf("global evaluation_interval on %s", promText(c.prom.Name(), cfg.URI))
+
+	if rule.Group != nil && rule.Group.Interval != nil {
+		isFirst, modified := isFirstRuleInGroup(path, rule, entries)
+		lines := appendLines(rule.Group.Interval.Lines(), ruleNameLines(rule)...)
+		if len(modified) > 0 && !slices.ContainsFunc(lines, func(l int) bool { return slices.Contains(modified, l) }) {
+			// Group problems must be reported on a modified line, or they would be
+			// hidden when running pint ci.
+			lines = appendLines(lines, modified[0])
+		}
+
+		dur, err := model.ParseDuration(rule.Group.Interval.Value.Value)
+		if err != nil {
+			if isFirst {
+				problems = append(problems, Problem{
+					Fragment: rule.Group.Interval.Value.Value,
+					Lines:    lines,
+					Reporter: c.Reporter(),
+					Text:     fmt.Sprintf("invalid interval on %q rule group: %s", rule.Group.GetName(), err),
+					Severity: Bug,
+				})
+			}
+			return problems
+		}
+
+		if time.Duration(dur) < interval && isFirst {
+			problems = append(problems, Problem{
+				Fragment: rule.Group.Interval.Value.Value,
+				Lines:    lines,
+				Reporter: c.Reporter(),
+				Text: fmt.Sprintf("%q rule group is using %s interval which is shorter than %s global evaluation_interval configured on %s",
+					rule.Group.GetName(), output.HumanizeDuration(time.Duration(dur)),
+					output.HumanizeDuration(interval), promText(c.prom.Name(), cfg.URI)),
+				Severity: Warning,
+			})
+		}
+
+		interval = time.Duration(dur)
+		source = fmt.Sprintf("%q rule group interval", rule.Group.GetName())
+	}
+
+	for _, problem := range c.checkNode(expr.Query, interval, source) {
+		problems = append(problems, Problem{
+			Fragment: problem.expr,
+			Lines:    expr.Lines(),
+			Reporter: c.Reporter(),
+			Text:     problem.text,
+			Severity: problem.severity,
+		})
+	}
+
+	return problems
+}
+
+func (c GroupIntervalCheck) checkNode(node *parser.PromQLNode, interval time.Duration, source string) (problems []exprProblem) {
+	if n, ok := node.Node.(*promParser.Call); ok && (n.Func.Name == "rate" || n.Func.Name == "irate" || n.Func.Name == "increase") {
+		for _, arg := range n.Args {
+			m, ok := arg.(*promParser.MatrixSelector)
+			if !ok {
+				continue
+			}
+			if m.Range < interval*time.Duration(c.minIntervals) {
+				problems = append(problems, exprProblem{
+					expr: node.Expr,
+					text: fmt.Sprintf("duration for %s() must be at least %d x rule evaluation interval, this rule is evaluated every %s according to %s",
+						n.Func.Name, c.minIntervals, output.HumanizeDuration(interval), source),
+					severity: Warning,
+				})
+			}
+		}
+	}
+
+	for _, child := range node.Children {
+		problems = append(problems, c.checkNode(child, interval, source)...)
+	}
+
+	return problems
+}
+
+// isFirstRuleInGroup returns true if rule is the first rule in its group that
+// wasn't removed, together with the modified lines of that rule.
+// Group level problems are only reported once, on the first rule.
+func isFirstRuleInGroup(path string, rule parser.Rule, entries []discovery.Entry) (isFirst bool, modified []int) {
+	first := rule.Lines()[0]
+	isFirst = true
+	for _, entry := range entries {
+		if entry.PathError != nil || entry.ReportedPath != path || entry.Rule.Group == nil || entry.State == discovery.Removed {
+			continue
+		}
+		if !slices.Equal(entry.Rule.Group.Position.Lines, rule.Group.Position.Lines) {
+			continue
+		}
+		lines := entry.Rule.Lines()
+		if len(lines) == 0 {
+			continue
+		}
+		if lines[0] < first {
+			isFirst = false
+		}
+		if lines[0] == first {
+			modified = entry.ModifiedLines
+		}
+	}
+	return isFirst, modified
+}
+
+func ruleNameLines(rule parser.Rule) []int {
+	if rule.AlertingRule != nil {
+		return rule.AlertingRule.Alert.Lines()
+	}
+	return rule.RecordingRule.Record.Lines()
+}
+
+func appendLines(lines []int, extra ...int) []int {
+	out := make([]int, 0, len(lines)+len(extra))
+	out = append(out, lines...)
+	for _, l := range extra {
+		if !slices.Contains(out, l) {
+			out = append(out, l)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
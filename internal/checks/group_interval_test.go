@@ -0,0 +1,361 @@
+package checks_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newGroupIntervalCheck(prom *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewGroupIntervalCheck(prom)
+}
+
+func groupIntervalTooShort(group, interval, global, name, uri string) string {
+	return fmt.Sprintf("%q rule group is using %s interval which is shorter than %s global evaluation_interval configured on prometheus %q at %s",
+		group, interval, global, name, uri)
+}
+
+func groupRangeTooShort(fn, interval, source string) string {
+	return fmt.Sprintf("duration for %s() must be at least 2 x rule evaluation interval, this rule is evaluated every %s according to %s",
+		fn, interval, source)
+}
+
+func TestGroupIntervalCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: sum(foo) without(\n",
+			checker:     newGroupIntervalCheck,
+			prometheus:  newSimpleProm,
+			problems:    noProblems,
+		},
+		{
+			description: "config query error",
+			content:     "- record: foo\n  expr: rate(foo[5m])\n",
+			checker:     newGroupIntervalCheck,
+			prometheus:  newSimpleProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "rate(foo[5m])",
+						Lines:    []int{2},
+						Reporter: "group/interval",
+						Text:     checkErrorUnableToRun(checks.GroupIntervalCheckName, "prom", uri, "server_error: internal error"),
+						Severity: checks.Bug,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  respondWithInternalError(),
+				},
+			},
+		},
+		{
+			description: "config query error, not strict",
+			content:     "- record: foo\n  expr: rate(foo[5m])\n",
+			checker:     newGroupIntervalCheck,
+			prometheus: func(uri string) *promapi.FailoverGroup {
+				return simpleProm("prom", uri, time.Second*5, false)
+			},
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "rate(foo[5m])",
+						Lines:    []int{2},
+						Reporter: "group/interval",
+						Text:     checkErrorUnableToRun(checks.GroupIntervalCheckName, "prom", uri, "server_error: internal error"),
+						Severity: checks.Warning,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  respondWithInternalError(),
+				},
+			},
+		},
+		{
+			description: "no group, rate range is fine",
+			content:     "- record: foo\n  expr: rate(foo[2m])\n",
+			checker:     newGroupIntervalCheck,
+			prometheus:  newSimpleProm,
+			problems:    noProblems,
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  evaluation_interval: 1m\n"},
+				},
+			},
+		},
+		{
+			description: "no group, rate range is too short",
+			content:     "- record: foo\n  expr: rate(foo[1m])\n",
+			checker:     newGroupIntervalCheck,
+			prometheus:  newSimpleProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "rate(foo[1m])",
+						Lines:    []int{2},
+						Reporter: "group/interval",
+						Text:     groupRangeTooShort("rate", "1m", fmt.Sprintf("global evaluation_interval on prometheus %q at %s", "prom", uri)),
+						Severity: checks.Warning,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  evaluation_interval: 1m\n"},
+				},
+			},
+		},
+		{
+			description: "group without interval uses global evaluation_interval",
+			content: `
+groups:
+- name: foo
+  rules:
+  - record: foo
+    expr: sum(increase(foo[1m]))
+`,
+			checker:    newGroupIntervalCheck,
+			prometheus: newSimpleProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "increase(foo[1m])",
+						Lines:    []int{6},
+						Reporter: "group/interval",
+						Text:     groupRangeTooShort("increase", "2m", fmt.Sprintf("global evaluation_interval on prometheus %q at %s", "prom", uri)),
+						Severity: checks.Warning,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  evaluation_interval: 2m\n"},
+				},
+			},
+		},
+		{
+			description: "group interval longer than rate range",
+			content: `
+groups:
+- name: foo
+  interval: 5m
+  rules:
+  - record: foo
+    expr: rate(foo[5m]) / irate(foo[10m])
+`,
+			checker:    newGroupIntervalCheck,
+			prometheus: newSimpleProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "rate(foo[5m])",
+						Lines:    []int{7},
+						Reporter: "group/interval",
+						Text:     groupRangeTooShort("rate", "5m", `"foo" rule group interval`),
+						Severity: checks.Warning,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  evaluation_interval: 1m\n"},
+				},
+			},
+		},
+		{
+			description: "group interval shorter than evaluation_interval",
+			content: `
+groups:
+- name: foo
+  interval: 15s
+  rules:
+  - alert: foo
+    expr: rate(foo[1m]) > 0
+`,
+			checker:    newGroupIntervalCheck,
+			prometheus: newSimpleProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "15s",
+						Lines:    []int{4, 6},
+						Reporter: "group/interval",
+						Text:     groupIntervalTooShort("foo", "15s", "1m", "prom", uri),
+						Severity: checks.Warning,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  evaluation_interval: 1m\n"},
+				},
+			},
+		},
+		{
+			description: "group interval is only reported on the first rule",
+			content: `
+groups:
+- name: foo
+  interval: 15s
+  rules:
+  # foo rule would be here
+  #
+  - record: bar
+    expr: sum(bar)
+`,
+			checker:    newGroupIntervalCheck,
+			prometheus: newSimpleProm,
+			entries: mustParseContent(`
+groups:
+- name: foo
+  interval: 15s
+  rules:
+  - record: foo
+    expr: sum(foo)
+  - record: bar
+    expr: sum(bar)
+`),
+			problems: noProblems,
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  evaluation_interval: 1m\n"},
+				},
+			},
+		},
+		{
+			description: "group interval is reported on the first rule that wasn't removed",
+			content: `
+groups:
+- name: foo
+  interval: 15s
+  rules:
+  # foo rule would be here
+  #
+  - record: bar
+    expr: sum(bar)
+`,
+			checker:    newGroupIntervalCheck,
+			prometheus: newSimpleProm,
+			entries: func() []discovery.Entry {
+				entries := mustParseContent(`
+groups:
+- name: foo
+  interval: 15s
+  rules:
+  - record: foo
+    expr: sum(foo)
+  - record: bar
+    expr: sum(bar)
+`)
+				entries[0].State = discovery.Removed
+				return entries
+			}(),
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "15s",
+						Lines:    []int{4, 8},
+						Reporter: "group/interval",
+						Text:     groupIntervalTooShort("foo", "15s", "1m", "prom", uri),
+						Severity: checks.Warning,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  evaluation_interval: 1m\n"},
+				},
+			},
+		},
+		{
+			description: "group interval is reported on a modified line",
+			content: `
+groups:
+- name: foo
+  interval: 15s
+  rules:
+  - record: foo
+    expr: sum(foo)
+`,
+			checker:    newGroupIntervalCheck,
+			prometheus: newSimpleProm,
+			entries: func() []discovery.Entry {
+				entries := mustParseContent(`
+groups:
+- name: foo
+  interval: 15s
+  rules:
+  - record: foo
+    expr: sum(foo)
+`)
+				entries[0].ModifiedLines = []int{7}
+				return entries
+			}(),
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "15s",
+						Lines:    []int{4, 6, 7},
+						Reporter: "group/interval",
+						Text:     groupIntervalTooShort("foo", "15s", "1m", "prom", uri),
+						Severity: checks.Warning,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  evaluation_interval: 1m\n"},
+				},
+			},
+		},
+		{
+			description: "invalid group interval",
+			content: `
+groups:
+- name: foo
+  interval: abc
+  rules:
+  - record: foo
+    expr: rate(foo[1m])
+`,
+			checker:    newGroupIntervalCheck,
+			prometheus: newSimpleProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "abc",
+						Lines:    []int{4, 6},
+						Reporter: "group/interval",
+						Text:     `invalid interval on "foo" rule group: not a valid duration string: "abc"`,
+						Severity: checks.Bug,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  evaluation_interval: 1m\n"},
+				},
+			},
+		},
+	}
+	runTests(t, testCases)
+}
@@ -0,0 +1,185 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	CommentsCheckName = "pint/comments"
+
+	disableComment     = "disable"
+	snoozeComment      = "snooze"
+	ruleSetComment     = "rule/set"
+	ignoreFileComment  = "ignore/file"
+	ignoreLineComment  = "ignore/line"
+	ignoreNextComment  = "ignore/next-line"
+	ignoreBeginComment = "ignore/begin"
+	ignoreEndComment   = "ignore/end"
+)
+
+// NewCommentsCheck creates a check validating all "# pint ..." comments.
+// available is the list of all check names and instances configured for
+// the rule, it's used to tell if a disable or snooze comment has any effect.
+func NewCommentsCheck(available []string) CommentsCheck {
+	return CommentsCheck{available: available}
+}
+
+type CommentsCheck struct {
+	available []string
+}
+
+func (c CommentsCheck) Meta() CheckMeta {
+	return CheckMeta{IsOnline: false}
+}
+
+func (c CommentsCheck) String() string {
+	return CommentsCheckName
+}
+
+func (c CommentsCheck) Reporter() string {
+	return CommentsCheckName
+}
+
+func (c CommentsCheck) Check(_ context.Context, path string, rule parser.Rule, entries []discovery.Entry) (problems []Problem) {
+	if rule.Error.Err != nil {
+		return nil
+	}
+
+	var comments []parser.ControlComment
+	var isKnown bool
+	for _, entry := range entries {
+		if entry.ReportedPath != path || entry.State == discovery.Removed {
+			continue
+		}
+		if entry.PathError == nil && entry.Rule.Error.Err == nil && entry.Rule.IsSame(rule) {
+			// Discovery attaches to every rule all pint comments that belong to it.
+			comments = entry.Comments
+			isKnown = true
+			break
+		}
+	}
+	if !isKnown {
+		return nil
+	}
+
+	for _, cmt := range comments {
+		var text string
+		severity := Warning
+		switch cmt.Key {
+		case ignoreFileComment, ignoreLineComment, ignoreNextComment, ignoreBeginComment, ignoreEndComment:
+			switch {
+			case cmt.Value != "":
+				text = fmt.Sprintf("`%s` comment doesn't accept any arguments and will be ignored", cmt.Key)
+			case cmt.Unmatched && cmt.Key == ignoreBeginComment:
+				text = fmt.Sprintf("`%s` comment without matching `%s`, everything below it will be ignored", ignoreBeginComment, ignoreEndComment)
+			case cmt.Unmatched:
+				text = fmt.Sprintf("`%s` comment without matching `%s`, it has no effect", ignoreEndComment, ignoreBeginComment)
+			}
+		case discovery.FileOwnerComment, discovery.RuleOwnerComment:
+			if cmt.Value == "" {
+				text = fmt.Sprintf("`%s` comment requires an owner name", cmt.Key)
+			}
+		case discovery.FileDisabledCheckComment:
+			text = c.checkTarget(cmt.Key, cmt.Value)
+		case disableComment:
+			text = c.checkTarget(cmt.Key, cmt.Value)
+			if text == "" {
+				text, severity = c.checkRuleComment(rule, cmt, cmt.Value)
+			}
+		case discovery.FileSnoozeCheckComment, snoozeComment, discovery.RuleSnoozeCheckComment:
+			switch {
+			case parser.ParseSnooze(cmt.Value) != nil:
+				s := parser.ParseSnooze(cmt.Value)
+				text = c.checkTarget(cmt.Key, s.Text)
+				if text == "" && cmt.Key != discovery.FileSnoozeCheckComment {
+					text, severity = c.checkRuleComment(rule, cmt, s.Text)
+				}
+			case parser.ParseExpiredSnooze(cmt.Value) != nil:
+				// Expired rule snoozes are already reported as rule/snooze problems.
+				if cmt.Key == discovery.FileSnoozeCheckComment {
+					s := parser.ParseExpiredSnooze(cmt.Value)
+					text = fmt.Sprintf("`%s` comment expired on %s and no longer has any effect, it can be removed", cmt.Key, s.Until.Format(time.RFC3339))
+					severity = Information
+				}
+			default:
+				text = fmt.Sprintf("`%s` comment must use `# pint %s $TIMESTAMP $CHECK` syntax, where $TIMESTAMP is either YYYY-MM-DD or RFC3339 formatted", cmt.Key, cmt.Key)
+			}
+		case ruleSetComment:
+			name, _, _ := strings.Cut(cmt.Value, " ")
+			text = c.checkTarget(cmt.Key, name)
+		default:
+			text = fmt.Sprintf("`%s` is not a valid pint comment", commentText(cmt))
+		}
+
+		if text == "" {
+			continue
+		}
+		problems = append(problems, Problem{
+			Fragment: commentText(cmt),
+			Lines:    []int{cmt.Line},
+			Reporter: c.Reporter(),
+			Text:     text,
+			Severity: severity,
+		})
+	}
+
+	return problems
+}
+
+// checkTarget verifies that the check name used in a comment is valid.
+func (c CommentsCheck) checkTarget(key, target string) string {
+	if target == "" {
+		return fmt.Sprintf("`%s` comment requires a check name", key)
+	}
+	name, _, _ := strings.Cut(target, "(")
+	if !slices.Contains(CheckNames, name) && !slices.Contains(c.available, name) {
+		return fmt.Sprintf("`%s` comment references unknown check %q", key, name)
+	}
+	return ""
+}
+
+// checkRuleComment verifies that a rule level comment is attached to a rule
+// and that it disables a check that would otherwise run on that rule.
+func (c CommentsCheck) checkRuleComment(rule parser.Rule, cmt parser.ControlComment, target string) (string, Severity) {
+	if !hasRuleComment(rule, cmt) {
+		return fmt.Sprintf("`%s` comment is not attached to any rule and has no effect, it must be placed directly above the rule or inside it", cmt.Key), Warning
+	}
+
+	if slices.Contains(c.available, target) {
+		return "", Information
+	}
+	if name, sel, ok := strings.Cut(strings.TrimSuffix(target, ")"), "("); ok && name == SeriesCheckName && slices.Contains(c.available, name) {
+		if expr := rule.Expr(); expr.Query != nil {
+			for _, selector := range getSelectors(expr.Query) {
+				if isSelectorMatching(sel, selector) {
+					return "", Information
+				}
+			}
+		}
+	}
+	return fmt.Sprintf("`%s` comment has no effect because %s check is not enabled for this rule", cmt.Key, target), Warning
+}
+
+func hasRuleComment(rule parser.Rule, cmt parser.ControlComment) bool {
+	for _, rc := range rule.GetComments(cmt.Key) {
+		if rc.Value == cmt.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func commentText(cmt parser.ControlComment) string {
+	if cmt.Value == "" {
+		return "# pint " + cmt.Key
+	}
+	return "# pint " + cmt.String()
+}
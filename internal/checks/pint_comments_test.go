@@ -0,0 +1,359 @@
+package checks_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newCommentsCheck(available ...string) func(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return func(_ *promapi.FailoverGroup) checks.RuleChecker {
+		return checks.NewCommentsCheck(available)
+	}
+}
+
+// mustParseContentWithComments uses discovery to attach pint comments
+// to the rules they belong to.
+func mustParseContentWithComments(content string) []discovery.Entry {
+	entries, err := discovery.ReadRules("fake.yml", "fake.yml", strings.NewReader(content), false)
+	if err != nil {
+		panic(err)
+	}
+	return entries
+}
+
+func TestCommentsCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "no comments",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newCommentsCheck(),
+			prometheus:  noProm,
+			entries:     mustParseContentWithComments("- record: foo\n  expr: sum(foo)\n"),
+			problems:    noProblems,
+		},
+		{
+			description: "valid comments",
+			content: `
+# pint file/owner bob
+# pint file/disable promql/series
+# pint ignore/begin
+# pint ignore/end
+# pint rule/owner alice
+# pint disable promql/series(prom)
+# pint snooze 2099-01-01 promql/series
+# pint rule/set promql/series min-age 1w
+- record: foo
+  expr: sum(foo) # pint ignore/line
+`,
+			checker:    newCommentsCheck(checks.SeriesCheckName, "promql/series(prom)"),
+			prometheus: noProm,
+			entries: mustParseContentWithComments(`
+# pint file/owner bob
+# pint file/disable promql/series
+# pint ignore/begin
+# pint ignore/end
+# pint rule/owner alice
+# pint disable promql/series(prom)
+# pint snooze 2099-01-01 promql/series
+# pint rule/set promql/series min-age 1w
+- record: foo
+  expr: sum(foo) # pint ignore/line
+`),
+			problems: noProblems,
+		},
+		{
+			description: "unknown comment",
+			content:     "# pint disabel promql/series\n- record: foo\n  expr: sum(foo)\n",
+			checker:     newCommentsCheck(),
+			prometheus:  noProm,
+			entries:     mustParseContentWithComments("# pint disabel promql/series\n- record: foo\n  expr: sum(foo)\n"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "# pint disabel promql/series",
+						Lines:    []int{1},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`# pint disabel promql/series` is not a valid pint comment",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "unknown check",
+			content:     "# pint disable promql/foo\n# pint file/disable promql/bar\n- record: foo\n  expr: sum(foo)\n",
+			checker:     newCommentsCheck(),
+			prometheus:  noProm,
+			entries:     mustParseContentWithComments("# pint disable promql/foo\n# pint file/disable promql/bar\n- record: foo\n  expr: sum(foo)\n"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "# pint disable promql/foo",
+						Lines:    []int{1},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`disable` comment references unknown check \"promql/foo\"",
+						Severity: checks.Warning,
+					},
+					{
+						Fragment: "# pint file/disable promql/bar",
+						Lines:    []int{2},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`file/disable` comment references unknown check \"promql/bar\"",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "missing values",
+			content:     "# pint disable\n# pint rule/owner\n# pint rule/set\n- record: foo\n  expr: sum(foo)\n",
+			checker:     newCommentsCheck(),
+			prometheus:  noProm,
+			entries:     mustParseContentWithComments("# pint disable\n# pint rule/owner\n# pint rule/set\n- record: foo\n  expr: sum(foo)\n"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "# pint disable",
+						Lines:    []int{1},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`disable` comment requires a check name",
+						Severity: checks.Warning,
+					},
+					{
+						Fragment: "# pint rule/owner",
+						Lines:    []int{2},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`rule/owner` comment requires an owner name",
+						Severity: checks.Warning,
+					},
+					{
+						Fragment: "# pint rule/set",
+						Lines:    []int{3},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`rule/set` comment requires a check name",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "ignore comments with arguments",
+			content:     "- record: foo # pint ignore/line foo\n  expr: sum(foo)\n",
+			checker:     newCommentsCheck(),
+			prometheus:  noProm,
+			entries:     mustParseContentWithComments("- record: foo # pint ignore/line foo\n  expr: sum(foo)\n"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "# pint ignore/line foo",
+						Lines:    []int{1},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`ignore/line` comment doesn't accept any arguments and will be ignored",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "unmatched ignore/end",
+			content:     "# pint ignore/end\n- record: foo\n  expr: sum(foo)\n",
+			checker:     newCommentsCheck(),
+			prometheus:  noProm,
+			entries:     mustParseContentWithComments("# pint ignore/end\n- record: foo\n  expr: sum(foo)\n"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "# pint ignore/end",
+						Lines:    []int{1},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`ignore/end` comment without matching `ignore/begin`, it has no effect",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "unmatched ignore/begin",
+			content:     "- record: foo\n  expr: sum(foo)\n# pint ignore/begin\n",
+			checker:     newCommentsCheck(),
+			prometheus:  noProm,
+			entries:     mustParseContentWithComments("- record: foo\n  expr: sum(foo)\n# pint ignore/begin\n"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "# pint ignore/begin",
+						Lines:    []int{3},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`ignore/begin` comment without matching `ignore/end`, everything below it will be ignored",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "invalid snooze",
+			content:     "# pint snooze promql/series\n# pint rule/snooze 2099-13-01 promql/series\n- record: foo\n  expr: sum(foo)\n",
+			checker:     newCommentsCheck(checks.SeriesCheckName),
+			prometheus:  noProm,
+			entries:     mustParseContentWithComments("# pint snooze promql/series\n# pint rule/snooze 2099-13-01 promql/series\n- record: foo\n  expr: sum(foo)\n"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "# pint snooze promql/series",
+						Lines:    []int{1},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`snooze` comment must use `# pint snooze $TIMESTAMP $CHECK` syntax, where $TIMESTAMP is either YYYY-MM-DD or RFC3339 formatted",
+						Severity: checks.Warning,
+					},
+					{
+						Fragment: "# pint rule/snooze 2099-13-01 promql/series",
+						Lines:    []int{2},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`rule/snooze` comment must use `# pint rule/snooze $TIMESTAMP $CHECK` syntax, where $TIMESTAMP is either YYYY-MM-DD or RFC3339 formatted",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "expired file/snooze",
+			content:     "# pint file/snooze 2000-01-01 promql/series\n- record: foo\n  expr: sum(foo)\n",
+			checker:     newCommentsCheck(checks.SeriesCheckName),
+			prometheus:  noProm,
+			entries:     mustParseContentWithComments("# pint file/snooze 2000-01-01 promql/series\n- record: foo\n  expr: sum(foo)\n"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "# pint file/snooze 2000-01-01 promql/series",
+						Lines:    []int{1},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`file/snooze` comment expired on 2000-01-01T00:00:00Z and no longer has any effect, it can be removed",
+						Severity: checks.Information,
+					},
+				}
+			},
+		},
+		{
+			description: "expired rule snooze is ignored",
+			content:     "# pint snooze 2000-01-01 promql/series\n- record: foo\n  expr: sum(foo)\n",
+			checker:     newCommentsCheck(checks.SeriesCheckName),
+			prometheus:  noProm,
+			entries:     mustParseContentWithComments("# pint snooze 2000-01-01 promql/series\n- record: foo\n  expr: sum(foo)\n"),
+			problems:    noProblems,
+		},
+		{
+			description: "disable check that is not enabled",
+			content:     "# pint disable promql/series(prom)\n# pint rule/snooze 2099-01-01 promql/rate\n- record: foo\n  expr: sum(foo)\n",
+			checker:     newCommentsCheck(checks.SeriesCheckName, "promql/series(other)"),
+			prometheus:  noProm,
+			entries:     mustParseContentWithComments("# pint disable promql/series(prom)\n# pint rule/snooze 2099-01-01 promql/rate\n- record: foo\n  expr: sum(foo)\n"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "# pint disable promql/series(prom)",
+						Lines:    []int{1},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`disable` comment has no effect because promql/series(prom) check is not enabled for this rule",
+						Severity: checks.Warning,
+					},
+					{
+						Fragment: "# pint rule/snooze 2099-01-01 promql/rate",
+						Lines:    []int{2},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`rule/snooze` comment has no effect because promql/rate check is not enabled for this rule",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "disable promql/series for a selector",
+			content:     "# pint disable promql/series(foo)\n# pint disable promql/series(bar)\n- record: foo\n  expr: sum(foo)\n",
+			checker:     newCommentsCheck(checks.SeriesCheckName, "promql/series(prom)"),
+			prometheus:  noProm,
+			entries:     mustParseContentWithComments("# pint disable promql/series(foo)\n# pint disable promql/series(bar)\n- record: foo\n  expr: sum(foo)\n"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "# pint disable promql/series(bar)",
+						Lines:    []int{2},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`disable` comment has no effect because promql/series(bar) check is not enabled for this rule",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "comment not attached to any rule",
+			content: `# pint disable promql/series
+groups:
+- name: foo
+  rules:
+  - record: foo
+    expr: sum(foo)
+`,
+			checker:    newCommentsCheck(checks.SeriesCheckName),
+			prometheus: noProm,
+			entries: mustParseContentWithComments(`# pint disable promql/series
+groups:
+- name: foo
+  rules:
+  - record: foo
+    expr: sum(foo)
+`),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "# pint disable promql/series",
+						Lines:    []int{1},
+						Reporter: checks.CommentsCheckName,
+						Text:     "`disable` comment is not attached to any rule and has no effect, it must be placed directly above the rule or inside it",
+						Severity: checks.Warning,
+					},
+				}
+			},
+		},
+		{
+			description: "rule from a different file",
+			content:     "# pint disabel promql/series\n- record: foo\n  expr: sum(foo)\n",
+			checker:     newCommentsCheck(),
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+	}
+	runTests(t, testCases)
+}
+
+func TestCommentsCheckMultipleRules(t *testing.T) {
+	content := `
+# pint disabel promql/series
+- record: foo
+  expr: sum(foo)
+
+# pint rule/owner
+- record: bar
+  expr: sum(bar)
+
+# pint ignore/end
+`
+	entries := mustParseContentWithComments(content)
+	check := checks.NewCommentsCheck(nil)
+
+	var got [][]int
+	for _, entry := range entries {
+		var lines []int
+		for _, problem := range check.Check(context.Background(), entry.ReportedPath, entry.Rule, entries) {
+			lines = append(lines, problem.Lines...)
+		}
+		got = append(got, lines)
+	}
+	require.Equal(t, [][]int{{2}, {6, 10}}, got)
+}
@@ -0,0 +1,40 @@
+package checks
+
+import (
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+// TextRange is the part of the rule file a problem was reported for.
+// Line and column numbers start at 1, end position is exclusive.
+type TextRange struct {
+	Line      int
+	Column    int
+	EndLine   int
+	EndColumn int
+}
+
+// exprRange returns the TextRange for given position range of the query,
+// or nil if it cannot be mapped back to the rule file.
+func exprRange(expr parser.PromQLExpr, pr promParser.PositionRange) *TextRange {
+	start, end, ok := expr.TextRange(pr)
+	if !ok {
+		return nil
+	}
+	return &TextRange{
+		Line:      start.Line,
+		Column:    start.Column,
+		EndLine:   end.Line,
+		EndColumn: end.Column,
+	}
+}
+
+// nodeRange returns the TextRange of given PromQL node, or nil if there's
+// no node or it cannot be mapped back to the rule file.
+func nodeRange(expr parser.PromQLExpr, node promParser.Node) *TextRange {
+	if node == nil {
+		return nil
+	}
+	return exprRange(expr, node.PositionRange())
+}
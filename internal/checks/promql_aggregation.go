@@ -3,11 +3,14 @@ package checks
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
 
 	"github.com/cloudflare/pint/internal/discovery"
 	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
 
 	promParser "github.com/prometheus/prometheus/promql/parser"
 )
@@ -66,20 +69,32 @@ func (c AggregationCheck) Check(_ context.Context, _ string, rule parser.Rule, _
 		}
 	}
 
-	for _, problem := range c.checkNode(expr.Query) {
+	// Skip the query if we know that the label will be on every result
+	// or on none of them, no matter how aggregations inside are written.
+	ls := utils.InferLabels(expr.Query)
+	if c.keep && slices.Contains(ls.Guaranteed, c.label) {
+		return nil
+	}
+	if !c.keep && !ls.CanHave(c.label) {
+		return nil
+	}
+
+	for _, problem := range c.checkNode(expr, expr.Query) {
 		problems = append(problems, Problem{
 			Fragment: problem.expr,
 			Lines:    expr.Lines(),
 			Reporter: c.Reporter(),
 			Text:     problem.text,
 			Severity: c.severity,
+			Range:    nodeRange(expr, problem.node),
+			Fixes:    problem.fixes,
 		})
 	}
 
 	return problems
 }
 
-func (c AggregationCheck) checkNode(node *parser.PromQLNode) (problems []exprProblem) {
+func (c AggregationCheck) checkNode(expr parser.PromQLExpr, node *parser.PromQLNode) (problems []exprProblem) {
 	if n, ok := node.Node.(*promParser.AggregateExpr); ok {
 		switch n.Op {
 		case promParser.SUM:
@@ -117,14 +132,17 @@ func (c AggregationCheck) checkNode(node *parser.PromQLNode) (problems []exprPro
 		if n.Without {
 			if found && c.keep {
 				problems = append(problems, exprProblem{
-					expr: node.Expr,
-					text: fmt.Sprintf("%s label is required and should be preserved when aggregating %q rules, remove %s from without()", c.label, c.nameRegex.anchored, c.label),
+					expr:  node.Expr,
+					node:  node.Node,
+					text:  fmt.Sprintf("%s label is required and should be preserved when aggregating %q rules, remove %s from without()", c.label, c.nameRegex.anchored, c.label),
+					fixes: removeGroupingLabel(expr, n, c.label),
 				})
 			}
 
 			if !found && !c.keep {
 				problems = append(problems, exprProblem{
 					expr: node.Expr,
+					node: node.Node,
 					text: fmt.Sprintf("%s label should be removed when aggregating %q rules, use without(%s, ...)", c.label, c.nameRegex.anchored, c.label),
 				})
 			}
@@ -137,14 +155,17 @@ func (c AggregationCheck) checkNode(node *parser.PromQLNode) (problems []exprPro
 		} else {
 			if found && !c.keep {
 				problems = append(problems, exprProblem{
-					expr: node.Expr,
-					text: fmt.Sprintf("%s label should be removed when aggregating %q rules, remove %s from by()", c.label, c.nameRegex.anchored, c.label),
+					expr:  node.Expr,
+					node:  node.Node,
+					text:  fmt.Sprintf("%s label should be removed when aggregating %q rules, remove %s from by()", c.label, c.nameRegex.anchored, c.label),
+					fixes: removeGroupingLabel(expr, n, c.label),
 				})
 			}
 
 			if !found && c.keep {
 				problems = append(problems, exprProblem{
 					expr: node.Expr,
+					node: node.Node,
 					text: fmt.Sprintf("%s label is required and should be preserved when aggregating %q rules, use by(%s, ...)", c.label, c.nameRegex.anchored, c.label),
 				})
 			}
@@ -163,10 +184,10 @@ NEXT:
 		case promParser.CardOneToOne:
 			// sum() + sum()
 		case promParser.CardManyToOne, promParser.CardManyToMany:
-			problems = append(problems, c.checkNode(node.Children[0])...)
+			problems = append(problems, c.checkNode(expr, node.Children[0])...)
 			return problems
 		case promParser.CardOneToMany:
-			problems = append(problems, c.checkNode(node.Children[1])...)
+			problems = append(problems, c.checkNode(expr, node.Children[1])...)
 			return problems
 		default:
 			log.Warn().Str("matching", n.VectorMatching.Card.String()).Msg("Unsupported VectorMatching operation")
@@ -174,8 +195,127 @@ NEXT:
 	}
 
 	for _, child := range node.Children {
-		problems = append(problems, c.checkNode(child)...)
+		problems = append(problems, c.checkNode(expr, child)...)
 	}
 
 	return problems
 }
+
+type textRange struct {
+	start int
+	end   int
+}
+
+// removeGroupingLabel returns edits removing given label from by() or
+// without() clause of an aggregation. If by() would end up empty then
+// the whole clause is removed, since that results in the same query.
+func removeGroupingLabel(expr parser.PromQLExpr, n *promParser.AggregateExpr, label string) []TextEdit {
+	pr := n.PositionRange()
+	if int(pr.End) > len(expr.Value.Value) {
+		return nil
+	}
+
+	skip := []textRange{{start: int(n.Expr.PositionRange().Start), end: int(n.Expr.PositionRange().End)}}
+	if n.Param != nil {
+		skip = append(skip, textRange{start: int(n.Param.PositionRange().Start), end: int(n.Param.PositionRange().End)})
+	}
+
+	keyword := "by"
+	if n.Without {
+		keyword = "without"
+	}
+
+	clause, items, ok := findGroupingClause(expr.Value.Value, int(pr.Start), int(pr.End), keyword, skip)
+	if !ok {
+		return nil
+	}
+
+	idx := -1
+	for i, item := range items {
+		if expr.Value.Value[item.start:item.end] == label {
+			idx = i
+			break
+		}
+	}
+
+	var cut textRange
+	switch {
+	case idx < 0:
+		return nil
+	case len(items) == 1 && !n.Without:
+		cut = clause
+		for cut.start > int(pr.Start) && isSpace(expr.Value.Value[cut.start-1]) {
+			cut.start--
+		}
+	case len(items) == 1:
+		cut = items[idx]
+	case idx < len(items)-1:
+		cut = textRange{start: items[idx].start, end: items[idx+1].start}
+	default:
+		cut = textRange{start: items[idx-1].end, end: items[idx].end}
+	}
+
+	fix, ok := exprEdit(expr, cut.start, cut.end, "")
+	if !ok {
+		return nil
+	}
+	return []TextEdit{fix}
+}
+
+// findGroupingClause finds by() or without() clause of an aggregation between
+// start and end offsets of the query, ignoring any skipped ranges.
+// It returns the range of the whole clause and ranges of all labels in it.
+func findGroupingClause(query string, start, end int, keyword string, skip []textRange) (clause textRange, items []textRange, ok bool) {
+	isSkipped := func(i int) bool {
+		for _, s := range skip {
+			if i >= s.start && i < s.end {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := start; i < end; i++ {
+		if isSkipped(i) || !isLabelNameChar(query[i]) || (i > start && isLabelNameChar(query[i-1])) {
+			continue
+		}
+		j := i
+		for j < end && isLabelNameChar(query[j]) {
+			j++
+		}
+		if !strings.EqualFold(query[i:j], keyword) {
+			i = j - 1
+			continue
+		}
+		for j < end && isSpace(query[j]) {
+			j++
+		}
+		if j >= end || query[j] != '(' {
+			return clause, nil, false
+		}
+		j++
+		for j < end {
+			for j < end && (isSpace(query[j]) || query[j] == ',') {
+				j++
+			}
+			if j < end && query[j] == ')' {
+				return textRange{start: i, end: j + 1}, items, true
+			}
+			k := j
+			for k < end && isLabelNameChar(query[k]) {
+				k++
+			}
+			if k == j {
+				return clause, nil, false
+			}
+			items = append(items, textRange{start: j, end: k})
+			j = k
+		}
+		return clause, nil, false
+	}
+	return clause, nil, false
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+}
@@ -69,6 +69,8 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, remove job from without()`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 40},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 34, EndLine: 2, EndColumn: 39, Text: ""}},
 					},
 				}
 			},
@@ -88,6 +90,8 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, remove job from without()`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 40},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 34, EndLine: 2, EndColumn: 39, Text: ""}},
 					},
 				}
 			},
@@ -107,6 +111,7 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label should be removed when aggregating "^.+$" rules, use without(job, ...)`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 35},
 					},
 				}
 			},
@@ -144,6 +149,8 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, remove job from without()`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 13, EndLine: 2, EndColumn: 34},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 30, EndLine: 2, EndColumn: 33, Text: ""}},
 					},
 				}
 			},
@@ -172,6 +179,7 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `instance label should be removed when aggregating "^.+$" rules, use without(instance, ...)`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 48},
 					},
 					{
 						Fragment: "sum without (foo) (foo)",
@@ -179,6 +187,7 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `instance label should be removed when aggregating "^.+$" rules, use without(instance, ...)`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 13, EndLine: 2, EndColumn: 34},
 					},
 				}
 			},
@@ -198,6 +207,8 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, remove job from without()`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 13, EndLine: 2, EndColumn: 34},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 30, EndLine: 2, EndColumn: 33, Text: ""}},
 					},
 				}
 			},
@@ -226,6 +237,8 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, remove job from without()`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 31},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 27, EndLine: 2, EndColumn: 30, Text: ""}},
 					},
 				}
 			},
@@ -254,6 +267,8 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, remove job from without()`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 31},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 21, EndLine: 2, EndColumn: 24, Text: ""}},
 					},
 				}
 			},
@@ -282,6 +297,8 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, remove job from without()`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 57, EndLine: 2, EndColumn: 79},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 69, EndLine: 2, EndColumn: 72, Text: ""}},
 					},
 				}
 			},
@@ -338,6 +355,7 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, use by(job, ...)`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 30},
 					},
 				}
 			},
@@ -357,6 +375,7 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, use by(job, ...)`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 30},
 					},
 				}
 			},
@@ -376,6 +395,8 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label should be removed when aggregating "^.+$" rules, remove job from by()`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 25},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 17, EndLine: 2, EndColumn: 25, Text: ""}},
 					},
 				}
 			},
@@ -404,6 +425,7 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, use by(job, ...)`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 13, EndLine: 2, EndColumn: 34},
 					},
 				}
 			},
@@ -432,6 +454,7 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, use by(job, ...)`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 31},
 					},
 				}
 			},
@@ -460,6 +483,7 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, use by(job, ...)`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 27},
 					},
 				}
 			},
@@ -488,6 +512,7 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, use by(job, ...)`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 52, EndLine: 2, EndColumn: 70},
 					},
 				}
 			},
@@ -525,6 +550,8 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, remove job from without()`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 48},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 44, EndLine: 2, EndColumn: 47, Text: ""}},
 					},
 					{
 						Fragment: "sum by (instance) (foo)",
@@ -532,6 +559,7 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, use by(job, ...)`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 13, EndLine: 2, EndColumn: 34},
 					},
 				}
 			},
@@ -551,6 +579,8 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, remove job from without()`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 52},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 48, EndLine: 2, EndColumn: 51, Text: ""}},
 					},
 				}
 			},
@@ -570,6 +600,7 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, use by(job, ...)`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 13, EndLine: 2, EndColumn: 34},
 					},
 				}
 			},
@@ -589,6 +620,7 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `instance label should be removed when aggregating "^.+$" rules, use without(instance, ...)`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 48},
 					},
 					{
 						Fragment: "sum by (instance) (foo)",
@@ -596,6 +628,8 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `instance label should be removed when aggregating "^.+$" rules, remove instance from by()`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 13, EndLine: 2, EndColumn: 34},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 21, EndLine: 2, EndColumn: 34, Text: ""}},
 					},
 				}
 			},
@@ -615,6 +649,7 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, use by(job, ...)`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 17},
 					},
 				}
 			},
@@ -634,6 +669,7 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label is required and should be preserved when aggregating "^.+$" rules, use by(job, ...)`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 22},
 					},
 				}
 			},
@@ -653,10 +689,29 @@ func TestAggregationCheck(t *testing.T) {
 						Reporter: checks.AggregationCheckName,
 						Text:     `job label should be removed when aggregating "^.+$" rules, use without(job, ...)`,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 27},
 					},
 				}
 			},
 		},
+		{
+			description: "must keep job label / added by group_left()",
+			content:     "- record: foo\n  expr: sum(foo) by(instance) * on(instance) group_left(job) bar{job=\"a\"}\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewAggregationCheck(checks.MustTemplatedRegexp(".+"), "job", true, checks.Warning)
+			},
+			prometheus: noProm,
+			problems:   noProblems,
+		},
+		{
+			description: "must strip job label / removed by on()",
+			content:     "- record: foo\n  expr: sum(foo) without(instance) * on(instance) bar\n",
+			checker: func(_ *promapi.FailoverGroup) checks.RuleChecker {
+				return checks.NewAggregationCheck(checks.MustTemplatedRegexp(".+"), "job", false, checks.Warning)
+			},
+			prometheus: noProm,
+			problems:   noProblems,
+		},
 	}
 	runTests(t, testCases)
 }
@@ -62,6 +62,7 @@ func (c CounterCheck) Check(ctx context.Context, _ string, rule parser.Rule, ent
 			Reporter: c.Reporter(),
 			Text:     problem.text,
 			Severity: problem.severity,
+			Range:    nodeRange(expr, problem.node),
 		})
 	}
 
@@ -86,6 +87,7 @@ func (c CounterCheck) checkNode(ctx context.Context, node *parser.PromQLNode, en
 				text, severity := textAndSeverityFromError(err, c.Reporter(), c.prom.Name(), Bug)
 				problems = append(problems, exprProblem{
 					expr:     s.Name,
+					node:     s,
 					text:     text,
 					severity: severity,
 				})
@@ -108,6 +110,7 @@ func (c CounterCheck) checkNode(ctx context.Context, node *parser.PromQLNode, en
 
 			p := exprProblem{
 				expr: node.Expr,
+				node: node.Node,
 				text: fmt.Sprintf("Counter metric `%s` should be used with one of following functions: (%s).", s.Name, allowedFuncString),
 				// There can be valid edge cases like a recording rule: `foo{label="value"}` or being constrained to use a counter as an info metric for joining.
 				severity: Warning,
@@ -70,6 +70,7 @@ func TestCounterCheck(t *testing.T) {
 						Reporter: "promql/counter",
 						Text:     CounterMustUseFuncTextForRecordingRule("foo"),
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 10, EndLine: 2, EndColumn: 13},
 					},
 				}
 			},
@@ -140,6 +141,7 @@ func TestCounterCheck(t *testing.T) {
 						Reporter: "promql/counter",
 						Text:     CounterMustUseFuncTextForRecordingRule("foo"),
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 35, EndLine: 2, EndColumn: 48},
 					},
 				}
 			},
@@ -165,6 +167,7 @@ func TestCounterCheck(t *testing.T) {
 						Reporter: "promql/counter",
 						Text:     CounterMustUseFuncTextForRecordingRule("foo"),
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 15, EndLine: 2, EndColumn: 18},
 					},
 				}
 			},
@@ -190,6 +193,7 @@ func TestCounterCheck(t *testing.T) {
 						Reporter: "promql/counter",
 						Text:     CounterMustUseFuncTextForAlert("foo"),
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 15, EndLine: 2, EndColumn: 18},
 					},
 				}
 			},
@@ -228,6 +232,7 @@ func TestCounterCheck(t *testing.T) {
 						Reporter: "promql/counter",
 						Text:     checkErrorUnableToRun(checks.CounterCheckName, "prom", uri, "server_error: internal error"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 14, EndLine: 2, EndColumn: 17},
 					},
 				}
 			},
@@ -45,6 +45,7 @@ func (c FragileCheck) Check(_ context.Context, _ string, rule parser.Rule, _ []d
 			Reporter: c.Reporter(),
 			Text:     problem.text,
 			Severity: Warning,
+			Range:    nodeRange(expr, problem.node),
 		})
 	}
 	return problems
@@ -83,6 +84,7 @@ func (c FragileCheck) checkNode(node *parser.PromQLNode) (problems []exprProblem
 		if len(series) >= 2 {
 			p := exprProblem{
 				expr:     node.Expr,
+				node:     node.Node,
 				text:     "aggregation using without() can be fragile when used inside binary expression because both sides must have identical sets of labels to produce any results, adding or removing labels to metrics used here can easily break the query, consider aggregating using by() to ensure consistent labels",
 				severity: Warning,
 			}
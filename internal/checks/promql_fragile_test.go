@@ -63,6 +63,7 @@ func TestFragileCheck(t *testing.T) {
 						Reporter: "promql/fragile",
 						Text:     text,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 36},
 					},
 				}
 			},
@@ -80,6 +81,7 @@ func TestFragileCheck(t *testing.T) {
 						Reporter: "promql/fragile",
 						Text:     text,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 54},
 					},
 				}
 			},
@@ -97,6 +99,7 @@ func TestFragileCheck(t *testing.T) {
 						Reporter: "promql/fragile",
 						Text:     text,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 56},
 					},
 				}
 			},
@@ -114,6 +117,7 @@ func TestFragileCheck(t *testing.T) {
 						Reporter: "promql/fragile",
 						Text:     text,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 10, EndLine: 2, EndColumn: 37},
 					},
 				}
 			},
@@ -131,6 +135,7 @@ func TestFragileCheck(t *testing.T) {
 						Reporter: "promql/fragile",
 						Text:     text,
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 43},
 					},
 				}
 			},
@@ -82,6 +82,7 @@ func (c RangeQueryCheck) Check(ctx context.Context, _ string, rule parser.Rule,
 			Reporter: c.Reporter(),
 			Text:     problem.text,
 			Severity: problem.severity,
+			Range:    nodeRange(expr, problem.node),
 		})
 	}
 
@@ -93,6 +94,7 @@ func (c RangeQueryCheck) checkNode(ctx context.Context, node *parser.PromQLNode,
 		if n.Range > retention {
 			problems = append(problems, exprProblem{
 				expr: node.Expr,
+				node: node.Node,
 				text: fmt.Sprintf("%s selector is trying to query Prometheus for %s worth of metrics, but %s is configured to only keep %s of metrics history",
 					node.Expr, model.Duration(n.Range), promText(c.prom.Name(), uri), model.Duration(retention)),
 				severity: Warning,
@@ -69,6 +69,7 @@ func TestRangeQueryCheck(t *testing.T) {
 						Reporter: "promql/range_query",
 						Text:     retentionToLow("prom", uri, "foo[30d]", "30d", "15d"),
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 14, EndLine: 2, EndColumn: 22},
 					},
 				}
 			},
@@ -107,6 +108,7 @@ func TestRangeQueryCheck(t *testing.T) {
 						Reporter: "promql/range_query",
 						Text:     retentionToLow("prom", uri, "foo[20d]", "20d", "15d"),
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 14, EndLine: 2, EndColumn: 22},
 					},
 				}
 			},
@@ -145,6 +147,7 @@ func TestRangeQueryCheck(t *testing.T) {
 						Reporter: "promql/range_query",
 						Text:     retentionToLow("prom", uri, "foo[11d1h]", "11d1h", "11d"),
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 14, EndLine: 2, EndColumn: 24},
 					},
 				}
 			},
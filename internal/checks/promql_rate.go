@@ -70,6 +70,7 @@ func (c RateCheck) Check(ctx context.Context, _ string, rule parser.Rule, entrie
 			Reporter: c.Reporter(),
 			Text:     problem.text,
 			Severity: problem.severity,
+			Range:    nodeRange(expr, problem.node),
 		})
 	}
 
@@ -86,6 +87,7 @@ func (c RateCheck) checkNode(ctx context.Context, node *parser.PromQLNode, entri
 			if m.Range < cfg.Config.Global.ScrapeInterval*time.Duration(c.minIntervals) {
 				p := exprProblem{
 					expr: node.Expr,
+					node: node.Node,
 					text: fmt.Sprintf("duration for %s() must be at least %d x scrape_interval, %s is using %s scrape_interval",
 						n.Func.Name, c.minIntervals, promText(c.prom.Name(), cfg.URI), output.HumanizeDuration(cfg.Config.Global.ScrapeInterval)),
 					severity: Bug,
@@ -105,6 +107,7 @@ func (c RateCheck) checkNode(ctx context.Context, node *parser.PromQLNode, entri
 					text, severity := textAndSeverityFromError(err, c.Reporter(), c.prom.Name(), Bug)
 					problems = append(problems, exprProblem{
 						expr:     s.Name,
+						node:     s,
 						text:     text,
 						severity: severity,
 					})
@@ -114,6 +117,7 @@ func (c RateCheck) checkNode(ctx context.Context, node *parser.PromQLNode, entri
 					if m.Type != v1.MetricTypeCounter && m.Type != v1.MetricTypeUnknown {
 						problems = append(problems, exprProblem{
 							expr: s.Name,
+							node: s,
 							text: fmt.Sprintf("%s() should only be used with counters but %q is a %s according to metrics metadata from %s",
 								n.Func.Name, s.Name, m.Type, promText(c.prom.Name(), metadata.URI)),
 							severity: Bug,
@@ -121,14 +125,20 @@ func (c RateCheck) checkNode(ctx context.Context, node *parser.PromQLNode, entri
 					}
 				}
 
-				for _, e := range entries {
-					if e.PathError != nil {
-						continue
-					}
-					if e.Rule.Error.Err != nil {
-						continue
+				for _, e := range findRecordingRules(entries, s.Name) {
+					if len(metadata.Metadata) == 0 && recordingRuleMismatch(*s, e) == "" {
+						for _, rc := range utils.HasOuterRate(e.Rule.RecordingRule.Expr.Query) {
+							problems = append(problems, exprProblem{
+								expr: node.Expr,
+								node: node.Node,
+								text: fmt.Sprintf("%s() should only be used with counters but %q is produced by recording rule defined at %s which already calls %s() on its results",
+									n.Func.Name, s.Name, ruleLocation(e), rc.Func.Name),
+								severity: Bug,
+							})
+							break
+						}
 					}
-					if e.Rule.RecordingRule != nil && e.Rule.RecordingRule.Expr.SyntaxError == nil && e.Rule.RecordingRule.Record.Value.Value == s.Name {
+					if e.Rule.RecordingRule.Expr.SyntaxError == nil {
 						for _, sm := range utils.HasOuterSum(e.Rule.RecordingRule.Expr.Query) {
 							if sv, ok := sm.Expr.(*promParser.VectorSelector); ok {
 								metadata, err := c.prom.Metadata(ctx, sv.Name)
@@ -145,6 +155,7 @@ func (c RateCheck) checkNode(ctx context.Context, node *parser.PromQLNode, entri
 									if m.Type == v1.MetricTypeCounter {
 										problems = append(problems, exprProblem{
 											expr: node.Expr,
+											node: node.Node,
 											text: fmt.Sprintf("rate(sum(counter)) chain detected, %s is called here on results of %s, calling rate on sum() results will return bogus results, always sum(rate(counter)), never rate(sum(counter))",
 												node.Expr, sm),
 											severity: Bug,
@@ -27,6 +27,10 @@ func rateSumText(rateName, sumExpr string) string {
 	return fmt.Sprintf("rate(sum(counter)) chain detected, rate(%s) is called here on results of %s, calling rate on sum() results will return bogus results, always sum(rate(counter)), never rate(sum(counter))", rateName, sumExpr)
 }
 
+func rateOverRateText(fun, metric, path, inner string) string {
+	return fmt.Sprintf("%s() should only be used with counters but %q is produced by recording rule defined at %s which already calls %s() on its results", fun, metric, path, inner)
+}
+
 func TestRateCheck(t *testing.T) {
 	testCases := []checkTest{
 		{
@@ -49,6 +53,7 @@ func TestRateCheck(t *testing.T) {
 						Reporter: "promql/rate",
 						Text:     durationMustText("prom", uri, "rate", "2", "1m"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 22},
 					},
 				}
 			},
@@ -116,6 +121,7 @@ func TestRateCheck(t *testing.T) {
 						Reporter: "promql/rate",
 						Text:     durationMustText("prom", uri, "irate", "2", "1m"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 23},
 					},
 				}
 			},
@@ -145,6 +151,7 @@ func TestRateCheck(t *testing.T) {
 						Reporter: "promql/rate",
 						Text:     durationMustText("prom", uri, "deriv", "2", "1m"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 23},
 					},
 				}
 			},
@@ -320,6 +327,7 @@ func TestRateCheck(t *testing.T) {
 						Reporter: "promql/rate",
 						Text:     durationMustText("prom", uri, "rate", "2", "1m"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 34, EndLine: 2, EndColumn: 47},
 					},
 				}
 			},
@@ -457,6 +465,7 @@ func TestRateCheck(t *testing.T) {
 						Reporter: "promql/rate",
 						Text:     durationMustText("prom", uri, "rate", "2", "1m"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 33},
 					},
 					{
 						Fragment: "foo",
@@ -464,6 +473,7 @@ func TestRateCheck(t *testing.T) {
 						Reporter: "promql/rate",
 						Text:     checkErrorUnableToRun(checks.RateCheckName, "prom", uri, "server_error: internal error"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 14, EndLine: 2, EndColumn: 28},
 					},
 				}
 			},
@@ -508,6 +518,7 @@ func TestRateCheck(t *testing.T) {
 						Reporter: "promql/rate",
 						Text:     durationMustText("prom", uri, "rate", "2", "1m"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 33},
 					},
 					{
 						Fragment: "foo",
@@ -515,6 +526,7 @@ func TestRateCheck(t *testing.T) {
 						Reporter: "promql/rate",
 						Text:     notCounterText("prom", uri, "rate", "foo", "gauge"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 14, EndLine: 2, EndColumn: 28},
 					},
 				}
 			},
@@ -544,6 +556,7 @@ func TestRateCheck(t *testing.T) {
 						Reporter: "promql/rate",
 						Text:     notCounterText("prom", uri, "rate", "bar_g", "gauge"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 32, EndLine: 2, EndColumn: 37},
 					},
 				}
 			},
@@ -604,6 +617,7 @@ func TestRateCheck(t *testing.T) {
 						Reporter: "promql/rate",
 						Text:     notCounterText("prom", uri, "rate", "foo", "gauge"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 14, EndLine: 2, EndColumn: 17},
 					},
 				}
 			},
@@ -637,6 +651,7 @@ func TestRateCheck(t *testing.T) {
 						Reporter: "promql/rate",
 						Text:     rateSumText("my:sum[5m]", "sum(foo)"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 25},
 					},
 				}
 			},
@@ -745,6 +760,93 @@ func TestRateCheck(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "rate_over_rate",
+			content:     "- alert: my alert\n  expr: rate(my:rate:5m[5m])\n",
+			entries:     mustParseContent("- record: my:rate:5m\n  expr: sum(rate(foo[5m])) by(job)\n"),
+			checker:     newRateCheck,
+			prometheus:  newSimpleProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "rate(my:rate:5m[5m])",
+						Lines:    []int{2},
+						Reporter: "promql/rate",
+						Text:     rateOverRateText("rate", "my:rate:5m", "fake.yml:1", "rate"),
+						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 29},
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  scrape_interval: 1m\n"},
+				},
+				{
+					conds: []requestCondition{
+						requireMetadataPath,
+						formCond{"metric", "my:rate:5m"},
+					},
+					resp: metadataResponse{metadata: map[string][]v1.Metadata{}},
+				},
+			},
+		},
+		{
+			description: "rate_over_rate_with_metadata",
+			content:     "- alert: my alert\n  expr: rate(my:rate:5m[5m])\n",
+			entries:     mustParseContent("- record: my:rate:5m\n  expr: sum(rate(foo[5m])) by(job)\n"),
+			checker:     newRateCheck,
+			prometheus:  newSimpleProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "my:rate:5m",
+						Lines:    []int{2},
+						Reporter: "promql/rate",
+						Text:     notCounterText("prom", uri, "rate", "my:rate:5m", "gauge"),
+						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 14, EndLine: 2, EndColumn: 24},
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  scrape_interval: 1m\n"},
+				},
+				{
+					conds: []requestCondition{
+						requireMetadataPath,
+						formCond{"metric", "my:rate:5m"},
+					},
+					resp: metadataResponse{metadata: map[string][]v1.Metadata{
+						"my:rate:5m": {{Type: "gauge"}},
+					}},
+				},
+			},
+		},
+		{
+			description: "rate_over_rate_with_different_labels",
+			content:     "- alert: my alert\n  expr: rate(my:rate:5m{job=\"foo\"}[5m])\n",
+			entries:     mustParseContent("- record: my:rate:5m\n  expr: sum(rate(foo[5m])) by(job)\n  labels:\n    job: bar\n"),
+			checker:     newRateCheck,
+			prometheus:  newSimpleProm,
+			problems:    noProblems,
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{requireConfigPath},
+					resp:  configResponse{yaml: "global:\n  scrape_interval: 1m\n"},
+				},
+				{
+					conds: []requestCondition{
+						requireMetadataPath,
+						formCond{"metric", "my:rate:5m"},
+					},
+					resp: metadataResponse{metadata: map[string][]v1.Metadata{}},
+				},
+			},
+		},
 	}
 	runTests(t, testCases)
 }
@@ -3,9 +3,12 @@ package checks
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"regexp/syntax"
+	"strings"
 
 	"github.com/prometheus/prometheus/model/labels"
+	promParser "github.com/prometheus/prometheus/promql/parser"
 
 	"github.com/cloudflare/pint/internal/discovery"
 	"github.com/cloudflare/pint/internal/parser"
@@ -79,12 +82,18 @@ func (c RegexpCheck) Check(_ context.Context, _ string, rule parser.Rule, _ []di
 				case labels.MatchNotRegexp:
 					op = labels.MatchNotEqual
 				}
+				var fixes []TextEdit
+				if beginText == 0 && endText == 0 && regexp.QuoteMeta(lm.Value) == lm.Value {
+					fixes = regexpMatcherFixes(expr, expr.Query, selector.String(), lm, op)
+				}
 				problems = append(problems, Problem{
 					Fragment: selector.String(),
 					Lines:    expr.Lines(),
 					Reporter: c.Reporter(),
 					Text:     fmt.Sprintf(`unnecessary regexp match on static string %s, use %s%s%q instead`, lm, lm.Name, op, lm.Value),
 					Severity: Bug,
+					Fixes:    fixes,
+					Range:    selectorRange(expr, expr.Query, selector.String()),
 				})
 			}
 			if beginText > 1 || endText > 1 {
@@ -96,6 +105,7 @@ func (c RegexpCheck) Check(_ context.Context, _ string, rule parser.Rule, _ []di
 						lm, lm.Name, lm.Type, lm.Value,
 					),
 					Severity: Bug,
+					Range:    selectorRange(expr, expr.Query, selector.String()),
 				})
 			}
 		}
@@ -103,3 +113,94 @@ func (c RegexpCheck) Check(_ context.Context, _ string, rule parser.Rule, _ []di
 
 	return problems
 }
+
+// selectorRange returns the TextRange of the first instance of given selector.
+func selectorRange(expr parser.PromQLExpr, node *parser.PromQLNode, selector string) *TextRange {
+	if vs, ok := node.Node.(*promParser.VectorSelector); ok {
+		nc := promParser.VectorSelector{Name: vs.Name, LabelMatchers: vs.LabelMatchers}
+		if nc.String() == selector {
+			return nodeRange(expr, vs)
+		}
+	}
+
+	for _, child := range node.Children {
+		if tr := selectorRange(expr, child, selector); tr != nil {
+			return tr
+		}
+	}
+
+	return nil
+}
+
+// regexpMatcherFixes returns edits replacing the regexp operator of given
+// label matcher with op in every instance of given selector.
+func regexpMatcherFixes(expr parser.PromQLExpr, node *parser.PromQLNode, selector string, lm *labels.Matcher, op labels.MatchType) (fixes []TextEdit) {
+	if vs, ok := node.Node.(*promParser.VectorSelector); ok {
+		nc := promParser.VectorSelector{Name: vs.Name, LabelMatchers: vs.LabelMatchers}
+		pr := vs.PositionRange()
+		if nc.String() == selector && int(pr.End) <= len(expr.Value.Value) {
+			if idx := findMatcherOperator(expr.Value.Value[pr.Start:pr.End], lm); idx >= 0 {
+				start := int(pr.Start) + idx
+				if fix, ok := exprEdit(expr, start, start+len(lm.Type.String()), op.String()); ok {
+					fixes = append(fixes, fix)
+				}
+			}
+		}
+	}
+
+	for _, child := range node.Children {
+		fixes = append(fixes, regexpMatcherFixes(expr, child, selector, lm, op)...)
+	}
+
+	return fixes
+}
+
+// findMatcherOperator returns the offset of the operator of given label
+// matcher inside the text of a vector selector, or -1 if it's not found.
+func findMatcherOperator(text string, lm *labels.Matcher) int {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		if quote != 0 {
+			switch {
+			case ch == '\\' && quote != '`':
+				i++
+			case ch == quote:
+				quote = 0
+			}
+			continue
+		}
+		if ch == '"' || ch == '\'' || ch == '`' {
+			quote = ch
+			continue
+		}
+		if !isLabelNameChar(ch) || (i > 0 && isLabelNameChar(text[i-1])) {
+			continue
+		}
+
+		end := i
+		for end < len(text) && isLabelNameChar(text[end]) {
+			end++
+		}
+		if text[i:end] == lm.Name {
+			opIdx := end
+			for opIdx < len(text) && text[opIdx] == ' ' {
+				opIdx++
+			}
+			if strings.HasPrefix(text[opIdx:], lm.Type.String()) {
+				val := strings.TrimLeft(text[opIdx+len(lm.Type.String()):], " ")
+				for _, q := range []string{`"`, "'", "`"} {
+					if strings.HasPrefix(val, q+lm.Value+q) {
+						return opIdx
+					}
+				}
+			}
+		}
+		i = end - 1
+	}
+	return -1
+}
+
+func isLabelNameChar(ch byte) bool {
+	return ch == '_' || ch == ':' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')
+}
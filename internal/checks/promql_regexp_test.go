@@ -61,6 +61,27 @@ func TestRegexpCheck(t *testing.T) {
 						Reporter: checks.RegexpCheckName,
 						Text:     `unnecessary regexp match on static string job=~"bar", use job="bar" instead`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 24},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 16, EndLine: 2, EndColumn: 18, Text: "="}},
+					},
+				}
+			},
+		},
+		{
+			description: "unnecessary regexp / multi-line",
+			content:     "- record: foo\n  expr: |\n    sum(\n      foo{job=~\"bar\"}\n    )\n",
+			checker:     newRegexpCheck,
+			prometheus:  noProm,
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: `foo{job=~"bar"}`,
+						Lines:    []int{2, 3, 4, 5},
+						Reporter: checks.RegexpCheckName,
+						Text:     `unnecessary regexp match on static string job=~"bar", use job="bar" instead`,
+						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 4, Column: 7, EndLine: 4, EndColumn: 22},
+						Fixes:    []checks.TextEdit{{Line: 4, Column: 14, EndLine: 4, EndColumn: 16, Text: "="}},
 					},
 				}
 			},
@@ -78,6 +99,8 @@ func TestRegexpCheck(t *testing.T) {
 						Reporter: checks.RegexpCheckName,
 						Text:     `unnecessary regexp match on static string job!~"bar", use job!="bar" instead`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 24},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 16, EndLine: 2, EndColumn: 18, Text: "!="}},
 					},
 				}
 			},
@@ -95,6 +118,8 @@ func TestRegexpCheck(t *testing.T) {
 						Reporter: checks.RegexpCheckName,
 						Text:     `unnecessary regexp match on static string job=~"", use job="" instead`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 21},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 16, EndLine: 2, EndColumn: 18, Text: "="}},
 					},
 				}
 			},
@@ -112,6 +137,7 @@ func TestRegexpCheck(t *testing.T) {
 						Reporter: checks.RegexpCheckName,
 						Text:     `prometheus regexp matchers are automatically fully anchored so match for job=~"^.+$" will result in job=~"^^.+$$", remove regexp anchors ^ and/or $`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 25},
 					},
 				}
 			},
@@ -129,6 +155,7 @@ func TestRegexpCheck(t *testing.T) {
 						Reporter: checks.RegexpCheckName,
 						Text:     `prometheus regexp matchers are automatically fully anchored so match for job=~"(foo|^.+)$" will result in job=~"^(foo|^.+)$$", remove regexp anchors ^ and/or $`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 31},
 					},
 				}
 			},
@@ -146,6 +173,11 @@ func TestRegexpCheck(t *testing.T) {
 						Reporter: checks.RegexpCheckName,
 						Text:     `unnecessary regexp match on static string job=~"bar", use job="bar" instead`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 24},
+						Fixes: []checks.TextEdit{
+							{Line: 2, Column: 16, EndLine: 2, EndColumn: 18, Text: "="},
+							{Line: 2, Column: 34, EndLine: 2, EndColumn: 36, Text: "="},
+						},
 					},
 				}
 			},
@@ -163,6 +195,8 @@ func TestRegexpCheck(t *testing.T) {
 						Reporter: checks.RegexpCheckName,
 						Text:     `unnecessary regexp match on static string job=~"bar", use job="bar" instead`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 24},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 16, EndLine: 2, EndColumn: 18, Text: "="}},
 					},
 					{
 						Fragment: `foo{job=~"bar",level="total"}`,
@@ -170,6 +204,8 @@ func TestRegexpCheck(t *testing.T) {
 						Reporter: checks.RegexpCheckName,
 						Text:     `unnecessary regexp match on static string job=~"bar", use job="bar" instead`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 27, EndLine: 2, EndColumn: 57},
+						Fixes:    []checks.TextEdit{{Line: 2, Column: 34, EndLine: 2, EndColumn: 36, Text: "="}},
 					},
 				}
 			},
@@ -230,14 +230,29 @@ func (c SeriesCheck) Check(ctx context.Context, _ string, rule parser.Rule, entr
 		if len(trs.Series.Ranges) == 0 {
 			// Check if we have recording rule that provides this metric before we give up
 			var rrEntry *discovery.Entry
-			for _, entry := range entries {
+			var mismatch string
+			for _, entry := range findRecordingRules(entries, bareSelector.String()) {
 				entry := entry
-				if entry.Rule.RecordingRule != nil &&
-					entry.Rule.Error.Err == nil &&
-					entry.Rule.RecordingRule.Record.Value.Value == bareSelector.String() {
-					rrEntry = &entry
-					break
+				if m := recordingRuleMismatch(selector, entry); m != "" {
+					if mismatch == "" {
+						mismatch = m
+					}
+					continue
 				}
+				rrEntry = &entry
+				break
+			}
+			if rrEntry == nil && mismatch != "" {
+				problems = append(problems, Problem{
+					Fragment: selector.String(),
+					Lines:    expr.Lines(),
+					Reporter: c.Reporter(),
+					Text: fmt.Sprintf("%s didn't have any series for %q metric in the last %s and %s, so `%s` will never match anything",
+						promText(c.prom.Name(), trs.URI), bareSelector.String(), sinceDesc(trs.Series.From), mismatch, selector.String()),
+					Severity: Bug,
+				})
+				log.Debug().Stringer("selector", &selector).Msg("Metric is provided by recording rule but it doesn't produce labels used in the query")
+				continue
 			}
 			if rrEntry != nil {
 				// Validate recording rule instead
@@ -607,34 +622,41 @@ func isDisabled(rule parser.Rule, selector promParser.VectorSelector) bool {
 	for _, c := range rule.GetComments("disable") {
 		if strings.HasPrefix(c.Value, SeriesCheckName+"(") && strings.HasSuffix(c.Value, ")") {
 			cs := strings.TrimSuffix(strings.TrimPrefix(c.Value, SeriesCheckName+"("), ")")
-			// try full string or name match first
-			if cs == selector.String() || cs == selector.Name {
+			if isSelectorMatching(cs, selector) {
 				return true
 			}
-			// then try matchers
-			m, err := promParser.ParseMetricSelector(cs)
-			if err != nil {
-				continue
-			}
-			for _, l := range m {
-				var isMatch bool
-				for _, s := range selector.LabelMatchers {
-					if s.Type == l.Type && s.Name == l.Name && s.Value == l.Value {
-						isMatch = true
-						break
-					}
-				}
-				if !isMatch {
-					goto NEXT
-				}
-			}
-			return true
 		}
-	NEXT:
 	}
 	return false
 }
 
+// isSelectorMatching returns true if selector string from a pint comment
+// matches given vector selector.
+func isSelectorMatching(cs string, selector promParser.VectorSelector) bool {
+	// try full string or name match first
+	if cs == selector.String() || cs == selector.Name {
+		return true
+	}
+	// then try matchers
+	m, err := promParser.ParseMetricSelector(cs)
+	if err != nil {
+		return false
+	}
+	for _, l := range m {
+		var isMatch bool
+		for _, s := range selector.LabelMatchers {
+			if s.Type == l.Type && s.Name == l.Name && s.Value == l.Value {
+				isMatch = true
+				break
+			}
+		}
+		if !isMatch {
+			return false
+		}
+	}
+	return true
+}
+
 func sinceDesc(t time.Time) (s string) {
 	dur := time.Since(t)
 	if dur > time.Hour*24 {
@@ -24,6 +24,10 @@ func noMetricRRText(name, uri, metric, since string) string {
 	return fmt.Sprintf(`prometheus %q at %s didn't have any series for %q metric in the last %s but found recording rule that generates it, skipping further checks`, name, uri, metric, since)
 }
 
+func noMetricRRMismatchText(name, uri, metric, since, reason, selector string) string {
+	return fmt.Sprintf("prometheus %q at %s didn't have any series for %q metric in the last %s and %s, so `%s` will never match anything", name, uri, metric, since, reason, selector)
+}
+
 func noFilterMatchText(name, uri, metric, label, filter, since string) string {
 	return fmt.Sprintf(`prometheus %q at %s has %q metric with %q label but there are no series matching %s in the last %s`, name, uri, metric, label, filter, since)
 }
@@ -445,7 +449,7 @@ func TestSeriesCheck(t *testing.T) {
 			content:     "- record: foo\n  expr: sum(foo:bar{job=\"xxx\"})\n",
 			checker:     newSeriesCheck,
 			prometheus:  newSimpleProm,
-			entries:     mustParseContent("- record: foo:bar\n  expr: sum(foo:bar)\n"),
+			entries:     mustParseContent("- record: foo:bar\n  expr: sum(foo:bar) by(job)\n"),
 			problems: func(uri string) []checks.Problem {
 				return []checks.Problem{
 					{
@@ -486,7 +490,171 @@ func TestSeriesCheck(t *testing.T) {
 			content:     "- record: foo\n  expr: sum(foo:bar{job=\"xxx\"})\n",
 			checker:     newSeriesCheck,
 			prometheus:  newSimpleProm,
-			entries:     mustParseContent("- record: foo:bar\n  expr: sum(foo)\n"),
+			entries:     mustParseContent("- record: foo:bar\n  expr: sum(foo) without(instance)\n"),
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "foo:bar",
+						Lines:    []int{2},
+						Reporter: checks.SeriesCheckName,
+						Text:     noMetricRRText("prom", uri, "foo:bar", "1w"),
+						Severity: checks.Information,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: `count(foo:bar{job="xxx"})`},
+					},
+					resp: respondWithEmptyVector(),
+				},
+				{
+					conds: []requestCondition{
+						requireRangeQueryPath,
+						formCond{key: "query", value: `count(foo:bar)`},
+					},
+					resp: respondWithEmptyMatrix(),
+				},
+				{
+					conds: []requestCondition{
+						requireRangeQueryPath,
+						formCond{key: "query", value: "count(up)"},
+					},
+					resp: respondWithSingleRangeVector1W(),
+				},
+			},
+		},
+		{
+			description: "#2 series never present but recording rule doesn't produce required label",
+			content:     "- record: foo\n  expr: sum(foo:bar{job=\"xxx\"})\n",
+			checker:     newSeriesCheck,
+			prometheus:  newSimpleProm,
+			entries:     mustParseContent("- record: foo:bar\n  expr: sum(foo) by(instance)\n"),
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: `foo:bar{job="xxx"}`,
+						Lines:    []int{2},
+						Reporter: checks.SeriesCheckName,
+						Text:     noMetricRRMismatchText("prom", uri, "foo:bar", "1w", "`foo:bar` recording rule defined at fake.yml:1 doesn't produce `job` label", `foo:bar{job="xxx"}`),
+						Severity: checks.Bug,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: `count(foo:bar{job="xxx"})`},
+					},
+					resp: respondWithEmptyVector(),
+				},
+				{
+					conds: []requestCondition{
+						requireRangeQueryPath,
+						formCond{key: "query", value: `count(foo:bar)`},
+					},
+					resp: respondWithEmptyMatrix(),
+				},
+				{
+					conds: []requestCondition{
+						requireRangeQueryPath,
+						formCond{key: "query", value: "count(up)"},
+					},
+					resp: respondWithSingleRangeVector1W(),
+				},
+			},
+		},
+		{
+			description: "#2 series never present but recording rule removes required label",
+			content:     "- record: foo\n  expr: sum(foo:bar{job=\"xxx\"})\n",
+			checker:     newSeriesCheck,
+			prometheus:  newSimpleProm,
+			entries:     mustParseContent("- record: foo:bar\n  expr: sum(foo) without(job)\n"),
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: `foo:bar{job="xxx"}`,
+						Lines:    []int{2},
+						Reporter: checks.SeriesCheckName,
+						Text:     noMetricRRMismatchText("prom", uri, "foo:bar", "1w", "`foo:bar` recording rule defined at fake.yml:1 doesn't produce `job` label", `foo:bar{job="xxx"}`),
+						Severity: checks.Bug,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: `count(foo:bar{job="xxx"})`},
+					},
+					resp: respondWithEmptyVector(),
+				},
+				{
+					conds: []requestCondition{
+						requireRangeQueryPath,
+						formCond{key: "query", value: `count(foo:bar)`},
+					},
+					resp: respondWithEmptyMatrix(),
+				},
+				{
+					conds: []requestCondition{
+						requireRangeQueryPath,
+						formCond{key: "query", value: "count(up)"},
+					},
+					resp: respondWithSingleRangeVector1W(),
+				},
+			},
+		},
+		{
+			description: "#2 series never present but recording rule sets a different label value",
+			content:     "- record: foo\n  expr: sum(foo:bar{job=\"xxx\"})\n",
+			checker:     newSeriesCheck,
+			prometheus:  newSimpleProm,
+			entries:     mustParseContent("- record: foo:bar\n  expr: sum(foo)\n  labels:\n    job: yyy\n"),
+			problems: func(uri string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: `foo:bar{job="xxx"}`,
+						Lines:    []int{2},
+						Reporter: checks.SeriesCheckName,
+						Text:     noMetricRRMismatchText("prom", uri, "foo:bar", "1w", "`foo:bar` recording rule defined at fake.yml:1 sets `job=\"yyy\"` label", `foo:bar{job="xxx"}`),
+						Severity: checks.Bug,
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: `count(foo:bar{job="xxx"})`},
+					},
+					resp: respondWithEmptyVector(),
+				},
+				{
+					conds: []requestCondition{
+						requireRangeQueryPath,
+						formCond{key: "query", value: `count(foo:bar)`},
+					},
+					resp: respondWithEmptyMatrix(),
+				},
+				{
+					conds: []requestCondition{
+						requireRangeQueryPath,
+						formCond{key: "query", value: "count(up)"},
+					},
+					resp: respondWithSingleRangeVector1W(),
+				},
+			},
+		},
+		{
+			description: "#2 series never present but one of recording rules provides it",
+			content:     "- record: foo\n  expr: sum(foo:bar{job=\"xxx\"})\n",
+			checker:     newSeriesCheck,
+			prometheus:  newSimpleProm,
+			entries:     mustParseContent("- record: foo:bar\n  expr: sum(foo)\n  labels:\n    job: yyy\n- record: foo:bar\n  expr: sum(bar)\n  labels:\n    job: xxx\n"),
 			problems: func(uri string) []checks.Problem {
 				return []checks.Problem{
 					{
@@ -553,7 +721,7 @@ func TestSeriesCheck(t *testing.T) {
 			content:     "- record: foo\n  expr: sum(foo:bar{job=\"xxx\"})\n",
 			checker:     newSeriesCheck,
 			prometheus:  newSimpleProm,
-			entries:     mustParseContent("- record: foo:bar\n  expr: sum(foo:bar)\n"),
+			entries:     mustParseContent("- record: foo:bar\n  expr: sum(foo:bar) by(job)\n"),
 			problems: func(uri string) []checks.Problem {
 				return []checks.Problem{
 					{
@@ -2,6 +2,7 @@ package checks
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/cloudflare/pint/internal/discovery"
@@ -33,12 +34,20 @@ func (c SyntaxCheck) Reporter() string {
 func (c SyntaxCheck) Check(_ context.Context, _ string, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
 	q := rule.Expr()
 	if q.SyntaxError != nil {
+		var tr *TextRange
+		var perr parser.PromQLError
+		if errors.As(q.SyntaxError, &perr) {
+			if pr, ok := perr.PositionRange(); ok {
+				tr = exprRange(q, pr)
+			}
+		}
 		problems = append(problems, Problem{
 			Fragment: q.Value.Value,
 			Lines:    q.Value.Position.Lines,
 			Reporter: c.Reporter(),
 			Text:     fmt.Sprintf("syntax error: %s", q.SyntaxError),
 			Severity: Fatal,
+			Range:    tr,
 		})
 	}
 	return problems
@@ -57,6 +57,7 @@ func TestSyntaxCheck(t *testing.T) {
 						Reporter: "promql/syntax",
 						Text:     "syntax error: unclosed left parenthesis",
 						Severity: checks.Fatal,
+						Range:    &checks.TextRange{Line: 2, Column: 21, EndLine: 2, EndColumn: 21},
 					},
 				}
 			},
@@ -41,26 +41,27 @@ func (c VectorMatchingCheck) Reporter() string {
 	return VectorMatchingCheckName
 }
 
-func (c VectorMatchingCheck) Check(ctx context.Context, _ string, rule parser.Rule, _ []discovery.Entry) (problems []Problem) {
+func (c VectorMatchingCheck) Check(ctx context.Context, _ string, rule parser.Rule, entries []discovery.Entry) (problems []Problem) {
 	expr := rule.Expr()
 	if expr.SyntaxError != nil {
 		return nil
 	}
 
-	for _, problem := range c.checkNode(ctx, expr.Query) {
+	for _, problem := range c.checkNode(ctx, expr.Query, entries) {
 		problems = append(problems, Problem{
 			Fragment: problem.expr,
 			Lines:    expr.Lines(),
 			Reporter: c.Reporter(),
 			Text:     problem.text,
 			Severity: problem.severity,
+			Range:    nodeRange(expr, problem.node),
 		})
 	}
 
 	return problems
 }
 
-func (c VectorMatchingCheck) checkNode(ctx context.Context, node *parser.PromQLNode) (problems []exprProblem) {
+func (c VectorMatchingCheck) checkNode(ctx context.Context, node *parser.PromQLNode, entries []discovery.Entry) (problems []exprProblem) {
 	if n, ok := utils.RemoveConditions(node.Node.String()).(*promParser.BinaryExpr); ok &&
 		n.VectorMatching != nil &&
 		n.Op != promParser.LOR &&
@@ -72,6 +73,7 @@ func (c VectorMatchingCheck) checkNode(ctx context.Context, node *parser.PromQLN
 			text, severity := textAndSeverityFromError(err, c.Reporter(), c.prom.Name(), Bug)
 			problems = append(problems, exprProblem{
 				expr:     node.Expr,
+				node:     node.Node,
 				text:     text,
 				severity: severity,
 			})
@@ -115,6 +117,7 @@ func (c VectorMatchingCheck) checkNode(ctx context.Context, node *parser.PromQLN
 				if rv, ok := rhsMatchers[k]; ok && rv != lv {
 					problems = append(problems, exprProblem{
 						expr:     node.Expr,
+						node:     node.Node,
 						text:     fmt.Sprintf("left hand side uses {%s=%q} while right hand side uses {%s=%q}, this will never match", k, lv, k, rv),
 						severity: Bug,
 					})
@@ -128,11 +131,18 @@ func (c VectorMatchingCheck) checkNode(ctx context.Context, node *parser.PromQLN
 			text, severity := textAndSeverityFromError(err, c.Reporter(), c.prom.Name(), Bug)
 			problems = append(problems, exprProblem{
 				expr:     node.Expr,
+				node:     node.Node,
 				text:     text,
 				severity: severity,
 			})
 			return problems
 		}
+		if leftLabels == nil {
+			leftLabels = recordingRulesLabels(n.LHS, entries, ignored...)
+		}
+		if leftLabels == nil {
+			leftLabels = inferredLabels(n.LHS, ignored...)
+		}
 		if leftLabels == nil {
 			goto NEXT
 		}
@@ -142,11 +152,18 @@ func (c VectorMatchingCheck) checkNode(ctx context.Context, node *parser.PromQLN
 			text, severity := textAndSeverityFromError(err, c.Reporter(), c.prom.Name(), Bug)
 			problems = append(problems, exprProblem{
 				expr:     node.Expr,
+				node:     node.Node,
 				text:     text,
 				severity: severity,
 			})
 			return problems
 		}
+		if rightLabels == nil {
+			rightLabels = recordingRulesLabels(n.RHS, entries, ignored...)
+		}
+		if rightLabels == nil {
+			rightLabels = inferredLabels(n.RHS, ignored...)
+		}
 		if rightLabels == nil {
 			goto NEXT
 		}
@@ -156,6 +173,7 @@ func (c VectorMatchingCheck) checkNode(ctx context.Context, node *parser.PromQLN
 				if !leftLabels.hasName(name) && rightLabels.hasName(name) {
 					problems = append(problems, exprProblem{
 						expr:     node.Expr,
+						node:     node.Node,
 						text:     fmt.Sprintf("using on(%q) won't produce any results because left hand side of the query doesn't have this label: %q", name, node.Node.(*promParser.BinaryExpr).LHS),
 						severity: Bug,
 					})
@@ -163,6 +181,7 @@ func (c VectorMatchingCheck) checkNode(ctx context.Context, node *parser.PromQLN
 				if leftLabels.hasName(name) && !rightLabels.hasName(name) {
 					problems = append(problems, exprProblem{
 						expr:     node.Expr,
+						node:     node.Node,
 						text:     fmt.Sprintf("using on(%q) won't produce any results because right hand side of the query doesn't have this label: %q", name, node.Node.(*promParser.BinaryExpr).RHS),
 						severity: Bug,
 					})
@@ -170,6 +189,7 @@ func (c VectorMatchingCheck) checkNode(ctx context.Context, node *parser.PromQLN
 				if !leftLabels.hasName(name) && !rightLabels.hasName(name) {
 					problems = append(problems, exprProblem{
 						expr:     node.Expr,
+						node:     node.Node,
 						text:     fmt.Sprintf("using on(%q) won't produce any results because both sides of the query don't have this label", name),
 						severity: Bug,
 					})
@@ -180,12 +200,14 @@ func (c VectorMatchingCheck) checkNode(ctx context.Context, node *parser.PromQLN
 			if len(n.VectorMatching.MatchingLabels) == 0 {
 				problems = append(problems, exprProblem{
 					expr:     node.Expr,
+					node:     node.Node,
 					text:     fmt.Sprintf("both sides of the query have different labels: %s != %s", l, r),
 					severity: Bug,
 				})
 			} else {
 				problems = append(problems, exprProblem{
 					expr:     node.Expr,
+					node:     node.Node,
 					text:     fmt.Sprintf("using ignoring(%q) won't produce any results because both sides of the query have different labels: %s != %s", strings.Join(n.VectorMatching.MatchingLabels, ","), l, r),
 					severity: Bug,
 				})
@@ -195,7 +217,7 @@ func (c VectorMatchingCheck) checkNode(ctx context.Context, node *parser.PromQLN
 
 NEXT:
 	for _, child := range node.Children {
-		problems = append(problems, c.checkNode(ctx, child)...)
+		problems = append(problems, c.checkNode(ctx, child, entries)...)
 	}
 
 	return problems
@@ -237,6 +259,66 @@ func (c VectorMatchingCheck) seriesLabels(ctx context.Context, query string, ign
 	return lsets, nil
 }
 
+// recordingRulesLabels returns label sets of time series produced by recording
+// rules from entries for a query that is a plain vector selector.
+// It returns nil if there are no such rules or if labels produced by any of
+// them can't be fully inferred.
+func recordingRulesLabels(expr promParser.Expr, entries []discovery.Entry, ignored ...model.LabelName) (lsets labelSets) {
+	for {
+		pe, ok := expr.(*promParser.ParenExpr)
+		if !ok {
+			break
+		}
+		expr = pe.Expr
+	}
+	vs, ok := expr.(*promParser.VectorSelector)
+	if !ok || vs.Name == "" {
+		return nil
+	}
+	for _, entry := range findRecordingRules(entries, vs.Name) {
+		if recordingRuleMismatch(*vs, entry) != "" {
+			continue
+		}
+		rl, static := recordingRuleLabels(entry.Rule.RecordingRule)
+		if !rl.IsExact {
+			return nil
+		}
+		var ls labelSet
+		for _, name := range rl.Names() {
+			if !slices.Contains(ignored, model.LabelName(name)) {
+				ls.add(name)
+			}
+		}
+		for name := range static {
+			if !slices.Contains(ignored, model.LabelName(name)) {
+				ls.add(name)
+			}
+		}
+		sort.Strings(ls.names)
+		lsets = append(lsets, ls)
+	}
+	return lsets
+}
+
+// inferredLabels returns label sets of time series returned by a query
+// if these can be fully inferred from the query itself, for example
+// when it's an aggregation with by() clause.
+// It returns nil if some of the labels can't be known without running the query.
+func inferredLabels(expr promParser.Expr, ignored ...model.LabelName) labelSets {
+	rl := utils.InferLabels(&parser.PromQLNode{Node: expr})
+	if !rl.IsExact {
+		return nil
+	}
+	var ls labelSet
+	for _, name := range rl.Names() {
+		if !slices.Contains(ignored, model.LabelName(name)) {
+			ls.add(name)
+		}
+	}
+	sort.Strings(ls.names)
+	return labelSets{ls}
+}
+
 type labelSet struct {
 	names []string
 }
@@ -56,6 +56,7 @@ func TestVectorMatchingCheck(t *testing.T) {
 						Reporter: checks.VectorMatchingCheckName,
 						Text:     differentLabelsText("instance, job, notfound", "instance, job"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 32},
 					},
 				}
 			},
@@ -171,6 +172,202 @@ func TestVectorMatchingCheck(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "missing right side provided by recording rule / passing",
+			content:     "- record: foo\n  expr: foo / xxx\n",
+			checker:     newVectorMatchingCheck,
+			prometheus:  newSimpleProm,
+			entries:     mustParseContent("- record: xxx\n  expr: sum(bar) by(job, instance)\n"),
+			problems:    noProblems,
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(foo / xxx)"},
+					},
+					resp: respondWithEmptyVector(),
+				},
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(foo) without(__name__)"},
+					},
+					resp: vectorResponse{
+						samples: []*model.Sample{
+							generateSample(map[string]string{
+								"instance": "aaa",
+								"job":      "bbb",
+							}),
+						},
+					},
+				},
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(xxx) without(__name__)"},
+					},
+					resp: respondWithEmptyVector(),
+				},
+			},
+		},
+		{
+			description: "missing right side provided by recording rule with unknown labels",
+			content:     "- record: foo\n  expr: foo / xxx\n",
+			checker:     newVectorMatchingCheck,
+			prometheus:  newSimpleProm,
+			entries:     mustParseContent("- record: xxx\n  expr: bar\n"),
+			problems:    noProblems,
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(foo / xxx)"},
+					},
+					resp: respondWithEmptyVector(),
+				},
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(foo) without(__name__)"},
+					},
+					resp: vectorResponse{
+						samples: []*model.Sample{
+							generateSample(map[string]string{
+								"instance": "aaa",
+								"job":      "bbb",
+							}),
+						},
+					},
+				},
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(xxx) without(__name__)"},
+					},
+					resp: respondWithEmptyVector(),
+				},
+			},
+		},
+		{
+			description: "missing right side provided by recording rule / mismatch",
+			content:     "- record: foo\n  expr: foo / xxx\n",
+			checker:     newVectorMatchingCheck,
+			prometheus:  newSimpleProm,
+			entries:     mustParseContent("- record: xxx\n  expr: sum(bar) by(instance)\n"),
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "foo / xxx",
+						Lines:    []int{2},
+						Reporter: checks.VectorMatchingCheckName,
+						Text:     differentLabelsText("instance, job", "instance"),
+						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 18},
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(foo / xxx)"},
+					},
+					resp: respondWithEmptyVector(),
+				},
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(foo) without(__name__)"},
+					},
+					resp: vectorResponse{
+						samples: []*model.Sample{
+							generateSample(map[string]string{
+								"instance": "aaa",
+								"job":      "bbb",
+							}),
+						},
+					},
+				},
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(xxx) without(__name__)"},
+					},
+					resp: respondWithEmptyVector(),
+				},
+			},
+		},
+		{
+			description: "missing both sides with labels inferred from the query / mismatch",
+			content:     "- record: foo\n  expr: sum(foo) by(job) / sum(bar) by(instance)\n",
+			checker:     newVectorMatchingCheck,
+			prometheus:  newSimpleProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "sum(foo) by(job) / sum(bar) by(instance)",
+						Lines:    []int{2},
+						Reporter: checks.VectorMatchingCheckName,
+						Text:     differentLabelsText("job", "instance"),
+						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 49},
+					},
+				}
+			},
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(sum by (job) (foo) / sum by (instance) (bar))"},
+					},
+					resp: respondWithEmptyVector(),
+				},
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(sum by (job) (foo)) without(__name__)"},
+					},
+					resp: respondWithEmptyVector(),
+				},
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(sum by (instance) (bar)) without(__name__)"},
+					},
+					resp: respondWithEmptyVector(),
+				},
+			},
+		},
+		{
+			description: "missing both sides with labels that can't be inferred from the query",
+			content:     "- record: foo\n  expr: sum(foo) by(job) / sum(bar) without(instance)\n",
+			checker:     newVectorMatchingCheck,
+			prometheus:  newSimpleProm,
+			problems:    noProblems,
+			mocks: []*prometheusMock{
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(sum by (job) (foo) / sum without (instance) (bar))"},
+					},
+					resp: respondWithEmptyVector(),
+				},
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(sum by (job) (foo)) without(__name__)"},
+					},
+					resp: respondWithEmptyVector(),
+				},
+				{
+					conds: []requestCondition{
+						requireQueryPath,
+						formCond{key: "query", value: "count(sum without (instance) (bar)) without(__name__)"},
+					},
+					resp: respondWithEmptyVector(),
+				},
+			},
+		},
 		{
 			description: "ignore missing or vector",
 			content:     "- record: foo\n  expr: sum(missing or vector(0))\n",
@@ -198,6 +395,7 @@ func TestVectorMatchingCheck(t *testing.T) {
 						Reporter: checks.VectorMatchingCheckName,
 						Text:     usingMismatchText(`ignoring("xxx")`, "instance, job", "app_name"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 41},
 					},
 				}
 			},
@@ -255,6 +453,7 @@ func TestVectorMatchingCheck(t *testing.T) {
 						Reporter: checks.VectorMatchingCheckName,
 						Text:     `using on("notfound") won't produce any results because both sides of the query don't have this label`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 31},
 					},
 				}
 			},
@@ -501,6 +700,7 @@ func TestVectorMatchingCheck(t *testing.T) {
 						Reporter: checks.VectorMatchingCheckName,
 						Text:     `using on("notfound") won't produce any results because left hand side of the query doesn't have this label: "foo"`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 45},
 					},
 				}
 			},
@@ -556,6 +756,7 @@ func TestVectorMatchingCheck(t *testing.T) {
 						Reporter: checks.VectorMatchingCheckName,
 						Text:     `using on("notfound") won't produce any results because right hand side of the query doesn't have this label: "bar"`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 45},
 					},
 				}
 			},
@@ -611,6 +812,7 @@ func TestVectorMatchingCheck(t *testing.T) {
 						Reporter: checks.VectorMatchingCheckName,
 						Text:     `using on("app_name") won't produce any results because left hand side of the query doesn't have this label: "(memory_bytes / ignoring (job) (memory_limit > 0))"`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 104},
 					},
 				}
 			},
@@ -799,6 +1001,7 @@ func TestVectorMatchingCheck(t *testing.T) {
 						Reporter: checks.VectorMatchingCheckName,
 						Text:     `both sides of the query have different labels: [instance, job, notfound] != [instance, job]`,
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 61},
 					},
 				}
 			},
@@ -927,6 +1130,7 @@ func TestVectorMatchingCheck(t *testing.T) {
 						Reporter: checks.VectorMatchingCheckName,
 						Text:     "both sides of the query have different labels: [instance, job] != [dev, instance, job]",
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 99},
 					},
 				}
 			},
@@ -1054,6 +1258,7 @@ func TestVectorMatchingCheck(t *testing.T) {
 						Reporter: checks.VectorMatchingCheckName,
 						Text:     checkErrorUnableToRun(checks.VectorMatchingCheckName, "prom", "http://127.0.0.1:1111", "connection refused"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 16},
 					},
 				}
 			},
@@ -1073,6 +1278,7 @@ func TestVectorMatchingCheck(t *testing.T) {
 						Reporter: checks.VectorMatchingCheckName,
 						Text:     checkErrorUnableToRun(checks.VectorMatchingCheckName, "prom", "http://127.0.0.1:1111", "connection refused"),
 						Severity: checks.Warning,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 16},
 					},
 				}
 			},
@@ -1092,6 +1298,7 @@ func TestVectorMatchingCheck(t *testing.T) {
 						Reporter: checks.VectorMatchingCheckName,
 						Text:     checkErrorUnableToRun(checks.VectorMatchingCheckName, "prom", uri, `server_error: internal error`),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 16},
 					},
 				}
 			},
@@ -1127,6 +1334,7 @@ func TestVectorMatchingCheck(t *testing.T) {
 						Reporter: checks.VectorMatchingCheckName,
 						Text:     checkErrorUnableToRun(checks.VectorMatchingCheckName, "prom", uri, `server_error: internal error`),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 16},
 					},
 				}
 			},
@@ -1174,6 +1382,7 @@ func TestVectorMatchingCheck(t *testing.T) {
 						Reporter: checks.VectorMatchingCheckName,
 						Text:     differentFilters("job", "a", "b"),
 						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 2, Column: 9, EndLine: 2, EndColumn: 34},
 					},
 				}
 			},
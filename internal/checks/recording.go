@@ -0,0 +1,74 @@
+package checks
+
+import (
+	"fmt"
+
+	"github.com/prometheus/prometheus/model/labels"
+	promParser "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+// findRecordingRules returns all valid recording rules from entries that
+// record given metric name.
+func findRecordingRules(entries []discovery.Entry, name string) (rules []discovery.Entry) {
+	for _, entry := range entries {
+		if entry.State == discovery.Removed || entry.PathError != nil || entry.Rule.Error.Err != nil {
+			continue
+		}
+		if entry.Rule.RecordingRule == nil || entry.Rule.RecordingRule.Record.Value.Value != name {
+			continue
+		}
+		rules = append(rules, entry)
+	}
+	return rules
+}
+
+func ruleLocation(entry discovery.Entry) string {
+	return fmt.Sprintf("%s:%d", entry.ReportedPath, entry.Rule.Lines()[0])
+}
+
+// recordingRuleLabels returns labels present on time series produced by
+// a recording rule, including static labels set in the labels block of
+// that rule.
+func recordingRuleLabels(rule *parser.RecordingRule) (ls utils.LabelSet, static map[string]string) {
+	static = map[string]string{}
+	if rule.Labels != nil {
+		for _, l := range rule.Labels.Items {
+			static[l.Key.Value] = l.Value.Value
+		}
+	}
+	if rule.Expr.SyntaxError != nil || rule.Expr.Query == nil {
+		return ls, static
+	}
+	return utils.InferLabels(rule.Expr.Query), static
+}
+
+// recordingRuleMismatch returns the reason why time series produced by given
+// recording rule will never match the selector, or an empty string if they
+// might.
+func recordingRuleMismatch(selector promParser.VectorSelector, entry discovery.Entry) string {
+	ls, static := recordingRuleLabels(entry.Rule.RecordingRule)
+	for _, lm := range selector.LabelMatchers {
+		if lm.Name == labels.MetricName {
+			continue
+		}
+		if v, ok := static[lm.Name]; ok {
+			if !lm.Matches(v) {
+				return fmt.Sprintf("`%s` recording rule defined at %s sets `%s=%q` label",
+					entry.Rule.RecordingRule.Record.Value.Value, ruleLocation(entry), lm.Name, v)
+			}
+			continue
+		}
+		if lm.Matches("") {
+			continue
+		}
+		if !ls.CanHave(lm.Name) {
+			return fmt.Sprintf("`%s` recording rule defined at %s doesn't produce `%s` label",
+				entry.Rule.RecordingRule.Record.Value.Value, ruleLocation(entry), lm.Name)
+		}
+	}
+	return ""
+}
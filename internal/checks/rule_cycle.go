@@ -0,0 +1,63 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/graph"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	RuleCycleCheckName = "rule/cycle"
+)
+
+func NewRuleCycleCheck() RuleCycleCheck {
+	return RuleCycleCheck{}
+}
+
+type RuleCycleCheck struct{}
+
+func (c RuleCycleCheck) Meta() CheckMeta {
+	return CheckMeta{IsOnline: false}
+}
+
+func (c RuleCycleCheck) String() string {
+	return RuleCycleCheckName
+}
+
+func (c RuleCycleCheck) Reporter() string {
+	return RuleCycleCheckName
+}
+
+func (c RuleCycleCheck) Check(ctx context.Context, path string, rule parser.Rule, entries []discovery.Entry) (problems []Problem) {
+	if rule.RecordingRule == nil || rule.RecordingRule.Expr.SyntaxError != nil {
+		return nil
+	}
+
+	g := graph.FromContext(ctx, entries)
+	node, ok := g.Find(path, rule)
+	if !ok {
+		return nil
+	}
+
+	cycle := g.FindCycle(node.ID)
+	if cycle == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(cycle))
+	for _, n := range cycle {
+		names = append(names, fmt.Sprintf("`%s`", n.Name))
+	}
+	problems = append(problems, Problem{
+		Fragment: rule.RecordingRule.Expr.Value.Value,
+		Lines:    rule.RecordingRule.Expr.Lines(),
+		Reporter: c.Reporter(),
+		Text:     fmt.Sprintf("this rule depends on its own results via a dependency cycle: %s", strings.Join(names, " -> ")),
+		Severity: Bug,
+	})
+	return problems
+}
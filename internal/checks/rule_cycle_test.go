@@ -0,0 +1,103 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newRuleCycleCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewRuleCycleCheck()
+}
+
+func TestRuleCycleCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: sum(foo) without(\n",
+			checker:     newRuleCycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries:     mustParseContent("- record: foo\n  expr: sum(foo) without(\n"),
+		},
+		{
+			description: "ignores alerting rules",
+			content:     "- alert: foo\n  expr: foo == 0\n",
+			checker:     newRuleCycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries:     mustParseContent("- alert: foo\n  expr: foo == 0\n- record: foo\n  expr: sum(up)\n"),
+		},
+		{
+			description: "ignores unknown rules",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newRuleCycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "no cycle",
+			content:     "- record: foo\n  expr: sum(bar)\n",
+			checker:     newRuleCycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries: concatEntries(
+				mustParseContent("- record: foo\n  expr: sum(bar)\n"),
+				mustParseContentAt("other.yml", "- record: bar\n  expr: sum(up)\n- record: up\n  expr: vector(1)\n"),
+			),
+		},
+		{
+			description: "rule using its own results",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newRuleCycleCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "sum(foo)",
+						Lines:    []int{2},
+						Reporter: checks.RuleCycleCheckName,
+						Text:     "this rule depends on its own results via a dependency cycle: `foo` -> `foo`",
+						Severity: checks.Bug,
+					},
+				}
+			},
+			entries: mustParseContent("- record: foo\n  expr: sum(foo)\n"),
+		},
+		{
+			description: "cycle across files",
+			content:     "- record: foo\n  expr: sum(bar)\n",
+			checker:     newRuleCycleCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "sum(bar)",
+						Lines:    []int{2},
+						Reporter: checks.RuleCycleCheckName,
+						Text:     "this rule depends on its own results via a dependency cycle: `foo` -> `bar` -> `baz` -> `foo`",
+						Severity: checks.Bug,
+					},
+				}
+			},
+			entries: concatEntries(
+				mustParseContent("- record: foo\n  expr: sum(bar)\n"),
+				mustParseContentAt("other.yml", "- record: bar\n  expr: sum(baz) + sum(up)\n- record: baz\n  expr: sum(foo{job=\"a\"})\n"),
+			),
+		},
+		{
+			description: "label mismatch breaks the cycle",
+			content:     "- record: foo\n  expr: sum(bar)\n  labels:\n    job: b\n",
+			checker:     newRuleCycleCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries: concatEntries(
+				mustParseContent("- record: foo\n  expr: sum(bar)\n  labels:\n    job: b\n"),
+				mustParseContentAt("other.yml", "- record: bar\n  expr: sum(foo{job=\"a\"})\n"),
+			),
+		},
+	}
+
+	runTests(t, testCases)
+}
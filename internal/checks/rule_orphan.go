@@ -0,0 +1,62 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/graph"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+const (
+	RuleOrphanCheckName = "rule/orphan"
+)
+
+func NewRuleOrphanCheck(severity Severity) RuleOrphanCheck {
+	return RuleOrphanCheck{severity: severity}
+}
+
+type RuleOrphanCheck struct {
+	severity Severity
+}
+
+func (c RuleOrphanCheck) Meta() CheckMeta {
+	return CheckMeta{IsOnline: false}
+}
+
+func (c RuleOrphanCheck) String() string {
+	return RuleOrphanCheckName
+}
+
+func (c RuleOrphanCheck) Reporter() string {
+	return RuleOrphanCheckName
+}
+
+func (c RuleOrphanCheck) Check(ctx context.Context, path string, rule parser.Rule, entries []discovery.Entry) (problems []Problem) {
+	if rule.RecordingRule == nil || rule.RecordingRule.Expr.SyntaxError != nil {
+		return nil
+	}
+
+	g := graph.FromContext(ctx, entries)
+	node, ok := g.Find(path, rule)
+	if !ok {
+		return nil
+	}
+
+	for _, consumer := range g.Consumers(node.ID) {
+		// Rules using their own results don't count, that's reported by rule/cycle.
+		if consumer.ID != node.ID {
+			return nil
+		}
+	}
+
+	problems = append(problems, Problem{
+		Fragment: fmt.Sprintf("%s: %s", rule.RecordingRule.Record.Key.Value, rule.RecordingRule.Record.Value.Value),
+		Lines:    rule.RecordingRule.Record.Lines(),
+		Reporter: c.Reporter(),
+		Text:     fmt.Sprintf("`%s` recording rule isn't used by any other rule in checked files", node.Name),
+		Severity: c.severity,
+	})
+	return problems
+}
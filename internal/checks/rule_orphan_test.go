@@ -0,0 +1,100 @@
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newRuleOrphanCheck(_ *promapi.FailoverGroup) checks.RuleChecker {
+	return checks.NewRuleOrphanCheck(checks.Warning)
+}
+
+func TestRuleOrphanCheck(t *testing.T) {
+	testCases := []checkTest{
+		{
+			description: "ignores rules with syntax errors",
+			content:     "- record: foo\n  expr: sum(foo) without(\n",
+			checker:     newRuleOrphanCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries:     mustParseContent("- record: foo\n  expr: sum(foo) without(\n"),
+		},
+		{
+			description: "ignores alerting rules",
+			content:     "- alert: foo\n  expr: up == 0\n",
+			checker:     newRuleOrphanCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries:     mustParseContent("- alert: foo\n  expr: up == 0\n"),
+		},
+		{
+			description: "ignores unknown rules",
+			content:     "- record: foo\n  expr: sum(up)\n",
+			checker:     newRuleOrphanCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+		},
+		{
+			description: "used by an alert",
+			content:     "- record: foo\n  expr: sum(up)\n",
+			checker:     newRuleOrphanCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries: concatEntries(
+				mustParseContent("- record: foo\n  expr: sum(up)\n"),
+				mustParseContentAt("other.yml", "- alert: foo\n  expr: foo == 0\n"),
+			),
+		},
+		{
+			description: "used by a recording rule",
+			content:     "- record: foo\n  expr: sum(up)\n",
+			checker:     newRuleOrphanCheck,
+			prometheus:  noProm,
+			problems:    noProblems,
+			entries:     mustParseContent("- record: foo\n  expr: sum(up)\n- record: bar\n  expr: sum({__name__=~\"fo+\"})\n"),
+		},
+		{
+			description: "not used",
+			content:     "- record: foo\n  expr: sum(up)\n  labels:\n    job: foo\n",
+			checker:     newRuleOrphanCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "record: foo",
+						Lines:    []int{1},
+						Reporter: checks.RuleOrphanCheckName,
+						Text:     "`foo` recording rule isn't used by any other rule in checked files",
+						Severity: checks.Warning,
+					},
+				}
+			},
+			entries: concatEntries(
+				mustParseContent("- record: foo\n  expr: sum(up)\n  labels:\n    job: foo\n"),
+				mustParseContentAt("other.yml", "- alert: foo\n  expr: foo{job=\"bar\"} == 0\n- alert: bar\n  expr: bar == 0\n"),
+			),
+		},
+		{
+			description: "only used by itself",
+			content:     "- record: foo\n  expr: sum(foo)\n",
+			checker:     newRuleOrphanCheck,
+			prometheus:  noProm,
+			problems: func(_ string) []checks.Problem {
+				return []checks.Problem{
+					{
+						Fragment: "record: foo",
+						Lines:    []int{1},
+						Reporter: checks.RuleOrphanCheckName,
+						Text:     "`foo` recording rule isn't used by any other rule in checked files",
+						Severity: checks.Warning,
+					},
+				}
+			},
+			entries: mustParseContent("- record: foo\n  expr: sum(foo)\n"),
+		},
+	}
+
+	runTests(t, testCases)
+}
@@ -0,0 +1,16 @@
+package config
+
+import (
+	"errors"
+)
+
+type Alertmanager struct {
+	Path string `hcl:"path" json:"path"`
+}
+
+func (am Alertmanager) validate() error {
+	if am.Path == "" {
+		return errors.New("alertmanager config path cannot be empty")
+	}
+	return nil
+}
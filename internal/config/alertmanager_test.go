@@ -0,0 +1,37 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertmanagerSettings(t *testing.T) {
+	type testCaseT struct {
+		conf Alertmanager
+		err  error
+	}
+
+	testCases := []testCaseT{
+		{
+			conf: Alertmanager{},
+			err:  errors.New("alertmanager config path cannot be empty"),
+		},
+		{
+			conf: Alertmanager{Path: "alertmanager.yml"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%v", tc.conf), func(t *testing.T) {
+			err := tc.conf.validate()
+			if err == nil || tc.err == nil {
+				require.Equal(t, err, tc.err)
+			} else {
+				require.EqualError(t, err, tc.err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"errors"
+)
+
+type Cache struct {
+	Dir    string `hcl:"dir" json:"dir"`
+	MaxAge string `hcl:"maxAge,optional" json:"maxAge,omitempty"`
+}
+
+func (c Cache) validate() error {
+	if c.Dir == "" {
+		return errors.New("cache dir cannot be empty")
+	}
+	if c.MaxAge != "" {
+		if _, err := parseDuration(c.MaxAge); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheSettings(t *testing.T) {
+	type testCaseT struct {
+		conf Cache
+		err  error
+	}
+
+	testCases := []testCaseT{
+		{
+			conf: Cache{},
+			err:  errors.New("cache dir cannot be empty"),
+		},
+		{
+			conf: Cache{Dir: ".cache"},
+		},
+		{
+			conf: Cache{Dir: ".cache", MaxAge: "1h"},
+		},
+		{
+			conf: Cache{Dir: ".cache", MaxAge: "foo"},
+			err:  errors.New(`not a valid duration string: "foo"`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%v", tc.conf), func(t *testing.T) {
+			err := tc.conf.validate()
+			if err == nil || tc.err == nil {
+				require.Equal(t, err, tc.err)
+			} else {
+				require.EqualError(t, err, tc.err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package config
+
+import "errors"
+
+type CheckstyleReporterSettings struct {
+	Path string `hcl:"path" json:"path"`
+}
+
+func (settings CheckstyleReporterSettings) validate() error {
+	if settings.Path == "" {
+		return errors.New("empty path")
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckstyleReporterSettings(t *testing.T) {
+	type testCaseT struct {
+		conf CheckstyleReporterSettings
+		err  error
+	}
+
+	testCases := []testCaseT{
+		{
+			conf: CheckstyleReporterSettings{Path: "checkstyle.xml"},
+		},
+		{
+			conf: CheckstyleReporterSettings{},
+			err:  errors.New("empty path"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%v", tc.conf), func(t *testing.T) {
+			err := tc.conf.validate()
+			if err == nil || tc.err == nil {
+				require.Equal(t, err, tc.err)
+			} else {
+				require.EqualError(t, err, tc.err.Error())
+			}
+		})
+	}
+}
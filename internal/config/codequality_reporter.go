@@ -0,0 +1,15 @@
+package config
+
+import "errors"
+
+type CodeQualityReporterSettings struct {
+	Path string `hcl:"path" json:"path"`
+}
+
+func (settings CodeQualityReporterSettings) validate() error {
+	if settings.Path == "" {
+		return errors.New("empty path")
+	}
+
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/zclconf/go-cty/cty"
 
+	"github.com/cloudflare/pint/internal/alertmanager"
 	"github.com/cloudflare/pint/internal/checks"
 	"github.com/cloudflare/pint/internal/parser"
 	"github.com/cloudflare/pint/internal/promapi"
@@ -25,16 +26,19 @@ import (
 )
 
 type Config struct {
-	CI                *CI                      `hcl:"ci,block" json:"ci,omitempty"`
-	Parser            *Parser                  `hcl:"parser,block" json:"parser,omitempty"`
-	Repository        *Repository              `hcl:"repository,block" json:"repository,omitempty"`
-	Prometheus        []PrometheusConfig       `hcl:"prometheus,block" json:"prometheus,omitempty"`
-	Checks            *Checks                  `hcl:"checks,block" json:"checks,omitempty"`
-	Check             []Check                  `hcl:"check,block" json:"check,omitempty"`
-	Rules             []Rule                   `hcl:"rule,block" json:"rules,omitempty"`
-	Owners            *Owners                  `hcl:"owners,block" json:"owners,omitempty"`
-	PrometheusServers []*promapi.FailoverGroup `json:"-"`
-	Reporters         *Reporters               `hcl:"reporters,block" json:"reporters,omitempty"`
+	CI                 *CI                      `hcl:"ci,block" json:"ci,omitempty"`
+	Parser             *Parser                  `hcl:"parser,block" json:"parser,omitempty"`
+	Repository         *Repository              `hcl:"repository,block" json:"repository,omitempty"`
+	Prometheus         []PrometheusConfig       `hcl:"prometheus,block" json:"prometheus,omitempty"`
+	Checks             *Checks                  `hcl:"checks,block" json:"checks,omitempty"`
+	Check              []Check                  `hcl:"check,block" json:"check,omitempty"`
+	Rules              []Rule                   `hcl:"rule,block" json:"rules,omitempty"`
+	Owners             *Owners                  `hcl:"owners,block" json:"owners,omitempty"`
+	PrometheusServers  []*promapi.FailoverGroup `json:"-"`
+	Reporters          *Reporters               `hcl:"reporters,block" json:"reporters,omitempty"`
+	Alertmanager       *Alertmanager            `hcl:"alertmanager,block" json:"alertmanager,omitempty"`
+	AlertmanagerConfig *alertmanager.Config     `json:"-"`
+	Cache              *Cache                   `hcl:"cache,block" json:"cache,omitempty"`
 }
 
 func (cfg *Config) DisableOnlineChecks() {
@@ -84,6 +88,24 @@ func (cfg Config) String() string {
 	return string(content)
 }
 
+// PrometheusServersForRule returns all Prometheus servers that a rule from
+// given path is evaluated on, based on path filters and resource labels.
+func (cfg *Config) PrometheusServersForRule(path string, r parser.Rule) []*promapi.FailoverGroup {
+	proms := []*promapi.FailoverGroup{}
+	for _, prom := range cfg.Prometheus {
+		if !prom.isEnabledForPath(path) || !prom.isEnabledForRule(r) {
+			continue
+		}
+		for _, p := range cfg.PrometheusServers {
+			if p.Name() == prom.Name {
+				proms = append(proms, p)
+				break
+			}
+		}
+	}
+	return proms
+}
+
 func (cfg *Config) GetChecksForRule(ctx context.Context, path string, r parser.Rule, disabledChecks []string) []checks.RuleChecker {
 	enabled := []checks.RuleChecker{}
 
@@ -112,21 +134,20 @@ func (cfg *Config) GetChecksForRule(ctx context.Context, path string, r parser.R
 			name:  checks.RegexpCheckName,
 			check: checks.NewRegexpCheck(),
 		},
+		{
+			name:  checks.RuleCycleCheckName,
+			check: checks.NewRuleCycleCheck(),
+		},
 	}
 
-	proms := []*promapi.FailoverGroup{}
-	for _, prom := range cfg.Prometheus {
-		if !prom.isEnabledForPath(path) {
-			continue
-		}
-		for _, p := range cfg.PrometheusServers {
-			if p.Name() == prom.Name {
-				proms = append(proms, p)
-				break
-			}
-		}
+	if cfg.AlertmanagerConfig != nil {
+		allChecks = append(allChecks, checkMeta{
+			name:  checks.RouteCheckName,
+			check: checks.NewRouteCheck(cfg.Alertmanager.Path, cfg.AlertmanagerConfig),
+		})
 	}
 
+	proms := cfg.PrometheusServersForRule(path, r)
 	for _, p := range proms {
 		allChecks = append(allChecks, checkMeta{
 			name:  checks.RateCheckName,
@@ -163,12 +184,34 @@ func (cfg *Config) GetChecksForRule(ctx context.Context, path string, r parser.R
 			check: checks.NewLabelsConflictCheck(p),
 			tags:  p.Tags(),
 		})
+		allChecks = append(allChecks, checkMeta{
+			name:  checks.GroupIntervalCheckName,
+			check: checks.NewGroupIntervalCheck(p),
+			tags:  p.Tags(),
+		})
+		allChecks = append(allChecks, checkMeta{
+			name:  checks.AlertsDependencyCheckName,
+			check: checks.NewAlertsDependencyCheck(p, cfg.PrometheusServersForRule),
+			tags:  p.Tags(),
+		})
 	}
 
 	for _, rule := range cfg.Rules {
 		allChecks = append(allChecks, rule.resolveChecks(ctx, path, r, proms)...)
 	}
 
+	available := make([]string, 0, len(allChecks)*2)
+	for _, cm := range allChecks {
+		available = append(available, cm.name, cm.check.String())
+		for _, tag := range cm.tags {
+			available = append(available, fmt.Sprintf("%s(+%s)", cm.name, tag))
+		}
+	}
+	allChecks = append(allChecks, checkMeta{
+		name:  checks.CommentsCheckName,
+		check: checks.NewCommentsCheck(available),
+	})
+
 	for _, cm := range allChecks {
 		// check if check is disabled for specific rule
 		if !isEnabled(cfg.Checks.Enabled, disabledChecks, r, cm.name, cm.check, cm.tags) {
@@ -279,12 +322,48 @@ func Load(path string, failOnMissing bool) (cfg Config, err error) {
 		}
 	}
 
+	if cfg.Repository != nil && cfg.Repository.GitLab != nil {
+		if cfg.Repository.GitLab.Timeout == "" {
+			cfg.Repository.GitLab.Timeout = time.Minute.String()
+		}
+		if err = cfg.Repository.GitLab.validate(); err != nil {
+			return cfg, err
+		}
+	}
+
 	if cfg.Checks != nil {
 		if err = cfg.Checks.validate(); err != nil {
 			return cfg, err
 		}
 	}
 
+	if cfg.Reporters != nil {
+		if err = cfg.Reporters.validate(); err != nil {
+			return cfg, err
+		}
+	}
+
+	if cfg.Alertmanager != nil {
+		if err = cfg.Alertmanager.validate(); err != nil {
+			return cfg, err
+		}
+		if cfg.AlertmanagerConfig, err = alertmanager.Load(cfg.Alertmanager.Path); err != nil {
+			return cfg, err
+		}
+	}
+
+	var diskCache *promapi.DiskCache
+	if cfg.Cache != nil {
+		if err = cfg.Cache.validate(); err != nil {
+			return cfg, err
+		}
+		var maxAge time.Duration
+		if cfg.Cache.MaxAge != "" {
+			maxAge, _ = parseDuration(cfg.Cache.MaxAge)
+		}
+		diskCache = promapi.NewDiskCache(cfg.Cache.Dir, maxAge)
+	}
+
 	for _, chk := range cfg.Check {
 		if err = chk.validate(); err != nil {
 			return cfg, err
@@ -333,8 +412,11 @@ func Load(path string, failOnMissing bool) (cfg Config, err error) {
 		if err != nil {
 			return cfg, fmt.Errorf("invalid prometheus TLS configuration: %w", err)
 		}
-		upstreams := []*promapi.Prometheus{
-			promapi.NewPrometheus(prom.Name, prom.URI, prom.Headers, timeout, concurrency, rateLimit, tlsConf),
+		var upstreams []*promapi.Prometheus
+		if prom.Path != "" {
+			upstreams = append(upstreams, promapi.NewFixturePrometheus(prom.Name, prom.Path, timeout, concurrency))
+		} else {
+			upstreams = append(upstreams, promapi.NewPrometheus(prom.Name, prom.URI, prom.Headers, timeout, concurrency, rateLimit, tlsConf))
 		}
 		for _, uri := range prom.Failover {
 			upstreams = append(upstreams, promapi.NewPrometheus(prom.Name, uri, prom.Headers, timeout, concurrency, rateLimit, tlsConf))
@@ -346,7 +428,11 @@ func Load(path string, failOnMissing bool) (cfg Config, err error) {
 		for _, path := range prom.Exclude {
 			exclude = append(exclude, strictRegex(path))
 		}
-		cfg.PrometheusServers = append(cfg.PrometheusServers, promapi.NewFailoverGroup(prom.Name, upstreams, prom.Required, uptime, include, exclude, prom.Tags))
+		fg := promapi.NewFailoverGroup(prom.Name, upstreams, prom.Required, uptime, include, exclude, prom.Tags)
+		if diskCache != nil {
+			fg.SetDiskCache(diskCache)
+		}
+		cfg.PrometheusServers = append(cfg.PrometheusServers, fg)
 	}
 
 	for _, rule := range cfg.Rules {
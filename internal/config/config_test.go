@@ -120,6 +120,22 @@ func newRule(t *testing.T, content string) parser.Rule {
 	return rules[0]
 }
 
+const prometheusRule = `
+apiVersion: monitoring.coreos.com/v1
+kind: PrometheusRule
+metadata:
+  name: example
+  namespace: monitoring
+  labels:
+    prometheus: k8s
+spec:
+  groups:
+  - name: example
+    rules:
+    - record: foo
+      expr: sum(foo)
+`
+
 func TestGetChecksForRule(t *testing.T) {
 	type testCaseT struct {
 		title          string
@@ -143,6 +159,8 @@ func TestGetChecksForRule(t *testing.T) {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -161,13 +179,17 @@ prometheus "prom" {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.RateCheckName + "(prom)",
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName, checks.RateCheckName + "(prom)",
 				checks.CounterCheckName + "(prom)",
 				checks.SeriesCheckName + "(prom)",
 				checks.VectorMatchingCheckName + "(prom)",
 				checks.RangeQueryCheckName + "(prom)",
 				checks.RuleDuplicateCheckName + "(prom)",
 				checks.LabelsConflictCheckName + "(prom)",
+				checks.GroupIntervalCheckName + "(prom)",
+				checks.AlertsDependencyCheckName + "(prom)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -187,13 +209,17 @@ prometheus "prom" {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.RateCheckName + "(prom)",
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName, checks.RateCheckName + "(prom)",
 				checks.CounterCheckName + "(prom)",
 				checks.SeriesCheckName + "(prom)",
 				checks.VectorMatchingCheckName + "(prom)",
 				checks.RangeQueryCheckName + "(prom)",
 				checks.RuleDuplicateCheckName + "(prom)",
 				checks.LabelsConflictCheckName + "(prom)",
+				checks.GroupIntervalCheckName + "(prom)",
+				checks.AlertsDependencyCheckName + "(prom)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -220,6 +246,7 @@ checks {
 # pint disable promql/range_query
 # pint disable rule/duplicate
 # pint disable labels/conflict
+# pint disable group/interval
 - record: foo
   expr: sum(foo)
 `),
@@ -229,6 +256,10 @@ checks {
 				checks.ComparisonCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.AlertsDependencyCheckName + "(prom1)",
+				checks.AlertsDependencyCheckName + "(prom2)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -249,6 +280,8 @@ prometheus "prom" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -270,6 +303,8 @@ prometheus "prom" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -290,6 +325,8 @@ prometheus "prom" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -309,13 +346,17 @@ prometheus "prom" {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.RateCheckName + "(prom)",
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName, checks.RateCheckName + "(prom)",
 				checks.CounterCheckName + "(prom)",
 				checks.SeriesCheckName + "(prom)",
 				checks.VectorMatchingCheckName + "(prom)",
 				checks.RangeQueryCheckName + "(prom)",
 				checks.RuleDuplicateCheckName + "(prom)",
 				checks.LabelsConflictCheckName + "(prom)",
+				checks.GroupIntervalCheckName + "(prom)",
+				checks.AlertsDependencyCheckName + "(prom)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -340,13 +381,17 @@ prometheus "ignore" {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.RateCheckName + "(prom)",
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName, checks.RateCheckName + "(prom)",
 				checks.CounterCheckName + "(prom)",
 				checks.SeriesCheckName + "(prom)",
 				checks.VectorMatchingCheckName + "(prom)",
 				checks.RangeQueryCheckName + "(prom)",
 				checks.RuleDuplicateCheckName + "(prom)",
 				checks.LabelsConflictCheckName + "(prom)",
+				checks.GroupIntervalCheckName + "(prom)",
+				checks.AlertsDependencyCheckName + "(prom)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -361,6 +406,8 @@ prometheus "ignore" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -384,9 +431,11 @@ rule {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.AggregationCheckName + "(job:true)",
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName, checks.AggregationCheckName + "(job:true)",
 				checks.AggregationCheckName + "(instance:false)",
 				checks.AggregationCheckName + "(rack:false)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -412,8 +461,60 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
 				checks.OffsetCheckName + "(^cloud1_.*$)",
 				checks.OffsetCheckName + "(^cloud2_.*$)",
+				checks.CommentsCheckName,
+			},
+		},
+		{
+			title: "rule with orphan check",
+			config: `
+rule {
+  match {
+    kind = "recording"
+  }
+  orphan {
+    severity = "info"
+  }
+}`,
+			path: "rules.yml",
+			rule: newRule(t, "- record: foo\n  expr: sum(foo)\n"),
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.RuleOrphanCheckName,
+				checks.CommentsCheckName,
+			},
+		},
+		{
+			title: "rule with routing labels check",
+			config: `
+rule {
+  match {
+    kind = "alerting"
+  }
+  routing {
+    labels = ["team", "severity"]
+  }
+}`,
+			path: "rules.yml",
+			rule: newRule(t, "- alert: foo\n  expr: sum(foo) > 0\n"),
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.RoutingLabelsCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -441,8 +542,10 @@ rule {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.AggregationCheckName + "(job:true)",
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName, checks.AggregationCheckName + "(job:true)",
 				checks.AggregationCheckName + "(rack:false)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -464,6 +567,8 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -502,15 +607,21 @@ prometheus "prom2" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
 				checks.RateCheckName + "(prom1)",
 				checks.CounterCheckName + "(prom1)",
 				checks.RangeQueryCheckName + "(prom1)",
 				checks.LabelsConflictCheckName + "(prom1)",
+				checks.GroupIntervalCheckName + "(prom1)",
+				checks.AlertsDependencyCheckName + "(prom1)",
 				checks.SeriesCheckName + "(prom2)",
 				checks.VectorMatchingCheckName + "(prom2)",
 				checks.RangeQueryCheckName + "(prom2)",
 				checks.RuleDuplicateCheckName + "(prom2)",
+				checks.GroupIntervalCheckName + "(prom2)",
+				checks.AlertsDependencyCheckName + "(prom2)",
 				checks.CostCheckName + "(prom1)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -554,10 +665,12 @@ rule {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.LabelCheckName + "(team:true)",
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName, checks.LabelCheckName + "(team:true)",
 				checks.AnnotationCheckName + "(summary:true)",
 				checks.LabelCheckName + "(team:false)",
 				checks.AnnotationCheckName + "(summary=~^foo.+$:true)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -601,6 +714,7 @@ rule {
 # pint disable promql/range_query
 # pint disable rule/duplicate
 # pint disable labels/conflict
+# pint disable group/interval
 - record: foo
   # pint disable promql/fragile
   # pint disable promql/regexp
@@ -611,12 +725,16 @@ rule {
 				checks.AlertForCheckName,
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
+				checks.RuleCycleCheckName,
+				checks.AlertsDependencyCheckName + "(prom1)",
+				checks.AlertsDependencyCheckName + "(prom2)",
 				checks.CostCheckName + "(prom1)",
 				checks.CostCheckName + "(prom2)",
 				checks.CostCheckName + "(prom1:10000)",
 				checks.CostCheckName + "(prom2:10000)",
 				checks.CostCheckName + "(prom1:20000)",
 				checks.CostCheckName + "(prom2:20000)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -645,10 +763,12 @@ rule {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.RejectCheckName + "(key=~'^http://.+$')",
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName, checks.RejectCheckName + "(key=~'^http://.+$')",
 				checks.RejectCheckName + "(val=~'^http://.+$')",
 				checks.RejectCheckName + "(key=~'^.* +.*$')",
 				checks.RejectCheckName + "(val=~'^$')",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -677,6 +797,8 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -705,6 +827,8 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -733,6 +857,8 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -760,7 +886,9 @@ rule {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.LabelCheckName + "(priority:true)",
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName, checks.LabelCheckName + "(priority:true)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -789,6 +917,8 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -817,6 +947,8 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -844,7 +976,9 @@ rule {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.LabelCheckName + "(priority:true)",
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName, checks.LabelCheckName + "(priority:true)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -865,6 +999,7 @@ checks {
 	"promql/range_query",
 	"rule/duplicate",
 	"labels/conflict",
+	"group/interval",
   ]
 }
 prometheus "prom1" {
@@ -886,7 +1021,10 @@ prometheus "prom1" {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.AlertsDependencyCheckName + "(prom1)",
 				checks.AlertsCheckName + "(prom1)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -919,14 +1057,18 @@ prometheus "prom1" {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.RateCheckName + "(prom1)",
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName, checks.RateCheckName + "(prom1)",
 				checks.CounterCheckName + "(prom1)",
 				checks.SeriesCheckName + "(prom1)",
 				checks.VectorMatchingCheckName + "(prom1)",
 				checks.RangeQueryCheckName + "(prom1)",
 				checks.RuleDuplicateCheckName + "(prom1)",
 				checks.LabelsConflictCheckName + "(prom1)",
+				checks.GroupIntervalCheckName + "(prom1)",
+				checks.AlertsDependencyCheckName + "(prom1)",
 				checks.AlertsCheckName + "(prom1)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -1051,7 +1193,9 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
 				checks.AnnotationCheckName + "(summary:true)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -1075,6 +1219,8 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -1098,6 +1244,8 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -1121,7 +1269,9 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
 				checks.AnnotationCheckName + "(summary:true)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -1145,6 +1295,8 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -1168,7 +1320,9 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
 				checks.AnnotationCheckName + "(summary:true)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -1194,7 +1348,9 @@ rule {
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
 				checks.RuleLinkCheckName + "(^https?://(.+)$)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -1227,8 +1383,12 @@ checks {
 				checks.ComparisonCheckName,
 				checks.FragileCheckName,
 				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.AlertsDependencyCheckName + "(prom1)",
+				checks.AlertsDependencyCheckName + "(prom2)",
+				checks.CommentsCheckName,
 			},
-			disabledChecks: []string{"promql/rate", "promql/counter", "promql/vector_matching", "rule/duplicate", "labels/conflict"},
+			disabledChecks: []string{"promql/rate", "promql/counter", "promql/vector_matching", "rule/duplicate", "labels/conflict", "group/interval"},
 		},
 		{
 			title: "two prometheus servers / snoozed checks via comment",
@@ -1263,9 +1423,58 @@ checks {
 				checks.AlertForCheckName,
 				checks.ComparisonCheckName,
 				checks.FragileCheckName,
+				checks.RuleCycleCheckName,
 				checks.LabelsConflictCheckName + "(prom1)",
+				checks.GroupIntervalCheckName + "(prom1)",
+				checks.AlertsDependencyCheckName + "(prom1)",
 				checks.SeriesCheckName + "(prom2)",
 				checks.LabelsConflictCheckName + "(prom2)",
+				checks.GroupIntervalCheckName + "(prom2)",
+				checks.AlertsDependencyCheckName + "(prom2)",
+				checks.CommentsCheckName,
+			},
+			disabledChecks: []string{"promql/rate", "promql/counter"},
+		},
+		{
+			title: "two prometheus servers / snoozed checks via rule/snooze comment",
+			config: `
+prometheus "prom1" {
+  uri     = "http://localhost/1"
+  timeout = "1s"
+}
+prometheus "prom2" {
+  uri     = "http://localhost/2"
+  timeout = "1s"
+}
+checks {
+  disabled = [ "alerts/template", "promql/regexp" ]
+}
+`,
+			path: "rules.yml",
+			rule: newRule(t, `
+# pint rule/snooze 2099-11-28 promql/series(prom1)
+# pint rule/snooze 2099-11-28T10:24:18Z promql/range_query
+# pint rule/snooze 2000-11-28 rule/duplicate
+# pint snooze 2099-11-28T00:00:00+00:00 promql/vector_matching
+- record: foo
+  expr: sum(foo)
+`),
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.FragileCheckName,
+				checks.RuleCycleCheckName,
+				checks.RuleDuplicateCheckName + "(prom1)",
+				checks.LabelsConflictCheckName + "(prom1)",
+				checks.GroupIntervalCheckName + "(prom1)",
+				checks.AlertsDependencyCheckName + "(prom1)",
+				checks.SeriesCheckName + "(prom2)",
+				checks.RuleDuplicateCheckName + "(prom2)",
+				checks.LabelsConflictCheckName + "(prom2)",
+				checks.GroupIntervalCheckName + "(prom2)",
+				checks.AlertsDependencyCheckName + "(prom2)",
+				checks.CommentsCheckName,
 			},
 			disabledChecks: []string{"promql/rate", "promql/counter"},
 		},
@@ -1299,16 +1508,22 @@ checks {
 				checks.AlertForCheckName,
 				checks.ComparisonCheckName,
 				checks.FragileCheckName,
+				checks.RuleCycleCheckName,
 				checks.SeriesCheckName + "(prom1)",
 				checks.VectorMatchingCheckName + "(prom1)",
 				checks.RangeQueryCheckName + "(prom1)",
 				checks.RuleDuplicateCheckName + "(prom1)",
 				checks.LabelsConflictCheckName + "(prom1)",
+				checks.GroupIntervalCheckName + "(prom1)",
+				checks.AlertsDependencyCheckName + "(prom1)",
 				checks.SeriesCheckName + "(prom2)",
 				checks.VectorMatchingCheckName + "(prom2)",
 				checks.RangeQueryCheckName + "(prom2)",
 				checks.RuleDuplicateCheckName + "(prom2)",
 				checks.LabelsConflictCheckName + "(prom2)",
+				checks.GroupIntervalCheckName + "(prom2)",
+				checks.AlertsDependencyCheckName + "(prom2)",
+				checks.CommentsCheckName,
 			},
 			disabledChecks: []string{"promql/rate", "promql/counter"},
 		},
@@ -1332,6 +1547,7 @@ prometheus "prom3" {
 			rule: newRule(t, `
 # pint disable alerts/count(+disable)
 # pint disable labels/conflict(+disable)
+# pint disable group/interval(+disable)
 # pint disable promql/range_query(+disable)
 # pint disable promql/regexp(+disable)
 # pint disable promql/series(+disable)
@@ -1348,13 +1564,17 @@ prometheus "prom3" {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.RateCheckName + "(prom2)",
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName, checks.AlertsDependencyCheckName + "(prom1)",
+				checks.RateCheckName + "(prom2)",
 				checks.CounterCheckName + "(prom2)",
 				checks.SeriesCheckName + "(prom2)",
 				checks.VectorMatchingCheckName + "(prom2)",
 				checks.RangeQueryCheckName + "(prom2)",
 				checks.RuleDuplicateCheckName + "(prom2)",
 				checks.LabelsConflictCheckName + "(prom2)",
+				checks.GroupIntervalCheckName + "(prom2)",
+				checks.AlertsDependencyCheckName + "(prom2)",
 				checks.RateCheckName + "(prom3)",
 				checks.CounterCheckName + "(prom3)",
 				checks.SeriesCheckName + "(prom3)",
@@ -1362,6 +1582,9 @@ prometheus "prom3" {
 				checks.RangeQueryCheckName + "(prom3)",
 				checks.RuleDuplicateCheckName + "(prom3)",
 				checks.LabelsConflictCheckName + "(prom3)",
+				checks.GroupIntervalCheckName + "(prom3)",
+				checks.AlertsDependencyCheckName + "(prom3)",
+				checks.CommentsCheckName,
 			},
 		},
 		{
@@ -1384,6 +1607,7 @@ prometheus "prom3" {
 			rule: newRule(t, `
 # pint snooze 2099-11-28 alerts/count(+disable)
 # pint snooze 2099-11-28 labels/conflict(+disable)
+# pint snooze 2099-11-28 group/interval(+disable)
 # pint snooze 2099-11-28 promql/range_query(+disable)
 # pint snooze 2099-11-28 promql/regexp(+disable)
 # pint snooze 2099-11-28 promql/series(+disable)
@@ -1400,13 +1624,17 @@ prometheus "prom3" {
 				checks.ComparisonCheckName,
 				checks.TemplateCheckName,
 				checks.FragileCheckName,
-				checks.RegexpCheckName, checks.RateCheckName + "(prom2)",
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName, checks.AlertsDependencyCheckName + "(prom1)",
+				checks.RateCheckName + "(prom2)",
 				checks.CounterCheckName + "(prom2)",
 				checks.SeriesCheckName + "(prom2)",
 				checks.VectorMatchingCheckName + "(prom2)",
 				checks.RangeQueryCheckName + "(prom2)",
 				checks.RuleDuplicateCheckName + "(prom2)",
 				checks.LabelsConflictCheckName + "(prom2)",
+				checks.GroupIntervalCheckName + "(prom2)",
+				checks.AlertsDependencyCheckName + "(prom2)",
 				checks.RateCheckName + "(prom3)",
 				checks.CounterCheckName + "(prom3)",
 				checks.SeriesCheckName + "(prom3)",
@@ -1414,6 +1642,180 @@ prometheus "prom3" {
 				checks.RangeQueryCheckName + "(prom3)",
 				checks.RuleDuplicateCheckName + "(prom3)",
 				checks.LabelsConflictCheckName + "(prom3)",
+				checks.GroupIntervalCheckName + "(prom3)",
+				checks.AlertsDependencyCheckName + "(prom3)",
+				checks.CommentsCheckName,
+			},
+		},
+		{
+			title: "rule with namespace match / mismatch",
+			config: `
+rule {
+  match {
+    namespace = "prod-.*"
+  }
+  label "priority" {
+    severity = "bug"
+    value    = "(1|2|3|4|5)"
+    required = true
+  }
+}
+`,
+			path: "rules.yml",
+			rule: newRule(t, prometheusRule),
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
+			},
+		},
+		{
+			title: "rule with namespace match / match",
+			config: `
+rule {
+  match {
+    namespace = "mon.*"
+  }
+  label "priority" {
+    severity = "bug"
+    value    = "(1|2|3|4|5)"
+    required = true
+  }
+}
+`,
+			path: "rules.yml",
+			rule: newRule(t, prometheusRule),
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.LabelCheckName + "(priority:true)",
+				checks.CommentsCheckName,
+			},
+		},
+		{
+			title: "rule with namespace match / not a PrometheusRule",
+			config: `
+rule {
+  match {
+    namespace = ".*"
+  }
+  label "priority" {
+    severity = "bug"
+    value    = "(1|2|3|4|5)"
+    required = true
+  }
+}
+`,
+			path: "rules.yml",
+			rule: newRule(t, "- record: foo\n  expr: sum(foo)\n"),
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
+			},
+		},
+		{
+			title: "rule with resourceLabel match / match",
+			config: `
+rule {
+  match {
+    resourceLabel "prometheus" {
+      value = "k8s"
+    }
+  }
+  label "priority" {
+    severity = "bug"
+    value    = "(1|2|3|4|5)"
+    required = true
+  }
+}
+`,
+			path: "rules.yml",
+			rule: newRule(t, prometheusRule),
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.LabelCheckName + "(priority:true)",
+				checks.CommentsCheckName,
+			},
+		},
+		{
+			title: "rule with resourceLabel match / mismatch",
+			config: `
+rule {
+  match {
+    resourceLabel "prometheus" {
+      value = "other"
+    }
+  }
+  label "priority" {
+    severity = "bug"
+    value    = "(1|2|3|4|5)"
+    required = true
+  }
+}
+`,
+			path: "rules.yml",
+			rule: newRule(t, prometheusRule),
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.CommentsCheckName,
+			},
+		},
+		{
+			title: "prometheus resourceLabels",
+			config: `
+prometheus "prom1" {
+  uri            = "http://localhost/1"
+  resourceLabels = { prometheus = "k8s" }
+}
+prometheus "prom2" {
+  uri            = "http://localhost/2"
+  resourceLabels = { prometheus = "other" }
+}
+checks {
+  disabled = ["promql/rate", "promql/counter", "promql/series", "promql/vector_matching", "promql/range_query", "rule/duplicate", "labels/conflict"]
+}
+`,
+			path: "rules.yml",
+			rule: newRule(t, prometheusRule),
+			checks: []string{
+				checks.SyntaxCheckName,
+				checks.AlertForCheckName,
+				checks.ComparisonCheckName,
+				checks.TemplateCheckName,
+				checks.FragileCheckName,
+				checks.RegexpCheckName,
+				checks.RuleCycleCheckName,
+				checks.GroupIntervalCheckName + "(prom1)",
+				checks.AlertsDependencyCheckName + "(prom1)",
+				checks.CommentsCheckName,
 			},
 		},
 	}
@@ -1694,6 +2096,31 @@ func TestConfigErrors(t *testing.T) {
 }`,
 			err: "error parsing regexp: invalid nested repetition operator: `++`",
 		},
+		{
+			config: `alertmanager {
+  path = ""
+}`,
+			err: "alertmanager config path cannot be empty",
+		},
+		{
+			config: `cache {
+  dir = ""
+}`,
+			err: "cache dir cannot be empty",
+		},
+		{
+			config: `cache {
+  dir    = ".cache"
+  maxAge = "1x"
+}`,
+			err: `unknown unit "x" in duration "1x"`,
+		},
+		{
+			config: `alertmanager {
+  path = "this/file/doesnt/exist.yml"
+}`,
+			err: "open this/file/doesnt/exist.yml: no such file or directory",
+		},
 	}
 
 	dir := t.TempDir()
@@ -1729,3 +2156,50 @@ prometheus "prom" {
 	_, err = config.Load(path, true)
 	require.EqualError(t, err, `prometheus server name must be unique, found two or more config blocks using "prom" name`)
 }
+
+func TestAlertmanagerConfig(t *testing.T) {
+	dir := t.TempDir()
+	amPath := path.Join(dir, "alertmanager.yml")
+	err := os.WriteFile(amPath, []byte(`
+route:
+  receiver: default
+receivers:
+- name: default
+`), 0o644)
+	require.NoError(t, err)
+
+	cfgPath := path.Join(dir, "config.hcl")
+	err = os.WriteFile(cfgPath, []byte(fmt.Sprintf(`
+alertmanager {
+  path = %q
+}
+`, amPath)), 0o644)
+	require.NoError(t, err)
+
+	cfg, err := config.Load(cfgPath, true)
+	require.NoError(t, err)
+	require.NotNil(t, cfg.AlertmanagerConfig)
+	require.Equal(t, "default", cfg.AlertmanagerConfig.Route.Receiver)
+
+	ctx := context.WithValue(context.Background(), config.CommandKey, config.LintCommand)
+	checkNames := []string{}
+	for _, c := range cfg.GetChecksForRule(ctx, "rules.yml", newRule(t, "- alert: foo\n  expr: sum(foo) > 0\n"), nil) {
+		checkNames = append(checkNames, c.String())
+	}
+	require.Equal(t, []string{
+		checks.SyntaxCheckName,
+		checks.AlertForCheckName,
+		checks.ComparisonCheckName,
+		checks.TemplateCheckName,
+		checks.FragileCheckName,
+		checks.RegexpCheckName,
+		checks.RuleCycleCheckName,
+		checks.RouteCheckName,
+		checks.CommentsCheckName,
+	}, checkNames)
+
+	err = os.WriteFile(amPath, []byte("route: {}\n"), 0o644)
+	require.NoError(t, err)
+	_, err = config.Load(cfgPath, true)
+	require.EqualError(t, err, fmt.Sprintf(`failed to parse Alertmanager config file %q: root route must specify a default receiver`, amPath))
+}
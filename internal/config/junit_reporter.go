@@ -0,0 +1,15 @@
+package config
+
+import "errors"
+
+type JUnitReporterSettings struct {
+	Path string `hcl:"path" json:"path"`
+}
+
+func (settings JUnitReporterSettings) validate() error {
+	if settings.Path == "" {
+		return errors.New("empty path")
+	}
+
+	return nil
+}
@@ -29,13 +29,15 @@ var (
 )
 
 type Match struct {
-	Path       string             `hcl:"path,optional" json:"path,omitempty"`
-	Name       string             `hcl:"name,optional" json:"name,omitempty"`
-	Kind       string             `hcl:"kind,optional" json:"kind,omitempty"`
-	For        string             `hcl:"for,optional" json:"for,omitempty"`
-	Label      *MatchLabel        `hcl:"label,block" json:"label,omitempty"`
-	Annotation *MatchAnnotation   `hcl:"annotation,block" json:"annotation,omitempty"`
-	Command    *ContextCommandVal `hcl:"command,optional" json:"command,omitempty"`
+	Path          string             `hcl:"path,optional" json:"path,omitempty"`
+	Name          string             `hcl:"name,optional" json:"name,omitempty"`
+	Kind          string             `hcl:"kind,optional" json:"kind,omitempty"`
+	For           string             `hcl:"for,optional" json:"for,omitempty"`
+	Namespace     string             `hcl:"namespace,optional" json:"namespace,omitempty"`
+	Label         *MatchLabel        `hcl:"label,block" json:"label,omitempty"`
+	Annotation    *MatchAnnotation   `hcl:"annotation,block" json:"annotation,omitempty"`
+	ResourceLabel *MatchLabel        `hcl:"resourceLabel,block" json:"resourceLabel,omitempty"`
+	Command       *ContextCommandVal `hcl:"command,optional" json:"command,omitempty"`
 }
 
 func (m Match) validate(allowEmpty bool) error {
@@ -47,6 +49,10 @@ func (m Match) validate(allowEmpty bool) error {
 		return err
 	}
 
+	if _, err := regexp.Compile(m.Namespace); err != nil {
+		return err
+	}
+
 	switch m.Kind {
 	case "":
 		// not set
@@ -68,13 +74,19 @@ func (m Match) validate(allowEmpty bool) error {
 		}
 	}
 
+	if m.ResourceLabel != nil {
+		if err := m.ResourceLabel.validate(); err != nil {
+			return err
+		}
+	}
+
 	if m.For != "" {
 		if _, err := parseDurationMatch(m.For); err != nil {
 			return err
 		}
 	}
 
-	if !allowEmpty && m.Path == "" && m.Name == "" && m.Kind == "" && m.Label == nil && m.Annotation == nil && m.Command == nil && m.For == "" {
+	if !allowEmpty && m.Path == "" && m.Name == "" && m.Kind == "" && m.Namespace == "" && m.Label == nil && m.Annotation == nil && m.ResourceLabel == nil && m.Command == nil && m.For == "" {
 		return fmt.Errorf("ignore block must have at least one condition")
 	}
 
@@ -120,6 +132,19 @@ func (m Match) IsMatch(ctx context.Context, path string, r parser.Rule) bool {
 		}
 	}
 
+	if m.Namespace != "" {
+		re := strictRegex(m.Namespace)
+		if r.PrometheusRule == nil || !re.MatchString(r.PrometheusRule.Namespace) {
+			return false
+		}
+	}
+
+	if m.ResourceLabel != nil {
+		if !m.ResourceLabel.isMatchingResource(r) {
+			return false
+		}
+	}
+
 	if m.Command != nil {
 		cmd := ctx.Value(CommandKey).(ContextCommandVal)
 		if cmd != *m.Command {
@@ -184,6 +209,21 @@ func (ml MatchLabel) isMatching(rule parser.Rule) bool {
 	return false
 }
 
+func (ml MatchLabel) isMatchingResource(rule parser.Rule) bool {
+	if rule.PrometheusRule == nil {
+		return false
+	}
+
+	keyRe := strictRegex(ml.Key)
+	valRe := strictRegex(ml.Value)
+	for k, v := range rule.PrometheusRule.Labels {
+		if keyRe.MatchString(k) && valRe.MatchString(v) {
+			return true
+		}
+	}
+	return false
+}
+
 type MatchAnnotation struct {
 	Key   string `hcl:",label" json:"key"`
 	Value string `hcl:"value" json:"value"`
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+type OrphanSettings struct {
+	Severity string `hcl:"severity,optional" json:"severity,omitempty"`
+}
+
+func (ors OrphanSettings) validate() error {
+	if ors.Severity != "" {
+		if _, err := checks.ParseSeverity(ors.Severity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ors OrphanSettings) getSeverity(fallback checks.Severity) checks.Severity {
+	if ors.Severity != "" {
+		sev, _ := checks.ParseSeverity(ors.Severity)
+		return sev
+	}
+	return fallback
+}
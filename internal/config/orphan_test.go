@@ -0,0 +1,50 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+func TestOrphanSettings(t *testing.T) {
+	type testCaseT struct {
+		conf     OrphanSettings
+		err      error
+		severity checks.Severity
+	}
+
+	testCases := []testCaseT{
+		{
+			conf:     OrphanSettings{},
+			severity: checks.Warning,
+		},
+		{
+			conf: OrphanSettings{
+				Severity: "info",
+			},
+			severity: checks.Information,
+		},
+		{
+			conf: OrphanSettings{
+				Severity: "foo",
+			},
+			err: errors.New("unknown severity: foo"),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			err := tc.conf.validate()
+			if err == nil || tc.err == nil {
+				require.Equal(t, err, tc.err)
+				require.Equal(t, tc.severity, tc.conf.getSeverity(checks.Warning))
+			} else {
+				require.EqualError(t, err, tc.err.Error())
+			}
+		})
+	}
+}
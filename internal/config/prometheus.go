@@ -9,6 +9,8 @@ import (
 	"os"
 	"regexp"
 	"strings"
+
+	pintParser "github.com/cloudflare/pint/internal/parser"
 )
 
 type TLSConfig struct {
@@ -20,23 +22,35 @@ type TLSConfig struct {
 }
 
 type PrometheusConfig struct {
-	Name        string            `hcl:",label" json:"name"`
-	URI         string            `hcl:"uri" json:"uri"`
-	Headers     map[string]string `hcl:"headers,optional" json:"headers,omitempty"`
-	Failover    []string          `hcl:"failover,optional" json:"failover,omitempty"`
-	Timeout     string            `hcl:"timeout,optional"  json:"timeout"`
-	Concurrency int               `hcl:"concurrency,optional" json:"concurrency"`
-	RateLimit   int               `hcl:"rateLimit,optional" json:"rateLimit"`
-	Uptime      string            `hcl:"uptime,optional" json:"uptime"`
-	Include     []string          `hcl:"include,optional" json:"include,omitempty"`
-	Exclude     []string          `hcl:"exclude,optional" json:"exclude,omitempty"`
-	Tags        []string          `hcl:"tags,optional" json:"tags,omitempty"`
-	Required    bool              `hcl:"required,optional" json:"required"`
-	TLS         *TLSConfig        `hcl:"tls,block" json:"tls,omitempty"`
+	Name           string            `hcl:",label" json:"name"`
+	URI            string            `hcl:"uri,optional" json:"uri,omitempty"`
+	Path           string            `hcl:"path,optional" json:"path,omitempty"`
+	Headers        map[string]string `hcl:"headers,optional" json:"headers,omitempty"`
+	Failover       []string          `hcl:"failover,optional" json:"failover,omitempty"`
+	Timeout        string            `hcl:"timeout,optional"  json:"timeout"`
+	Concurrency    int               `hcl:"concurrency,optional" json:"concurrency"`
+	RateLimit      int               `hcl:"rateLimit,optional" json:"rateLimit"`
+	Uptime         string            `hcl:"uptime,optional" json:"uptime"`
+	Include        []string          `hcl:"include,optional" json:"include,omitempty"`
+	Exclude        []string          `hcl:"exclude,optional" json:"exclude,omitempty"`
+	Tags           []string          `hcl:"tags,optional" json:"tags,omitempty"`
+	ResourceLabels map[string]string `hcl:"resourceLabels,optional" json:"resourceLabels,omitempty"`
+	Required       bool              `hcl:"required,optional" json:"required"`
+	TLS            *TLSConfig        `hcl:"tls,block" json:"tls,omitempty"`
 }
 
 func (pc PrometheusConfig) validate() error {
-	if pc.URI == "" {
+	if pc.Path != "" {
+		if pc.URI != "" {
+			return errors.New("prometheus URI and path cannot be set at the same time")
+		}
+		if len(pc.Failover) > 0 {
+			return errors.New("prometheus failover URIs cannot be used with fixture path")
+		}
+		if _, err := os.Stat(pc.Path); err != nil {
+			return fmt.Errorf("invalid prometheus fixture path: %w", err)
+		}
+	} else if pc.URI == "" {
 		return errors.New("prometheus URI cannot be empty")
 	}
 
@@ -100,6 +114,18 @@ func (pc PrometheusConfig) isEnabledForPath(path string) bool {
 	return false
 }
 
+func (pc PrometheusConfig) isEnabledForRule(rule pintParser.Rule) bool {
+	if len(pc.ResourceLabels) == 0 || rule.PrometheusRule == nil {
+		return true
+	}
+	for k, v := range pc.ResourceLabels {
+		if rule.PrometheusRule.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
 func (pc PrometheusConfig) getTLSConfig() (*tls.Config, error) {
 	if pc.TLS == nil {
 		return nil, nil
@@ -39,6 +39,21 @@ func TestPrometheusConfig(t *testing.T) {
 			conf: PrometheusConfig{},
 			err:  errors.New("prometheus URI cannot be empty"),
 		},
+		{
+			conf: PrometheusConfig{Path: "prometheus_test.go"},
+		},
+		{
+			conf: PrometheusConfig{URI: "http://localhost", Path: "prometheus_test.go"},
+			err:  errors.New("prometheus URI and path cannot be set at the same time"),
+		},
+		{
+			conf: PrometheusConfig{Path: "prometheus_test.go", Failover: []string{"http://localhost"}},
+			err:  errors.New("prometheus failover URIs cannot be used with fixture path"),
+		},
+		{
+			conf: PrometheusConfig{Path: "/404/xxx"},
+			err:  errors.New("invalid prometheus fixture path: stat /404/xxx: no such file or directory"),
+		},
 		{
 			conf: PrometheusConfig{
 				URI:     "http://localhost",
@@ -1,5 +1,46 @@
 package config
 
+import "fmt"
+
 type Reporters struct {
-	JSON *JSONReporterSettings `hcl:"json,block" json:"json,omitempty"`
+	JSON        *JSONReporterSettings        `hcl:"json,block" json:"json,omitempty"`
+	SARIF       *SARIFReporterSettings       `hcl:"sarif,block" json:"sarif,omitempty"`
+	JUnit       *JUnitReporterSettings       `hcl:"junit,block" json:"junit,omitempty"`
+	CodeQuality *CodeQualityReporterSettings `hcl:"codeQuality,block" json:"codeQuality,omitempty"`
+	Checkstyle  *CheckstyleReporterSettings  `hcl:"checkstyle,block" json:"checkstyle,omitempty"`
+	Template    *TemplateReporterSettings    `hcl:"template,block" json:"template,omitempty"`
+}
+
+func (r Reporters) validate() error {
+	if r.JSON != nil {
+		if err := r.JSON.validate(); err != nil {
+			return fmt.Errorf("invalid json reporter config: %w", err)
+		}
+	}
+	if r.SARIF != nil {
+		if err := r.SARIF.validate(); err != nil {
+			return fmt.Errorf("invalid sarif reporter config: %w", err)
+		}
+	}
+	if r.JUnit != nil {
+		if err := r.JUnit.validate(); err != nil {
+			return fmt.Errorf("invalid junit reporter config: %w", err)
+		}
+	}
+	if r.CodeQuality != nil {
+		if err := r.CodeQuality.validate(); err != nil {
+			return fmt.Errorf("invalid codeQuality reporter config: %w", err)
+		}
+	}
+	if r.Checkstyle != nil {
+		if err := r.Checkstyle.validate(); err != nil {
+			return fmt.Errorf("invalid checkstyle reporter config: %w", err)
+		}
+	}
+	if r.Template != nil {
+		if err := r.Template.validate(); err != nil {
+			return fmt.Errorf("invalid template reporter config: %w", err)
+		}
+	}
+	return nil
 }
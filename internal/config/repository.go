@@ -77,7 +77,32 @@ func (gh GitHub) validate() error {
 	return nil
 }
 
+type GitLab struct {
+	URI     string `hcl:"uri,optional"`
+	Timeout string `hcl:"timeout,optional"`
+	Project string `hcl:"project,optional"`
+}
+
+func (gl GitLab) validate() error {
+	if gl.URI == "" && os.Getenv("CI_SERVER_URL") == "" {
+		return fmt.Errorf("uri cannot be empty")
+	}
+	if gl.URI != "" {
+		if _, err := url.Parse(gl.URI); err != nil {
+			return fmt.Errorf("invalid uri: %w", err)
+		}
+	}
+	if gl.Project == "" && os.Getenv("CI_PROJECT_ID") == "" {
+		return fmt.Errorf("project cannot be empty")
+	}
+	if _, err := parseDuration(gl.Timeout); err != nil {
+		return err
+	}
+	return nil
+}
+
 type Repository struct {
 	BitBucket *BitBucket `hcl:"bitbucket,block" json:"bitbucket,omitempty"`
 	GitHub    *GitHub    `hcl:"github,block" json:"github,omitempty"`
+	GitLab    *GitLab    `hcl:"gitlab,block" json:"gitlab,omitempty"`
 }
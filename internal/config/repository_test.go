@@ -181,3 +181,86 @@ func TestGitHubSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestGitLabSettings(t *testing.T) {
+	type testCaseT struct {
+		conf GitLab
+		env  map[string]string
+		err  error
+	}
+
+	noEnv := map[string]string{"CI_SERVER_URL": "", "CI_PROJECT_ID": ""}
+
+	testCases := []testCaseT{
+		{
+			conf: GitLab{
+				URI:     "https://gitlab.example.com",
+				Project: "123",
+				Timeout: "5m",
+			},
+			env: noEnv,
+		},
+		{
+			conf: GitLab{
+				URI:     "https://gitlab.example.com",
+				Project: "123",
+			},
+			env: noEnv,
+			err: errors.New(`empty duration string`),
+		},
+		{
+			conf: GitLab{
+				URI:     "https://gitlab.example.com",
+				Project: "123",
+				Timeout: "foo",
+			},
+			env: noEnv,
+			err: errors.New(`not a valid duration string: "foo"`),
+		},
+		{
+			conf: GitLab{
+				Project: "123",
+				Timeout: "5m",
+			},
+			env: noEnv,
+			err: errors.New("uri cannot be empty"),
+		},
+		{
+			conf: GitLab{
+				URI:     "http://%41:8080/",
+				Project: "123",
+				Timeout: "5m",
+			},
+			env: noEnv,
+			err: errors.New(`invalid uri: parse "http://%41:8080/": invalid URL escape "%41"`),
+		},
+		{
+			conf: GitLab{
+				URI:     "https://gitlab.example.com",
+				Timeout: "5m",
+			},
+			env: noEnv,
+			err: errors.New("project cannot be empty"),
+		},
+		{
+			conf: GitLab{
+				Timeout: "5m",
+			},
+			env: map[string]string{"CI_SERVER_URL": "https://gitlab.example.com", "CI_PROJECT_ID": "123"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%v", tc.conf), func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			err := tc.conf.validate()
+			if err == nil || tc.err == nil {
+				require.Equal(t, tc.err, err)
+			} else {
+				require.EqualError(t, err, tc.err.Error())
+			}
+		})
+	}
+}
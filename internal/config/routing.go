@@ -0,0 +1,43 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+type RoutingSettings struct {
+	Labels   []string `hcl:"labels" json:"labels"`
+	Severity string   `hcl:"severity,optional" json:"severity,omitempty"`
+}
+
+func (rs RoutingSettings) validate() error {
+	if len(rs.Labels) == 0 {
+		return errors.New("routing labels list cannot be empty")
+	}
+
+	for _, name := range rs.Labels {
+		if !model.LabelName(name).IsValid() {
+			return fmt.Errorf("%q is not a valid label name", name)
+		}
+	}
+
+	if rs.Severity != "" {
+		if _, err := checks.ParseSeverity(rs.Severity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (rs RoutingSettings) getSeverity(fallback checks.Severity) checks.Severity {
+	if rs.Severity != "" {
+		sev, _ := checks.ParseSeverity(rs.Severity)
+		return sev
+	}
+	return fallback
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+func TestRoutingSettings(t *testing.T) {
+	type testCaseT struct {
+		conf     RoutingSettings
+		err      error
+		severity checks.Severity
+	}
+
+	testCases := []testCaseT{
+		{
+			conf: RoutingSettings{},
+			err:  errors.New("routing labels list cannot be empty"),
+		},
+		{
+			conf: RoutingSettings{
+				Labels: []string{"team", "severity"},
+			},
+			severity: checks.Bug,
+		},
+		{
+			conf: RoutingSettings{
+				Labels: []string{"team", "bad-label"},
+			},
+			err: errors.New(`"bad-label" is not a valid label name`),
+		},
+		{
+			conf: RoutingSettings{
+				Labels:   []string{"team"},
+				Severity: "warning",
+			},
+			severity: checks.Warning,
+		},
+		{
+			conf: RoutingSettings{
+				Labels:   []string{"team"},
+				Severity: "foo",
+			},
+			err: errors.New("unknown severity: foo"),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			err := tc.conf.validate()
+			if err == nil || tc.err == nil {
+				require.Equal(t, err, tc.err)
+				require.Equal(t, tc.severity, tc.conf.getSeverity(checks.Bug))
+			} else {
+				require.EqualError(t, err, tc.err.Error())
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
 	"github.com/cloudflare/pint/internal/parser"
 	"github.com/cloudflare/pint/internal/promapi"
 )
@@ -26,6 +27,8 @@ type Rule struct {
 	Reject     []RejectSettings     `hcl:"reject,block" json:"reject,omitempty"`
 	RuleLink   []RuleLinkSettings   `hcl:"link,block" json:"link,omitempty"`
 	Offset     []OffsetSettings     `hcl:"offset,block" json:"offset,omitempty"`
+	Orphan     *OrphanSettings      `hcl:"orphan,block" json:"orphan,omitempty"`
+	Routing    *RoutingSettings     `hcl:"routing,block" json:"routing,omitempty"`
 }
 
 func (rule Rule) validate() (err error) {
@@ -95,6 +98,18 @@ func (rule Rule) validate() (err error) {
 		}
 	}
 
+	if rule.Orphan != nil {
+		if err = rule.Orphan.validate(); err != nil {
+			return err
+		}
+	}
+
+	if rule.Routing != nil {
+		if err = rule.Routing.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -278,6 +293,20 @@ func (rule Rule) resolveChecks(ctx context.Context, path string, r parser.Rule,
 		}
 	}
 
+	if rule.Orphan != nil {
+		enabled = append(enabled, checkMeta{
+			name:  checks.RuleOrphanCheckName,
+			check: checks.NewRuleOrphanCheck(rule.Orphan.getSeverity(checks.Warning)),
+		})
+	}
+
+	if rule.Routing != nil {
+		enabled = append(enabled, checkMeta{
+			name:  checks.RoutingLabelsCheckName,
+			check: checks.NewRoutingLabelsCheck(rule.Routing.Labels, rule.Routing.getSeverity(checks.Bug)),
+		})
+	}
+
 	return enabled
 }
 
@@ -306,21 +335,23 @@ func isEnabled(enabledChecks, disabledChecks []string, rule parser.Rule, name st
 	for _, tag := range promTags {
 		disabled = append(disabled, fmt.Sprintf("%s(+%s)", name, tag))
 	}
-	for _, comment := range rule.GetComments("snooze") {
-		s := parser.ParseSnooze(comment.Value)
-		if s == nil {
-			continue
-		}
-		if !slices.Contains(disabled, s.Text) {
-			continue
-		}
-		log.Debug().
-			Str("check", instance).
-			Str("comment", comment.String()).
-			Time("until", s.Until).
-			Str("snooze", s.Text).
-			Msg("Check snoozed by comment")
-		return false
+	for _, key := range []string{"snooze", discovery.RuleSnoozeCheckComment} {
+		for _, comment := range rule.GetComments(key) {
+			s := parser.ParseSnooze(comment.Value)
+			if s == nil {
+				continue
+			}
+			if !slices.Contains(disabled, s.Text) {
+				continue
+			}
+			log.Debug().
+				Str("check", instance).
+				Str("comment", comment.String()).
+				Time("until", s.Until).
+				Str("snooze", s.Text).
+				Msg("Check snoozed by comment")
+			return false
+		}
 	}
 
 	for _, c := range disabledChecks {
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+type TemplateReporterSettings struct {
+	Path     string `hcl:"path" json:"path"`
+	Template string `hcl:"template" json:"template"`
+}
+
+func (settings TemplateReporterSettings) validate() error {
+	if settings.Path == "" {
+		return errors.New("empty path")
+	}
+	if settings.Template == "" {
+		return errors.New("empty template")
+	}
+	if _, err := reporter.ParseTemplate("template", settings.Template); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateReporterSettings(t *testing.T) {
+	type testCaseT struct {
+		conf TemplateReporterSettings
+		err  error
+	}
+
+	testCases := []testCaseT{
+		{
+			conf: TemplateReporterSettings{Path: "out.txt", Template: "{{ .Path }}:{{ .FirstLine }} {{ .Text }}"},
+		},
+		{
+			conf: TemplateReporterSettings{Template: "{{ .Path }}"},
+			err:  errors.New("empty path"),
+		},
+		{
+			conf: TemplateReporterSettings{Path: "out.txt"},
+			err:  errors.New("empty template"),
+		},
+		{
+			conf: TemplateReporterSettings{Path: "out.txt", Template: "{{ .Path "},
+			err:  errors.New(`invalid template: template: template:1: unclosed action`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%v", tc.conf), func(t *testing.T) {
+			err := tc.conf.validate()
+			if err == nil || tc.err == nil {
+				require.Equal(t, err, tc.err)
+			} else {
+				require.EqualError(t, err, tc.err.Error())
+			}
+		})
+	}
+}
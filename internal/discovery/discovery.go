@@ -21,6 +21,7 @@ const (
 	FileDisabledCheckComment = "file/disable"
 	FileSnoozeCheckComment   = "file/snooze"
 	RuleOwnerComment         = "rule/owner"
+	RuleSnoozeCheckComment   = "rule/snooze"
 )
 
 var ignoredErrors = []string{
@@ -87,6 +88,25 @@ type Entry struct {
 	Rule           parser.Rule
 	Owner          string
 	DisabledChecks []string
+	Comments       []parser.ControlComment `json:",omitempty"`
+}
+
+// ReadRules parses all rules from r and returns them as unmodified entries.
+// It allows to check content that isn't saved on disk, like files open
+// in an editor.
+func ReadRules(reportedPath, sourcePath string, r io.Reader, isStrict bool) (entries []Entry, err error) {
+	el, err := readRules(reportedPath, sourcePath, r, isStrict)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range el {
+		e.State = Noop
+		if len(e.ModifiedLines) == 0 {
+			e.ModifiedLines = e.Rule.Lines()
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
 }
 
 func readRules(reportedPath, sourcePath string, r io.Reader, isStrict bool) (entries []Entry, err error) {
@@ -138,7 +158,9 @@ func readRules(reportedPath, sourcePath string, r io.Reader, isStrict bool) (ent
 		return entries, nil
 	}
 
-	if isStrict {
+	// PrometheusRule resources are Kubernetes manifests, rulefmt can only validate
+	// plain rule files.
+	if isStrict && !parser.IsPrometheusRule(content.Body) {
 		if _, errs := rulefmt.Parse(content.Body); len(errs) > 0 {
 			seen := map[string]struct{}{}
 			for _, err := range errs {
@@ -180,11 +202,15 @@ func readRules(reportedPath, sourcePath string, r io.Reader, isStrict bool) (ent
 		return entries, nil
 	}
 
+	comments := parser.FindControlComments(content.Body)
 	for _, rule := range rules {
 		owner, ok := rule.GetComment(RuleOwnerComment)
 		if !ok {
 			owner = fileOwner
 		}
+		if owner.Value == "" && rule.PrometheusRule != nil {
+			owner.Value = rule.PrometheusRule.Name
+		}
 		entries = append(entries, Entry{
 			ReportedPath:   reportedPath,
 			SourcePath:     sourcePath,
@@ -195,10 +221,55 @@ func readRules(reportedPath, sourcePath string, r io.Reader, isStrict bool) (ent
 		})
 	}
 
+	attachComments(entries, comments)
+
 	log.Debug().Str("path", sourcePath).Int("rules", len(entries)).Msg("File parsed")
 	return entries, nil
 }
 
+// attachComments adds every pint comment to the rule it belongs to.
+// A comment belongs to the rule it's attached to, otherwise to the first
+// rule that ends on or after the comment line, or to the last rule in
+// the file if there's no such rule.
+func attachComments(entries []Entry, comments []parser.ControlComment) {
+	for _, cmt := range comments {
+		owner, prev, last := -1, -1, -1
+		for i, entry := range entries {
+			end := entry.Rule.LastLine()
+			if end >= cmt.Line && (owner < 0 || end < entries[owner].Rule.LastLine()) {
+				owner = i
+			}
+			if end < cmt.Line && (prev < 0 || end > entries[prev].Rule.LastLine()) {
+				prev = i
+			}
+			if last < 0 || end > entries[last].Rule.LastLine() {
+				last = i
+			}
+		}
+		switch {
+		case owner >= 0 && isAttached(entries[owner].Rule, cmt):
+		case prev >= 0 && isAttached(entries[prev].Rule, cmt):
+			// Comments placed below a rule can be attached to it.
+			owner = prev
+		case owner < 0:
+			owner = last
+		}
+		if owner < 0 {
+			continue
+		}
+		entries[owner].Comments = append(entries[owner].Comments, cmt)
+	}
+}
+
+func isAttached(rule parser.Rule, cmt parser.ControlComment) bool {
+	for _, rc := range rule.GetComments(cmt.Key) {
+		if rc.Value == cmt.Value {
+			return true
+		}
+	}
+	return false
+}
+
 func matchesAny(re []*regexp.Regexp, s string) bool {
 	for _, r := range re {
 		if v := r.MatchString(s); v {
@@ -21,6 +21,21 @@ func (r failingReader) Read(_ []byte) (int, error) {
 	return 0, r.err
 }
 
+const prometheusRule = `apiVersion: monitoring.coreos.com/v1
+kind: PrometheusRule
+metadata:
+  name: example
+  namespace: monitoring
+  labels:
+    prometheus: k8s
+spec:
+  groups:
+  - name: foo
+    rules:
+    - record: foo
+      expr: bar
+`
+
 func TestReadRules(t *testing.T) {
 	mustParse := func(offset int, s string) parser.Rule {
 		p := parser.NewParser()
@@ -34,6 +49,17 @@ func TestReadRules(t *testing.T) {
 		return r[0]
 	}
 
+	withGroup := func(line int, name string, r parser.Rule) parser.Rule {
+		r.Group = &parser.Group{
+			Position: parser.NewFilePosition([]int{line}),
+			Name: &parser.YamlKeyValue{
+				Key:   &parser.YamlNode{Position: parser.NewFilePosition([]int{line}), Value: "name"},
+				Value: &parser.YamlNode{Position: parser.NewFilePosition([]int{line}), Value: name},
+			},
+		}
+		return r
+	}
+
 	type testCaseT struct {
 		title        string
 		reportedPath string
@@ -135,6 +161,7 @@ func TestReadRules(t *testing.T) {
 					ModifiedLines:  []int{4, 5},
 					Rule:           mustParse(3, "- record: foo\n  expr: bar\n"),
 					DisabledChecks: []string{"promql/series"},
+					Comments:       []parser.ControlComment{{Line: 2, Comment: parser.Comment{Key: "file/disable", Value: "promql/series"}}},
 				},
 			},
 		},
@@ -160,8 +187,9 @@ groups:
 					ReportedPath:   "rules.yml",
 					SourcePath:     "rules.yml",
 					ModifiedLines:  []int{7, 8},
-					Rule:           mustParse(6, "- record: foo\n  expr: bar\n"),
+					Rule:           withGroup(5, "foo", mustParse(6, "- record: foo\n  expr: bar\n")),
 					DisabledChecks: []string{"promql/series"},
+					Comments:       []parser.ControlComment{{Line: 2, Comment: parser.Comment{Key: "file/disable", Value: "promql/series"}}},
 				},
 			},
 		},
@@ -185,6 +213,7 @@ groups:
 					SourcePath:    "rules.yml",
 					ModifiedLines: []int{4, 5},
 					Rule:          mustParse(3, "- record: foo\n  expr: bar\n"),
+					Comments:      []parser.ControlComment{{Line: 2, Comment: parser.Comment{Key: "file/snooze", Value: "2000-01-01T00:00:00Z promql/series"}}},
 				},
 			},
 		},
@@ -210,7 +239,8 @@ groups:
 					ReportedPath:  "rules.yml",
 					SourcePath:    "rules.yml",
 					ModifiedLines: []int{7, 8},
-					Rule:          mustParse(6, "- record: foo\n  expr: bar\n"),
+					Rule:          withGroup(5, "foo", mustParse(6, "- record: foo\n  expr: bar\n")),
+					Comments:      []parser.ControlComment{{Line: 2, Comment: parser.Comment{Key: "file/snooze", Value: "2000-01-01T00:00:00Z promql/series"}}},
 				},
 			},
 		},
@@ -235,6 +265,7 @@ groups:
 					ModifiedLines:  []int{4, 5},
 					Rule:           mustParse(3, "- record: foo\n  expr: bar\n"),
 					DisabledChecks: []string{"promql/series"},
+					Comments:       []parser.ControlComment{{Line: 2, Comment: parser.Comment{Key: "file/snooze", Value: "2099-01-01T00:00:00Z promql/series"}}},
 				},
 			},
 		},
@@ -260,8 +291,9 @@ groups:
 					ReportedPath:   "rules.yml",
 					SourcePath:     "rules.yml",
 					ModifiedLines:  []int{7, 8},
-					Rule:           mustParse(6, "- record: foo\n  expr: bar\n"),
+					Rule:           withGroup(5, "foo", mustParse(6, "- record: foo\n  expr: bar\n")),
 					DisabledChecks: []string{"promql/series"},
+					Comments:       []parser.ControlComment{{Line: 2, Comment: parser.Comment{Key: "file/snooze", Value: "2099-01-01T00:00:00Z promql/series"}}},
 				},
 			},
 		},
@@ -314,6 +346,85 @@ groups:
 				},
 			},
 		},
+		{
+			title:        "PrometheusRule",
+			reportedPath: "rules.yml",
+			sourcePath:   "rules.yml",
+			sourceFunc: func(t *testing.T) io.Reader {
+				return bytes.NewBuffer([]byte(prometheusRule))
+			},
+			isStrict: true,
+			entries: []Entry{
+				{
+					State:         Unknown,
+					ReportedPath:  "rules.yml",
+					SourcePath:    "rules.yml",
+					ModifiedLines: []int{12, 13},
+					Rule:          mustParse(0, prometheusRule),
+					Owner:         "example",
+				},
+			},
+		},
+		{
+			title:        "PrometheusRule with file/owner",
+			reportedPath: "rules.yml",
+			sourcePath:   "rules.yml",
+			sourceFunc: func(t *testing.T) io.Reader {
+				return bytes.NewBuffer([]byte("# pint file/owner bob\n" + prometheusRule))
+			},
+			isStrict: true,
+			entries: []Entry{
+				{
+					State:         Unknown,
+					ReportedPath:  "rules.yml",
+					SourcePath:    "rules.yml",
+					ModifiedLines: []int{13, 14},
+					Rule:          mustParse(0, "# pint file/owner bob\n"+prometheusRule),
+					Owner:         "bob",
+					Comments:      []parser.ControlComment{{Line: 1, Comment: parser.Comment{Key: "file/owner", Value: "bob"}}},
+				},
+			},
+		},
+		{
+			title:        "comments belong to the rule below them",
+			reportedPath: "rules.yml",
+			sourcePath:   "rules.yml",
+			sourceFunc: func(t *testing.T) io.Reader {
+				return bytes.NewBuffer([]byte(`
+# pint disable promql/series
+- record: foo
+  expr: bar
+
+# pint rule/owner bob
+- record: bar
+  expr: foo
+# pint ignore/end
+`))
+			},
+			isStrict: false,
+			entries: []Entry{
+				{
+					State:         Unknown,
+					ReportedPath:  "rules.yml",
+					SourcePath:    "rules.yml",
+					ModifiedLines: []int{3, 4},
+					Rule:          mustParse(1, "# pint disable promql/series\n- record: foo\n  expr: bar\n"),
+					Comments:      []parser.ControlComment{{Line: 2, Comment: parser.Comment{Key: "disable", Value: "promql/series"}}},
+				},
+				{
+					State:         Unknown,
+					ReportedPath:  "rules.yml",
+					SourcePath:    "rules.yml",
+					ModifiedLines: []int{7, 8},
+					Rule:          mustParse(5, "# pint rule/owner bob\n- record: bar\n  expr: foo\n"),
+					Owner:         "bob",
+					Comments: []parser.ControlComment{
+						{Line: 6, Comment: parser.Comment{Key: "rule/owner", Value: "bob"}},
+						{Line: 9, Comment: parser.Comment{Key: "ignore/end"}, Unmatched: true},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -6,7 +6,10 @@ import (
 	"regexp"
 	"strings"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/cloudflare/pint/internal/git"
+	"github.com/cloudflare/pint/internal/parser"
 
 	"github.com/rs/zerolog/log"
 )
@@ -148,6 +151,7 @@ func (f GitBlameFinder) Find() (entries []Entry, err error) {
 			if len(e.ModifiedLines) == 0 && e.PathError != nil {
 				e.ModifiedLines = allowedLines
 			}
+			e.Comments = modifiedComments(e.Comments, allowedLines)
 			if isOverlap(allowedLines, e.Rule.Lines()) || isOverlap(allowedLines, e.ModifiedLines) {
 				entries = append(entries, e)
 			}
@@ -214,6 +218,15 @@ func getOverlap(a, b []int) (o []int) {
 	return o
 }
 
+func modifiedComments(comments []parser.ControlComment, lines []int) (modified []parser.ControlComment) {
+	for _, cmt := range comments {
+		if slices.Contains(lines, cmt.Line) {
+			modified = append(modified, cmt)
+		}
+	}
+	return modified
+}
+
 func isDirectoryPath(path string) (bool, error) {
 	fileInfo, err := os.Stat(path)
 	if err != nil {
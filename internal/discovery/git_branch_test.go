@@ -48,6 +48,17 @@ func TestGitBranchFinder(t *testing.T) {
 		return r[0]
 	}
 
+	withGroup := func(line int, name string, r parser.Rule) parser.Rule {
+		r.Group = &parser.Group{
+			Position: parser.NewFilePosition([]int{line}),
+			Name: &parser.YamlKeyValue{
+				Key:   &parser.YamlNode{Position: parser.NewFilePosition([]int{line}), Value: "name"},
+				Value: &parser.YamlNode{Position: parser.NewFilePosition([]int{line}), Value: name},
+			},
+		}
+		return r
+	}
+
 	mustErr := func(s string) error {
 		_, errs := rulefmt.Parse([]byte(s))
 		if len(errs) == 0 {
@@ -260,7 +271,7 @@ groups:
 					ReportedPath:  "rules.yml",
 					SourcePath:    "rules.yml",
 					ModifiedLines: []int{6},
-					Rule:          mustParse(4, "- record: up:count\n  expr: count(up == 1)\n"),
+					Rule:          withGroup(3, "v2", mustParse(4, "- record: up:count\n  expr: count(up == 1)\n")),
 				},
 			},
 		},
@@ -460,28 +471,28 @@ groups:
 					ReportedPath:  "rules.yml",
 					SourcePath:    "rules.yml",
 					ModifiedLines: []int{6},
-					Rule:          mustParse(4, "- record: up:count:1\n  expr: count(up == 1)\n"),
+					Rule:          withGroup(3, "v2", mustParse(4, "- record: up:count:1\n  expr: count(up == 1)\n")),
 				},
 				{
 					State:         discovery.Added,
 					ReportedPath:  "rules.yml",
 					SourcePath:    "rules.yml",
 					ModifiedLines: []int{7},
-					Rule:          mustParse(6, "- record: up:count:2a\n  expr: count(up)\n"),
+					Rule:          withGroup(3, "v2", mustParse(6, "- record: up:count:2a\n  expr: count(up)\n")),
 				},
 				{
 					State:         discovery.Added,
 					ReportedPath:  "rules.yml",
 					SourcePath:    "rules.yml",
 					ModifiedLines: []int{11, 12},
-					Rule:          mustParse(10, "- record: up:count:4\n  expr: count(up)\n"),
+					Rule:          withGroup(3, "v2", mustParse(10, "- record: up:count:4\n  expr: count(up)\n")),
 				},
 				{
 					State:         discovery.Removed,
 					ReportedPath:  "rules.yml",
 					SourcePath:    "rules.yml",
 					ModifiedLines: []int{7, 8},
-					Rule:          mustParse(6, "- record: up:count:2\n  expr: count(up)\n"),
+					Rule:          withGroup(3, "v1", mustParse(6, "- record: up:count:2\n  expr: count(up)\n")),
 				},
 			},
 		},
@@ -640,7 +651,7 @@ groups:
 					ReportedPath:  "rules.yml",
 					SourcePath:    "rules.yml",
 					ModifiedLines: nil,
-					Rule:          mustParse(4, "- record: up:count\n  expr: count(up)\n"),
+					Rule:          withGroup(3, "v2", mustParse(4, "- record: up:count\n  expr: count(up)\n")),
 				},
 				{
 					State:         discovery.Removed,
@@ -50,19 +50,13 @@ func (f GlobFinder) Find() (entries []Entry, err error) {
 		if err != nil {
 			return nil, err
 		}
-		el, err := readRules(fp.target, fp.path, fd, !matchesAny(f.relaxed, fp.target))
+		el, err := ReadRules(fp.target, fp.path, fd, !matchesAny(f.relaxed, fp.target))
 		if err != nil {
 			fd.Close()
 			return nil, fmt.Errorf("invalid file syntax: %w", err)
 		}
 		fd.Close()
-		for _, e := range el {
-			e.State = Noop
-			if len(e.ModifiedLines) == 0 {
-				e.ModifiedLines = e.Rule.Lines()
-			}
-			entries = append(entries, e)
-		}
+		entries = append(entries, el...)
 	}
 
 	return entries, nil
@@ -31,6 +31,9 @@ func TestGlobPathFinder(t *testing.T) {
 	testRuleBody := "# pint file/owner bob\n\n- record: foo\n  expr: sum(foo)\n"
 	testRules, err := p.Parse([]byte(testRuleBody))
 	require.NoError(t, err)
+	testRuleComments := []parser.ControlComment{
+		{Line: 1, Comment: parser.Comment{Key: "file/owner", Value: "bob"}},
+	}
 
 	parseErr := func(input string) error {
 		_, err := rulefmt.Parse([]byte(input))
@@ -77,6 +80,7 @@ func TestGlobPathFinder(t *testing.T) {
 					Rule:          testRules[0],
 					ModifiedLines: testRules[0].Lines(),
 					Owner:         "bob",
+					Comments:      testRuleComments,
 				},
 			},
 		},
@@ -91,6 +95,10 @@ func TestGlobPathFinder(t *testing.T) {
 					Rule:          testRules[0],
 					ModifiedLines: testRules[0].Lines(),
 					Owner:         "alice",
+					Comments: append(testRuleComments, parser.ControlComment{
+						Line:    7,
+						Comment: parser.Comment{Key: "file/owner", Value: "alice"},
+					}),
 				},
 			},
 		},
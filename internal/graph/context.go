@@ -0,0 +1,36 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cloudflare/pint/internal/discovery"
+)
+
+type contextKey struct{}
+
+type lazyGraph struct {
+	once    sync.Once
+	entries []discovery.Entry
+	graph   Graph
+}
+
+// NewContext returns a copy of ctx carrying a Graph for given entries.
+// The graph is built only once, when it's first requested by FromContext,
+// and then shared by everyone using the returned context.
+func NewContext(ctx context.Context, entries []discovery.Entry) context.Context {
+	return context.WithValue(ctx, contextKey{}, &lazyGraph{entries: entries})
+}
+
+// FromContext returns the Graph stored in ctx by NewContext.
+// If there's no graph in ctx then a new one is built from given entries.
+func FromContext(ctx context.Context, entries []discovery.Entry) Graph {
+	lg, ok := ctx.Value(contextKey{}).(*lazyGraph)
+	if !ok {
+		return New(entries)
+	}
+	lg.once.Do(func() {
+		lg.graph = New(lg.entries)
+	})
+	return lg.graph
+}
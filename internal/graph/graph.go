@@ -0,0 +1,211 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/prometheus/prometheus/model/labels"
+	promParser "github.com/prometheus/prometheus/promql/parser"
+	"golang.org/x/exp/slices"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+// Node is a single alerting or recording rule.
+type Node struct {
+	ID   int             `json:"id"`
+	Path string          `json:"path"`
+	Name string          `json:"name"`
+	Type parser.RuleType `json:"type"`
+	Line int             `json:"line"`
+	Rule parser.Rule     `json:"-"`
+}
+
+func (n Node) String() string {
+	return fmt.Sprintf("%s:%d", n.Path, n.Line)
+}
+
+// Edge connects a recording rule with a rule that uses its results.
+// Selector is the vector selector that references the recording rule.
+type Edge struct {
+	From     int    `json:"from"`
+	To       int    `json:"to"`
+	Selector string `json:"selector"`
+}
+
+// Graph holds dependencies between all rules.
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+
+	dependencies map[int][]int
+	consumers    map[int][]int
+	paths        map[string][]int
+}
+
+// New builds a dependency graph for all valid rules in entries.
+// A rule depends on a recording rule if any of the vector selectors in its
+// query would match the time series produced by that recording rule.
+func New(entries []discovery.Entry) (g Graph) {
+	g.Nodes = []Node{}
+	g.Edges = []Edge{}
+	g.dependencies = map[int][]int{}
+	g.consumers = map[int][]int{}
+	g.paths = map[string][]int{}
+
+	records := map[string][]int{}
+	for _, entry := range entries {
+		if entry.State == discovery.Removed || entry.PathError != nil || entry.Rule.Error.Err != nil {
+			continue
+		}
+		if entry.Rule.AlertingRule == nil && entry.Rule.RecordingRule == nil {
+			continue
+		}
+		node := Node{
+			ID:   len(g.Nodes),
+			Path: entry.ReportedPath,
+			Name: entry.Rule.Name(),
+			Type: entry.Rule.Type(),
+			Line: entry.Rule.Lines()[0],
+			Rule: entry.Rule,
+		}
+		if node.Type == parser.RecordingRuleType {
+			records[node.Name] = append(records[node.Name], node.ID)
+		}
+		g.paths[node.Path] = append(g.paths[node.Path], node.ID)
+		g.Nodes = append(g.Nodes, node)
+	}
+
+	for _, node := range g.Nodes {
+		expr := node.Rule.Expr()
+		if expr.SyntaxError != nil || expr.Query == nil {
+			continue
+		}
+		for _, vs := range utils.HasVectorSelector(expr.Query) {
+			var candidates []int
+			if vs.Name != "" {
+				candidates = records[vs.Name]
+			} else {
+				for _, ids := range records {
+					candidates = append(candidates, ids...)
+				}
+				slices.Sort(candidates)
+			}
+			for _, id := range candidates {
+				if !isMatchingRule(vs, g.Nodes[id].Rule.RecordingRule) || slices.Contains(g.dependencies[node.ID], id) {
+					continue
+				}
+				g.Edges = append(g.Edges, Edge{From: id, To: node.ID, Selector: vs.String()})
+				g.consumers[id] = append(g.consumers[id], node.ID)
+				g.dependencies[node.ID] = append(g.dependencies[node.ID], id)
+			}
+		}
+	}
+
+	return g
+}
+
+// isMatchingRule returns true if given selector can match time series
+// produced by a recording rule.
+// Only the metric name and static labels set on the rule are compared,
+// any other label could be present on the results of the rule query.
+func isMatchingRule(vs *promParser.VectorSelector, rule *parser.RecordingRule) bool {
+	for _, lm := range vs.LabelMatchers {
+		if lm.Name == labels.MetricName {
+			if !lm.Matches(rule.Record.Value.Value) {
+				return false
+			}
+			continue
+		}
+		if rule.Labels == nil {
+			continue
+		}
+		for _, l := range rule.Labels.Items {
+			if l.Key.Value == lm.Name && !lm.Matches(l.Value.Value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Find returns the node for a rule from given path.
+func (g Graph) Find(path string, rule parser.Rule) (Node, bool) {
+	for _, id := range g.paths[path] {
+		if g.Nodes[id].Rule.IsSame(rule) {
+			return g.Nodes[id], true
+		}
+	}
+	return Node{}, false
+}
+
+// Dependencies returns all recording rules used by given node.
+func (g Graph) Dependencies(id int) (nodes []Node) {
+	for _, dep := range g.dependencies[id] {
+		nodes = append(nodes, g.Nodes[dep])
+	}
+	return nodes
+}
+
+// Consumers returns all rules using results of given node.
+func (g Graph) Consumers(id int) (nodes []Node) {
+	for _, c := range g.consumers[id] {
+		nodes = append(nodes, g.Nodes[c])
+	}
+	return nodes
+}
+
+// FindCycle returns a list of nodes forming a dependency cycle that starts and
+// ends on given node, or nil if there's no such cycle.
+func (g Graph) FindCycle(id int) []Node {
+	visited := map[int]bool{}
+	var walk func(n int, path []Node) []Node
+	walk = func(n int, path []Node) []Node {
+		for _, dep := range g.Dependencies(n) {
+			if dep.ID == id {
+				return append(path, dep)
+			}
+			if visited[dep.ID] {
+				continue
+			}
+			visited[dep.ID] = true
+			if cycle := walk(dep.ID, append(path, dep)); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return walk(id, []Node{g.Nodes[id]})
+}
+
+// WriteJSON writes the graph as a JSON document.
+func (g Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g)
+}
+
+// WriteDOT writes the graph using the Graphviz DOT language.
+// Recording rules are drawn as boxes and alerting rules as ellipses, edges
+// point from a recording rule to all rules using it.
+func (g Graph) WriteDOT(w io.Writer) (err error) {
+	var b strings.Builder
+	b.WriteString("digraph pint {\n")
+	for _, node := range g.Nodes {
+		shape := "ellipse"
+		if node.Type == parser.RecordingRuleType {
+			shape = "box"
+		}
+		fmt.Fprintf(&b, "  n%d [label=%q, tooltip=%q, shape=%s];\n", node.ID, node.Name, node.String(), shape)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  n%d -> n%d [tooltip=%q];\n", e.From, e.To, e.Selector)
+	}
+	b.WriteString("}\n")
+	_, err = io.WriteString(w, b.String())
+	return err
+}
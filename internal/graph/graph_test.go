@@ -0,0 +1,136 @@
+package graph_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/graph"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+func mustParse(t *testing.T, path, content string) (entries []discovery.Entry) {
+	p := parser.NewParser()
+	rules, err := p.Parse([]byte(content))
+	require.NoError(t, err)
+	for _, rule := range rules {
+		entries = append(entries, discovery.Entry{
+			ReportedPath:  path,
+			SourcePath:    path,
+			ModifiedLines: rule.Lines(),
+			Rule:          rule,
+		})
+	}
+	return entries
+}
+
+func TestGraph(t *testing.T) {
+	entries := mustParse(t, "a.yml", `
+- record: job:up:sum
+  expr: sum(up) by(job)
+- record: job:up:sum
+  expr: sum(up) by(job)
+  labels:
+    cluster: dev
+- record: job:up:ratio
+  expr: job:up:sum{cluster="prod"} / job:up:max
+- record: broken
+  expr: sum(
+`)
+	entries = append(entries, mustParse(t, "b.yml", `
+- record: job:up:max
+  expr: max({__name__=~"job:up:(sum|ratio)"})
+- alert: Down
+  expr: job:up:ratio == 0
+`)...)
+	entries = append(entries, discovery.Entry{
+		ReportedPath: "c.yml",
+		PathError:    discovery.ErrFileIsIgnored,
+	})
+
+	g := graph.New(entries)
+	require.Len(t, g.Nodes, 6)
+	require.Equal(t, []graph.Edge{
+		{From: 0, To: 2, Selector: `job:up:sum{cluster="prod"}`},
+		{From: 4, To: 2, Selector: "job:up:max"},
+		{From: 0, To: 4, Selector: `{__name__=~"job:up:(sum|ratio)"}`},
+		{From: 1, To: 4, Selector: `{__name__=~"job:up:(sum|ratio)"}`},
+		{From: 2, To: 4, Selector: `{__name__=~"job:up:(sum|ratio)"}`},
+		{From: 2, To: 5, Selector: "job:up:ratio"},
+	}, g.Edges)
+
+	node, ok := g.Find("a.yml", entries[2].Rule)
+	require.True(t, ok)
+	require.Equal(t, "job:up:ratio", node.Name)
+	require.Equal(t, "a.yml:8", node.String())
+
+	_, ok = g.Find("b.yml", entries[2].Rule)
+	require.False(t, ok)
+
+	var names []string
+	for _, n := range g.FindCycle(node.ID) {
+		names = append(names, n.Name)
+	}
+	require.Equal(t, []string{"job:up:ratio", "job:up:max", "job:up:ratio"}, names)
+	require.Nil(t, g.FindCycle(5))
+	require.Len(t, g.Consumers(0), 2)
+	require.Empty(t, g.Consumers(5))
+	require.Len(t, g.Dependencies(4), 3)
+
+	var dot bytes.Buffer
+	require.NoError(t, g.WriteDOT(&dot))
+	require.Equal(t, `digraph pint {
+  n0 [label="job:up:sum", tooltip="a.yml:2", shape=box];
+  n1 [label="job:up:sum", tooltip="a.yml:4", shape=box];
+  n2 [label="job:up:ratio", tooltip="a.yml:8", shape=box];
+  n3 [label="broken", tooltip="a.yml:10", shape=box];
+  n4 [label="job:up:max", tooltip="b.yml:2", shape=box];
+  n5 [label="Down", tooltip="b.yml:4", shape=ellipse];
+  n0 -> n2 [tooltip="job:up:sum{cluster=\"prod\"}"];
+  n4 -> n2 [tooltip="job:up:max"];
+  n0 -> n4 [tooltip="{__name__=~\"job:up:(sum|ratio)\"}"];
+  n1 -> n4 [tooltip="{__name__=~\"job:up:(sum|ratio)\"}"];
+  n2 -> n4 [tooltip="{__name__=~\"job:up:(sum|ratio)\"}"];
+  n2 -> n5 [tooltip="job:up:ratio"];
+}
+`, dot.String())
+}
+
+func TestGraphEmpty(t *testing.T) {
+	g := graph.New(nil)
+
+	var out bytes.Buffer
+	require.NoError(t, g.WriteJSON(&out))
+	require.Equal(t, "{\n  \"nodes\": [],\n  \"edges\": []\n}\n", out.String())
+}
+
+func TestGraphFromContext(t *testing.T) {
+	entries := mustParse(t, "a.yml", `
+- record: job:up:sum
+  expr: sum(up) by(job)
+- alert: Down
+  expr: job:up:sum == 0
+`)
+
+	ctx := graph.NewContext(context.Background(), entries)
+	g := graph.FromContext(ctx, nil)
+	require.Len(t, g.Nodes, 2)
+	require.Len(t, g.Edges, 1)
+
+	// Entries passed to FromContext are ignored if ctx already has a graph.
+	other := graph.FromContext(ctx, mustParse(t, "b.yml", "- record: foo\n  expr: bar\n"))
+	require.Equal(t, g.Nodes, other.Nodes)
+
+	node, ok := g.Find("a.yml", entries[1].Rule)
+	require.True(t, ok)
+	require.Equal(t, "Down", node.Name)
+	_, ok = g.Find("b.yml", entries[1].Rule)
+	require.False(t, ok)
+
+	// Without a graph in ctx one is built from given entries.
+	g = graph.FromContext(context.Background(), entries[:1])
+	require.Len(t, g.Nodes, 1)
+}
@@ -19,23 +19,33 @@ func DecodeExpr(expr string) (*PromQLNode, error) {
 			for _, perr := range perrs {
 				pqe.Err = perr.Err
 				pqe.node.Expr = perr.Query
+				pr := perr.PositionRange
+				pqe.positionRange = &pr
 			}
 		}
 		return nil, pqe
 	}
 
+	pn := decodeNode(node)
+	pn.Expr = expr
+	return pn, nil
+}
+
+// decodeNode builds a PromQLNode tree from an already parsed expression.
+// Child nodes are reused as-is, so their position ranges are always
+// relative to the root expression.
+func decodeNode(node promparser.Node) *PromQLNode {
 	pn := PromQLNode{
-		Expr: expr,
-		Node: node,
+		Expr: node.String(),
+		Node: node.(promparser.Expr),
 	}
 
 	for _, child := range promparser.Children(node) {
-		c, err := DecodeExpr(child.String())
-		if err != nil {
-			return nil, err
+		if _, ok := child.(promparser.Expr); !ok {
+			continue
 		}
-		pn.Children = append(pn.Children, c)
+		pn.Children = append(pn.Children, decodeNode(child))
 	}
 
-	return &pn, nil
+	return &pn
 }
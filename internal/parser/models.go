@@ -87,9 +87,20 @@ func mergeComments(node *yaml.Node) (comments []string) {
 }
 
 type YamlNode struct {
-	Position FilePosition
-	Value    string
-	Comments []string
+	Position  FilePosition
+	Value     string
+	Comments  []string
+	positions []TextPosition
+}
+
+// PositionAt returns the position in the rule file for given byte offset
+// inside the node value.
+// It returns false if the value cannot be mapped back to the file.
+func (yn YamlNode) PositionAt(offset int) (TextPosition, bool) {
+	if offset < 0 || offset >= len(yn.positions) {
+		return TextPosition{}, false
+	}
+	return yn.positions[offset], true
 }
 
 func newYamlNode(node *yaml.Node, offset int) *YamlNode {
@@ -140,7 +151,7 @@ func (ym YamlMap) GetValue(key string) *YamlNode {
 	return nil
 }
 
-func newYamlMap(key, value *yaml.Node, offset int) *YamlMap {
+func newYamlMap(content []byte, key, value *yaml.Node, offset int) *YamlMap {
 	ym := YamlMap{
 		Key: newYamlNode(key, offset),
 	}
@@ -152,6 +163,9 @@ func newYamlMap(key, value *yaml.Node, offset int) *YamlMap {
 				Key:   newYamlNode(ckey, offset),
 				Value: newYamlNode(child, offset),
 			}
+			if offset == 0 {
+				kv.Value.positions = mapValuePositions(content, child)
+			}
 			ym.Items = append(ym.Items, &kv)
 			ckey = nil
 		} else {
@@ -169,8 +183,9 @@ type PromQLNode struct {
 }
 
 type PromQLError struct {
-	node *PromQLNode
-	Err  error
+	node          *PromQLNode
+	positionRange *promparser.PositionRange
+	Err           error
 }
 
 func (pqle PromQLError) Error() string {
@@ -185,6 +200,15 @@ func (pqle PromQLError) Node() *PromQLNode {
 	return pqle.node
 }
 
+// PositionRange returns the part of the query the error was reported for,
+// if the PromQL parser provided it.
+func (pqle PromQLError) PositionRange() (promparser.PositionRange, bool) {
+	if pqle.positionRange == nil {
+		return promparser.PositionRange{}, false
+	}
+	return *pqle.positionRange, true
+}
+
 type PromQLExpr struct {
 	Key         *YamlNode
 	Value       *YamlNode
@@ -198,11 +222,39 @@ func (pqle PromQLExpr) Lines() (lines []int) {
 	return lines
 }
 
-func newPromQLExpr(key, val *yaml.Node, offset int) *PromQLExpr {
+// PositionAt returns the position in the rule file for given byte offset
+// inside the query string, as reported by PromQL position ranges.
+// It returns false if the query cannot be mapped back to the file.
+func (pqle PromQLExpr) PositionAt(offset int) (TextPosition, bool) {
+	return pqle.Value.PositionAt(offset)
+}
+
+// TextRange returns positions in the rule file of the start and end of given
+// position range, as reported by PromQL nodes. End position is exclusive.
+// It returns false if the range cannot be mapped back to the file.
+func (pqle PromQLExpr) TextRange(pr promparser.PositionRange) (start, end TextPosition, ok bool) {
+	if pr.End < pr.Start {
+		return start, end, false
+	}
+	if start, ok = pqle.PositionAt(int(pr.Start)); !ok {
+		return start, end, false
+	}
+	if end, ok = pqle.PositionAt(int(pr.End)); !ok {
+		return start, end, false
+	}
+	return start, end, true
+}
+
+func newPromQLExpr(content []byte, key, val *yaml.Node, offset int) *PromQLExpr {
 	expr := PromQLExpr{
 		Key:   newYamlNode(key, offset),
 		Value: newYamlNode(val, offset),
 	}
+	// Rules embedded inside scalar nodes are parsed from a copy of the
+	// scalar value, so we can't map those back to the file.
+	if offset == 0 {
+		expr.Value.positions = mapValuePositions(content, val)
+	}
 
 	qlNode, err := DecodeExpr(val.Value)
 	if err != nil {
@@ -295,6 +347,40 @@ func (rr RecordingRule) Comments() (comments []string) {
 	return comments
 }
 
+type Group struct {
+	Position                FilePosition
+	Name                    *YamlKeyValue
+	Interval                *YamlKeyValue
+	Limit                   *YamlKeyValue
+	PartialResponseStrategy *YamlKeyValue
+	Comments                []string
+}
+
+func (g Group) Lines() (lines []int) {
+	lines = appendLine(lines, g.Position.Lines...)
+	for _, kv := range []*YamlKeyValue{g.Name, g.Interval, g.Limit, g.PartialResponseStrategy} {
+		if kv != nil {
+			lines = appendLine(lines, kv.Lines()...)
+		}
+	}
+	slices.Sort(lines)
+	return lines
+}
+
+func (g Group) GetName() string {
+	if g.Name == nil {
+		return ""
+	}
+	return g.Name.Value.Value
+}
+
+type PrometheusRule struct {
+	Position  FilePosition
+	Name      string
+	Namespace string
+	Labels    map[string]string
+}
+
 type ParseError struct {
 	Fragment string
 	Err      error
@@ -302,9 +388,11 @@ type ParseError struct {
 }
 
 type Rule struct {
-	AlertingRule  *AlertingRule
-	RecordingRule *RecordingRule
-	Error         ParseError
+	AlertingRule   *AlertingRule
+	RecordingRule  *RecordingRule
+	Group          *Group
+	PrometheusRule *PrometheusRule
+	Error          ParseError
 }
 
 func (r Rule) ToYAML() string {
@@ -434,6 +522,15 @@ func (r Rule) Lines() []int {
 	return nil
 }
 
+func (r Rule) LastLine() (line int) {
+	for _, l := range r.Lines() {
+		if l > line {
+			line = l
+		}
+	}
+	return line
+}
+
 func (r Rule) LineRange() []int {
 	var lmin, lmax int
 	for i, line := range r.Lines() {
@@ -1,7 +1,10 @@
 package parser
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -14,6 +17,22 @@ const (
 	alertKey       = "alert"
 	forKey         = "for"
 	annotationsKey = "annotations"
+
+	groupNameKey                    = "name"
+	groupIntervalKey                = "interval"
+	groupLimitKey                   = "limit"
+	groupPartialResponseStrategyKey = "partial_response_strategy"
+	groupRulesKey                   = "rules"
+
+	prometheusRuleAPIGroup = "monitoring.coreos.com/"
+	prometheusRuleKind     = "PrometheusRule"
+	apiVersionKey          = "apiVersion"
+	kindKey                = "kind"
+	metadataKey            = "metadata"
+	metadataNameKey        = "name"
+	metadataNamespaceKey   = "namespace"
+	metadataLabelsKey      = "labels"
+	specKey                = "spec"
 )
 
 func NewParser() Parser {
@@ -33,25 +52,67 @@ func (p Parser) Parse(content []byte) (rules []Rule, err error) {
 		}
 	}()
 
-	var node yaml.Node
-	err = yaml.Unmarshal(content, &node)
-	if err != nil {
-		return nil, err
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var node yaml.Node
+		err = dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var rl []Rule
+		if pr, spec := parsePrometheusRule(&node); pr != nil {
+			if spec != nil {
+				rl, err = parseNode(content, spec, 0, nil)
+			}
+			for i := range rl {
+				rl[i].PrometheusRule = pr
+			}
+		} else {
+			rl, err = parseNode(content, &node, 0, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rl...)
 	}
 
-	return parseNode(content, &node, 0)
+	return rules, nil
+}
+
+// IsPrometheusRule returns true if any YAML document in given content
+// is a Kubernetes PrometheusRule resource.
+func IsPrometheusRule(content []byte) bool {
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var node yaml.Node
+		if err := dec.Decode(&node); err != nil {
+			return false
+		}
+		if pr, _ := parsePrometheusRule(&node); pr != nil {
+			return true
+		}
+	}
 }
 
-func parseNode(content []byte, node *yaml.Node, offset int) (rules []Rule, err error) {
+func parseNode(content []byte, node *yaml.Node, offset int, group *Group) (rules []Rule, err error) {
 	ret, isEmpty, err := parseRule(content, node, offset)
 	if err != nil {
 		return nil, err
 	}
 	if !isEmpty {
+		ret.Group = group
 		rules = append(rules, ret)
 		return rules, nil
 	}
 
+	if g := parseGroup(node, offset); g != nil {
+		group = g
+	}
+
 	var rl []Rule
 	var rule Rule
 	for _, root := range node.Content {
@@ -59,7 +120,7 @@ func parseNode(content []byte, node *yaml.Node, offset int) (rules []Rule, err e
 		switch root.Kind {
 		case yaml.SequenceNode:
 			for _, n := range root.Content {
-				rl, err = parseNode(content, n, offset)
+				rl, err = parseNode(content, n, offset, group)
 				if err != nil {
 					return nil, err
 				}
@@ -71,10 +132,15 @@ func parseNode(content []byte, node *yaml.Node, offset int) (rules []Rule, err e
 				return nil, err
 			}
 			if !isEmpty {
+				rule.Group = group
 				rules = append(rules, rule)
 			} else {
+				rg := group
+				if g := parseGroup(root, offset); g != nil {
+					rg = g
+				}
 				for _, n := range root.Content {
-					rl, err = parseNode(content, n, offset)
+					rl, err = parseNode(content, n, offset, rg)
 					if err != nil {
 						return nil, err
 					}
@@ -87,7 +153,7 @@ func parseNode(content []byte, node *yaml.Node, offset int) (rules []Rule, err e
 				var n yaml.Node
 				err = yaml.Unmarshal(c, &n)
 				if err == nil {
-					ret, err := parseNode(c, &n, offset+root.Line)
+					ret, err := parseNode(c, &n, offset+root.Line, nil)
 					if err != nil {
 						return nil, err
 					}
@@ -140,7 +206,7 @@ func parseRule(content []byte, node *yaml.Node, offset int) (rule Rule, _ bool,
 				if exprPart != nil {
 					return duplicatedKeyError(part.Line+offset, exprKey, nil)
 				}
-				exprPart = newPromQLExpr(key, part, offset)
+				exprPart = newPromQLExpr(content, key, part, offset)
 			case forKey:
 				if forPart != nil {
 					return duplicatedKeyError(part.Line+offset, forKey, nil)
@@ -150,12 +216,12 @@ func parseRule(content []byte, node *yaml.Node, offset int) (rule Rule, _ bool,
 				if labelsPart != nil {
 					return duplicatedKeyError(part.Line+offset, labelsKey, nil)
 				}
-				labelsPart = newYamlMap(key, part, offset)
+				labelsPart = newYamlMap(content, key, part, offset)
 			case annotationsKey:
 				if annotationsPart != nil {
 					return duplicatedKeyError(part.Line+offset, annotationsKey, nil)
 				}
-				annotationsPart = newYamlMap(key, part, offset)
+				annotationsPart = newYamlMap(content, key, part, offset)
 			default:
 				unknownKeys = append(unknownKeys, key)
 			}
@@ -244,6 +310,109 @@ func parseRule(content []byte, node *yaml.Node, offset int) (rule Rule, _ bool,
 	return rule, true, err
 }
 
+func parseGroup(node *yaml.Node, offset int) *Group {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	group := Group{
+		Position: NewFilePosition([]int{node.Line + offset}),
+		Comments: mergeComments(node),
+	}
+
+	var isGroup bool
+	var key *yaml.Node
+	for i, part := range unpackNodes(node) {
+		if i%2 == 0 {
+			key = part
+			continue
+		}
+		switch key.Value {
+		case groupNameKey:
+			group.Name = newYamlKeyValue(key, part, offset)
+		case groupIntervalKey:
+			group.Interval = newYamlKeyValue(key, part, offset)
+		case groupLimitKey:
+			group.Limit = newYamlKeyValue(key, part, offset)
+		case groupPartialResponseStrategyKey:
+			group.PartialResponseStrategy = newYamlKeyValue(key, part, offset)
+		case groupRulesKey:
+			isGroup = part.Kind == yaml.SequenceNode
+		}
+	}
+
+	if !isGroup {
+		return nil
+	}
+	return &group
+}
+
+func parsePrometheusRule(node *yaml.Node) (*PrometheusRule, *yaml.Node) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var apiVersion, kind string
+	var metadata, spec *yaml.Node
+	var key *yaml.Node
+	for i, part := range unpackNodes(node) {
+		if i%2 == 0 {
+			key = part
+			continue
+		}
+		switch key.Value {
+		case apiVersionKey:
+			apiVersion = part.Value
+		case kindKey:
+			kind = part.Value
+		case metadataKey:
+			metadata = part
+		case specKey:
+			spec = part
+		}
+	}
+
+	if kind != prometheusRuleKind || !strings.HasPrefix(apiVersion, prometheusRuleAPIGroup) {
+		return nil, nil
+	}
+
+	pr := PrometheusRule{
+		Position: NewFilePosition([]int{node.Line}),
+		Labels:   map[string]string{},
+	}
+	if metadata != nil && metadata.Kind == yaml.MappingNode {
+		for i, part := range unpackNodes(metadata) {
+			if i%2 == 0 {
+				key = part
+				continue
+			}
+			switch key.Value {
+			case metadataNameKey:
+				pr.Name = part.Value
+			case metadataNamespaceKey:
+				pr.Namespace = part.Value
+			case metadataLabelsKey:
+				if part.Kind != yaml.MappingNode {
+					continue
+				}
+				var lkey *yaml.Node
+				for j, lpart := range unpackNodes(part) {
+					if j%2 == 0 {
+						lkey = lpart
+						continue
+					}
+					pr.Labels[lkey.Value] = lpart.Value
+				}
+			}
+		}
+	}
+
+	return &pr, spec
+}
+
 func unpackNodes(node *yaml.Node) []*yaml.Node {
 	nodes := make([]*yaml.Node, 0, len(node.Content))
 	var isMerge bool
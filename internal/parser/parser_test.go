@@ -8,6 +8,7 @@ import (
 	"github.com/cloudflare/pint/internal/parser"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	promparser "github.com/prometheus/prometheus/promql/parser"
 )
 
@@ -477,6 +478,19 @@ groups:
 							},
 						},
 					},
+					Group: &parser.Group{
+						Position: parser.FilePosition{Lines: []int{3}},
+						Name: &parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{3}},
+								Value:    "name",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{3}},
+								Value:    "custom_rules",
+							},
+						},
+					},
 				},
 			},
 			shouldError: false,
@@ -817,6 +831,19 @@ data:
 							},
 						},
 					},
+					Group: &parser.Group{
+						Position: parser.FilePosition{Lines: []int{11}},
+						Name: &parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{11}},
+								Value:    "name",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{11}},
+								Value:    "example-app-alerts",
+							},
+						},
+					},
 				},
 				{
 					AlertingRule: &parser.AlertingRule{
@@ -874,6 +901,19 @@ data:
 							},
 						},
 					},
+					Group: &parser.Group{
+						Position: parser.FilePosition{Lines: []int{11}},
+						Name: &parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{11}},
+								Value:    "name",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{11}},
+								Value:    "example-app-alerts",
+							},
+						},
+					},
 				},
 			},
 		},
@@ -992,6 +1032,19 @@ data:
 							},
 						},
 					},
+					Group: &parser.Group{
+						Position: parser.FilePosition{Lines: []int{2}},
+						Name: &parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{2}},
+								Value:    "name",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{2}},
+								Value:    "haproxy.api_server.rules",
+							},
+						},
+					},
 				},
 			},
 		},
@@ -1031,6 +1084,19 @@ data:
 							Query: &parser.PromQLNode{Expr: "expr1"},
 						},
 					},
+					Group: &parser.Group{
+						Position: parser.FilePosition{Lines: []int{2}},
+						Name: &parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{2}},
+								Value:    "name",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{2}},
+								Value:    "certmanager",
+							},
+						},
+					},
 				},
 				{
 					RecordingRule: &parser.RecordingRule{
@@ -1056,6 +1122,19 @@ data:
 							Query: &parser.PromQLNode{Expr: "expr2"},
 						},
 					},
+					Group: &parser.Group{
+						Position: parser.FilePosition{Lines: []int{2}},
+						Name: &parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{2}},
+								Value:    "name",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{2}},
+								Value:    "certmanager",
+							},
+						},
+					},
 				},
 				{
 					RecordingRule: &parser.RecordingRule{
@@ -1081,6 +1160,19 @@ data:
 							Query: &parser.PromQLNode{Expr: "expr1"},
 						},
 					},
+					Group: &parser.Group{
+						Position: parser.FilePosition{Lines: []int{2}},
+						Name: &parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{2}},
+								Value:    "name",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{2}},
+								Value:    "certmanager",
+							},
+						},
+					},
 				},
 			},
 		},
@@ -1151,6 +1243,19 @@ data:
 							},
 						},
 					},
+					Group: &parser.Group{
+						Position: parser.FilePosition{Lines: []int{2}},
+						Name: &parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{2}},
+								Value:    "name",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{2}},
+								Value:    "certmanager",
+							},
+						},
+					},
 				},
 				{
 					RecordingRule: &parser.RecordingRule{
@@ -1205,6 +1310,19 @@ data:
 							},
 						},
 					},
+					Group: &parser.Group{
+						Position: parser.FilePosition{Lines: []int{2}},
+						Name: &parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{2}},
+								Value:    "name",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{2}},
+								Value:    "certmanager",
+							},
+						},
+					},
 				},
 			},
 		},
@@ -1244,6 +1362,220 @@ data:
 				{Error: parser.ParseError{Err: fmt.Errorf("missing expr key"), Line: 1}},
 			},
 		},
+		{
+			content: []byte(`groups:
+# group comment
+- name: foo
+  interval: 5m
+  limit: 10
+  partial_response_strategy: warn
+  rules:
+  - record: foo
+    expr: bar
+`),
+			output: []parser.Rule{
+				{
+					RecordingRule: &parser.RecordingRule{
+						Record: parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{8}},
+								Value:    "record",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{8}},
+								Value:    "foo",
+							},
+						},
+						Expr: parser.PromQLExpr{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{9}},
+								Value:    "expr",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{9}},
+								Value:    "bar",
+							},
+							Query: &parser.PromQLNode{Expr: "bar"},
+						},
+					},
+					Group: &parser.Group{
+						Position: parser.FilePosition{Lines: []int{3}},
+						Name: &parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{3}},
+								Value:    "name",
+								Comments: []string{"# group comment"},
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{3}},
+								Value:    "foo",
+							},
+						},
+						Interval: &parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{4}},
+								Value:    "interval",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{4}},
+								Value:    "5m",
+							},
+						},
+						Limit: &parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{5}},
+								Value:    "limit",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{5}},
+								Value:    "10",
+							},
+						},
+						PartialResponseStrategy: &parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{6}},
+								Value:    "partial_response_strategy",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{6}},
+								Value:    "warn",
+							},
+						},
+						Comments: []string{"# group comment"},
+					},
+				},
+			},
+		},
+		{
+			content: []byte(`- record: foo
+  expr: bar
+---
+- record: bar
+  expr: foo
+`),
+			output: []parser.Rule{
+				{
+					RecordingRule: &parser.RecordingRule{
+						Record: parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{1}},
+								Value:    "record",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{1}},
+								Value:    "foo",
+							},
+						},
+						Expr: parser.PromQLExpr{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{2}},
+								Value:    "expr",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{2}},
+								Value:    "bar",
+							},
+							Query: &parser.PromQLNode{Expr: "bar"},
+						},
+					},
+				},
+				{
+					RecordingRule: &parser.RecordingRule{
+						Record: parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{4}},
+								Value:    "record",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{4}},
+								Value:    "bar",
+							},
+						},
+						Expr: parser.PromQLExpr{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{5}},
+								Value:    "expr",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{5}},
+								Value:    "foo",
+							},
+							Query: &parser.PromQLNode{Expr: "foo"},
+						},
+					},
+				},
+			},
+		},
+		{
+			content: []byte(`---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+data:
+  foo: bar
+---
+apiVersion: monitoring.coreos.com/v1
+kind: PrometheusRule
+metadata:
+  name: example
+  namespace: monitoring
+  labels:
+    prometheus: k8s
+spec:
+  groups:
+  - name: example.rules
+    rules:
+    - record: foo
+      expr: bar
+`),
+			output: []parser.Rule{
+				{
+					RecordingRule: &parser.RecordingRule{
+						Record: parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{20}},
+								Value:    "record",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{20}},
+								Value:    "foo",
+							},
+						},
+						Expr: parser.PromQLExpr{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{21}},
+								Value:    "expr",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{21}},
+								Value:    "bar",
+							},
+							Query: &parser.PromQLNode{Expr: "bar"},
+						},
+					},
+					Group: &parser.Group{
+						Position: parser.FilePosition{Lines: []int{18}},
+						Name: &parser.YamlKeyValue{
+							Key: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{18}},
+								Value:    "name",
+							},
+							Value: &parser.YamlNode{
+								Position: parser.FilePosition{Lines: []int{18}},
+								Value:    "example.rules",
+							},
+						},
+					},
+					PrometheusRule: &parser.PrometheusRule{
+						Position:  parser.FilePosition{Lines: []int{9}},
+						Name:      "example",
+						Namespace: "monitoring",
+						Labels:    map[string]string{"prometheus": "k8s"},
+					},
+				},
+			},
+		},
 	}
 
 	alwaysEqual := cmp.Comparer(func(_, _ interface{}) bool { return true })
@@ -1275,7 +1607,7 @@ data:
 				return
 			}
 
-			if diff := cmp.Diff(tc.output, output, ignorePrometheusExpr, sameErrorText); diff != "" {
+			if diff := cmp.Diff(tc.output, output, ignorePrometheusExpr, sameErrorText, cmpopts.IgnoreUnexported(parser.YamlNode{})); diff != "" {
 				t.Errorf("Parse() returned wrong output (-want +got):\n%s", diff)
 				return
 			}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"bytes"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TextPosition is a location inside a rule file.
+// Both Line and Column start at 1, Column is counted in bytes.
+type TextPosition struct {
+	Line   int
+	Column int
+}
+
+// mapValuePositions returns the file position of every byte of a scalar
+// node value, plus one extra entry for the position right after the value.
+// YAML scalars can be quoted, folded or split across multiple lines, so we
+// walk both the decoded value and the raw file content side by side.
+// If the two cannot be matched up then nil is returned.
+func mapValuePositions(content []byte, node *yaml.Node) []TextPosition {
+	if node.Kind != yaml.ScalarNode || node.Line < 1 || node.Column < 1 {
+		return nil
+	}
+
+	lines := bytes.Split(content, []byte("\n"))
+	line, col := node.Line-1, node.Column-1
+	if line >= len(lines) {
+		return nil
+	}
+
+	var indent int
+	switch node.Style {
+	case yaml.SingleQuotedStyle, yaml.DoubleQuotedStyle:
+		col++
+	case yaml.LiteralStyle, yaml.FoldedStyle:
+		line++
+		for line < len(lines) && len(bytes.TrimSpace(lines[line])) == 0 {
+			line++
+		}
+		if line >= len(lines) {
+			return nil
+		}
+		indent = len(lines[line]) - len(bytes.TrimLeft(lines[line], " "))
+		col = indent
+	}
+
+	nextLine := func() bool {
+		line++
+		if line >= len(lines) {
+			return false
+		}
+		col = 0
+		if node.Style == yaml.LiteralStyle {
+			if len(lines[line]) >= indent {
+				col = indent
+			}
+			return true
+		}
+		for col < len(lines[line]) && (lines[line][col] == ' ' || lines[line][col] == '\t') {
+			col++
+		}
+		return true
+	}
+
+	positions := make([]TextPosition, 0, len(node.Value)+1)
+	for i := 0; i < len(node.Value); i++ {
+		if line >= len(lines) {
+			return nil
+		}
+		ch := node.Value[i]
+		cur := lines[line]
+		switch {
+		case col+1 < len(cur) && ch == '\'' && node.Style == yaml.SingleQuotedStyle && cur[col] == '\'' && cur[col+1] == '\'':
+			positions = append(positions, TextPosition{Line: line + 1, Column: col + 1})
+			col += 2
+		case col < len(cur) && cur[col] == ch:
+			if node.Style == yaml.DoubleQuotedStyle && ch == '\\' {
+				return nil
+			}
+			positions = append(positions, TextPosition{Line: line + 1, Column: col + 1})
+			col++
+		case (ch == '\n' || ch == ' ') && len(bytes.TrimSpace(cur[min(col, len(cur)):])) == 0:
+			positions = append(positions, TextPosition{Line: line + 1, Column: col + 1})
+			if !nextLine() && i < len(node.Value)-1 {
+				return nil
+			}
+			// Folded line breaks can span multiple empty lines.
+			for node.Style != yaml.LiteralStyle && i+1 < len(node.Value) && node.Value[i+1] != '\n' &&
+				line < len(lines) && len(bytes.TrimSpace(lines[line])) == 0 {
+				if !nextLine() {
+					return nil
+				}
+			}
+		default:
+			return nil
+		}
+	}
+	positions = append(positions, TextPosition{Line: line + 1, Column: col + 1})
+
+	return positions
+}
@@ -0,0 +1,170 @@
+package parser_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	promParser "github.com/prometheus/prometheus/promql/parser"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+func TestPromQLExprPositionAt(t *testing.T) {
+	type testCaseT struct {
+		content   string
+		offset    int
+		position  parser.TextPosition
+		isMissing bool
+	}
+
+	testCases := []testCaseT{
+		{
+			content:  "- record: foo\n  expr: sum(foo) by(job)\n",
+			offset:   0,
+			position: parser.TextPosition{Line: 2, Column: 9},
+		},
+		{
+			content:  "- record: foo\n  expr: sum(foo) by(job)\n",
+			offset:   12,
+			position: parser.TextPosition{Line: 2, Column: 21},
+		},
+		{
+			content:  "- record: foo\n  expr: sum(foo) by(job)\n",
+			offset:   len("sum(foo) by(job)"),
+			position: parser.TextPosition{Line: 2, Column: 25},
+		},
+		{
+			content:  "- record: foo\n  expr: \"sum(foo{job=~'bar'})\"\n",
+			offset:   8,
+			position: parser.TextPosition{Line: 2, Column: 18},
+		},
+		{
+			content:  "- record: foo\n  expr: 'sum(foo{job=~''bar''})'\n",
+			offset:   19,
+			position: parser.TextPosition{Line: 2, Column: 31},
+		},
+		{
+			content:  "- record: foo\n  expr: |\n    sum(foo)\n    by(job)\n",
+			offset:   11,
+			position: parser.TextPosition{Line: 4, Column: 7},
+		},
+		{
+			content:  "- record: foo\n  expr: >\n    sum(foo)\n\n    by(job)\n",
+			offset:   9,
+			position: parser.TextPosition{Line: 5, Column: 5},
+		},
+		{
+			content:  "- record: foo\n  expr: sum(foo)\n    by(job)\n",
+			offset:   12,
+			position: parser.TextPosition{Line: 3, Column: 8},
+		},
+		{
+			content:   "- record: foo\n  expr: \"sum(foo{job=~\\\"bar\\\"})\"\n",
+			offset:    0,
+			isMissing: true,
+		},
+		{
+			content:   "- record: foo\n  expr: sum(foo)\n",
+			offset:    100,
+			isMissing: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i+1), func(t *testing.T) {
+			p := parser.NewParser()
+			rules, err := p.Parse([]byte(tc.content))
+			require.NoError(t, err)
+			require.Len(t, rules, 1)
+
+			expr := rules[0].Expr()
+			require.Nil(t, expr.SyntaxError)
+			pos, ok := expr.PositionAt(tc.offset)
+			require.Equal(t, !tc.isMissing, ok, strings.TrimSpace(tc.content))
+			if ok {
+				require.Equal(t, tc.position, pos)
+			}
+		})
+	}
+}
+
+func TestYamlNodePositionAt(t *testing.T) {
+	p := parser.NewParser()
+	rules, err := p.Parse([]byte(`- alert: foo
+  expr: up == 0
+  labels:
+    severity: page
+  annotations:
+    summary: 'value is {{ $value }}'
+`))
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+
+	label := rules[0].AlertingRule.Labels.Items[0]
+	pos, ok := label.Value.PositionAt(0)
+	require.True(t, ok)
+	require.Equal(t, parser.TextPosition{Line: 4, Column: 15}, pos)
+
+	annotation := rules[0].AlertingRule.Annotations.Items[0]
+	pos, ok = annotation.Value.PositionAt(len("value is "))
+	require.True(t, ok)
+	require.Equal(t, parser.TextPosition{Line: 6, Column: 24}, pos)
+
+	_, ok = annotation.Key.PositionAt(0)
+	require.False(t, ok)
+}
+
+func TestPromQLExprTextRange(t *testing.T) {
+	type testCaseT struct {
+		content   string
+		pr        promParser.PositionRange
+		start     parser.TextPosition
+		end       parser.TextPosition
+		isMissing bool
+	}
+
+	testCases := []testCaseT{
+		{
+			content: "- record: foo\n  expr: sum(foo) by(job)\n",
+			pr:      promParser.PositionRange{Start: 4, End: 7},
+			start:   parser.TextPosition{Line: 2, Column: 13},
+			end:     parser.TextPosition{Line: 2, Column: 16},
+		},
+		{
+			content: "- record: foo\n  expr: |\n    sum(\n      foo\n    ) by(job)\n",
+			pr:      promParser.PositionRange{Start: 0, End: 20},
+			start:   parser.TextPosition{Line: 3, Column: 5},
+			end:     parser.TextPosition{Line: 5, Column: 14},
+		},
+		{
+			content:   "- record: foo\n  expr: sum(foo)\n",
+			pr:        promParser.PositionRange{Start: 0, End: 100},
+			isMissing: true,
+		},
+		{
+			content:   "- record: foo\n  expr: sum(foo)\n",
+			pr:        promParser.PositionRange{Start: 4, End: 2},
+			isMissing: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i+1), func(t *testing.T) {
+			p := parser.NewParser()
+			rules, err := p.Parse([]byte(tc.content))
+			require.NoError(t, err)
+			require.Len(t, rules, 1)
+
+			expr := rules[0].Expr()
+			require.Nil(t, expr.SyntaxError)
+			start, end, ok := expr.TextRange(tc.pr)
+			require.Equal(t, !tc.isMissing, ok, strings.TrimSpace(tc.content))
+			if ok {
+				require.Equal(t, tc.start, start)
+				require.Equal(t, tc.end, end)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"bufio"
+	"bytes"
 	"errors"
 	"io"
 	"strings"
@@ -199,3 +200,58 @@ func GetLastComment(text string, comment ...string) (Comment, bool) {
 	}
 	return comments[len(comments)-1], true
 }
+
+// ControlComment is a "# pint ..." comment together with the line it was found on.
+// Unmatched is set on ignore/begin and ignore/end comments without a matching pair.
+type ControlComment struct {
+	Line int
+	Comment
+	Unmatched bool
+}
+
+// FindControlComments returns all "# pint ..." comments from given content.
+func FindControlComments(content []byte) (comments []ControlComment) {
+	sc := bufio.NewScanner(bytes.NewReader(content))
+	var line int
+	for sc.Scan() {
+		line++
+		elems := strings.Split(sc.Text(), "#")
+		if len(elems) < 2 {
+			continue
+		}
+		parts := strings.SplitN(removeRedundantSpaces(elems[len(elems)-1]), " ", 3)
+		if len(parts) < 2 || parts[0] != "pint" {
+			continue
+		}
+		cc := ControlComment{Line: line, Comment: Comment{Key: parts[1]}}
+		if len(parts) == 3 {
+			cc.Value = parts[2]
+		}
+		comments = append(comments, cc)
+	}
+	markUnmatchedIgnoreComments(comments)
+	return comments
+}
+
+func markUnmatchedIgnoreComments(comments []ControlComment) {
+	begin := -1
+	for i, cmt := range comments {
+		if cmt.Value != "" {
+			continue
+		}
+		switch cmt.Key {
+		case "ignore/begin":
+			if begin < 0 {
+				begin = i
+			}
+		case "ignore/end":
+			if begin < 0 {
+				comments[i].Unmatched = true
+			}
+			begin = -1
+		}
+	}
+	if begin >= 0 {
+		comments[begin].Unmatched = true
+	}
+}
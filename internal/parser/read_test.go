@@ -280,3 +280,42 @@ func TestGetLastComment(t *testing.T) {
 		})
 	}
 }
+
+func TestFindControlComments(t *testing.T) {
+	type testCaseT struct {
+		input  string
+		output []parser.ControlComment
+	}
+
+	testCases := []testCaseT{
+		{
+			input: "",
+		},
+		{
+			input: "# foo\n# pint\n- record: foo # bar\n",
+		},
+		{
+			input: "# pint file/owner bob\n\n- record: foo # pint   disable    promql/series\n  expr: sum(foo)\n  # pint ignore/end\n",
+			output: []parser.ControlComment{
+				{Line: 1, Comment: parser.Comment{Key: "file/owner", Value: "bob"}},
+				{Line: 3, Comment: parser.Comment{Key: "disable", Value: "promql/series"}},
+				{Line: 5, Comment: parser.Comment{Key: "ignore/end"}, Unmatched: true},
+			},
+		},
+		{
+			input: "# pint ignore/begin\n# pint ignore/end\n# pint ignore/begin\n# pint ignore/begin\n",
+			output: []parser.ControlComment{
+				{Line: 1, Comment: parser.Comment{Key: "ignore/begin"}},
+				{Line: 2, Comment: parser.Comment{Key: "ignore/end"}},
+				{Line: 3, Comment: parser.Comment{Key: "ignore/begin"}, Unmatched: true},
+				{Line: 4, Comment: parser.Comment{Key: "ignore/begin"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			require.Equal(t, tc.output, parser.FindControlComments([]byte(tc.input)))
+		})
+	}
+}
@@ -10,7 +10,11 @@ type Snooze struct {
 	Text  string
 }
 
-func ParseSnooze(comment string) *Snooze {
+func (s Snooze) IsExpired() bool {
+	return !s.Until.After(time.Now())
+}
+
+func parseSnooze(comment string) *Snooze {
 	parts := strings.SplitN(comment, " ", 2)
 	if len(parts) != 2 {
 		return nil
@@ -28,9 +32,24 @@ func ParseSnooze(comment string) *Snooze {
 		return nil
 	}
 
-	if !s.Until.After(time.Now()) {
+	return &s
+}
+
+// ParseSnooze returns parsed snooze comment if it's valid and not expired yet.
+func ParseSnooze(comment string) *Snooze {
+	s := parseSnooze(comment)
+	if s == nil || s.IsExpired() {
 		return nil
 	}
+	return s
+}
 
-	return &s
+// ParseExpiredSnooze returns parsed snooze comment only if it's valid
+// and already expired.
+func ParseExpiredSnooze(comment string) *Snooze {
+	s := parseSnooze(comment)
+	if s == nil || !s.IsExpired() {
+		return nil
+	}
+	return s
 }
@@ -0,0 +1,284 @@
+package utils
+
+import (
+	"github.com/prometheus/prometheus/model/labels"
+	promParser "github.com/prometheus/prometheus/promql/parser"
+	"golang.org/x/exp/slices"
+
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+// LabelSet describes labels present on the results of a PromQL query.
+type LabelSet struct {
+	// Guaranteed labels are present on every result.
+	Guaranteed []string
+	// Possible labels might be present on some results.
+	Possible []string
+	// Excluded labels are never present on any result.
+	Excluded []string
+	// IsExact is true if results can't have any labels other than
+	// Guaranteed and Possible.
+	IsExact bool
+}
+
+// CanHave returns true if some results of the query might have given label.
+func (ls LabelSet) CanHave(name string) bool {
+	if slices.Contains(ls.Excluded, name) {
+		return false
+	}
+	if slices.Contains(ls.Guaranteed, name) || slices.Contains(ls.Possible, name) {
+		return true
+	}
+	return !ls.IsExact
+}
+
+// Names returns all guaranteed and possible labels.
+func (ls LabelSet) Names() (names []string) {
+	names = append(names, ls.Guaranteed...)
+	names = append(names, ls.Possible...)
+	slices.Sort(names)
+	return names
+}
+
+func (ls *LabelSet) add(name string, isGuaranteed bool) {
+	ls.remove(name)
+	if isGuaranteed {
+		ls.Guaranteed = append(ls.Guaranteed, name)
+	} else {
+		ls.Possible = append(ls.Possible, name)
+	}
+}
+
+func (ls *LabelSet) addFrom(name string, src LabelSet) {
+	switch {
+	case slices.Contains(src.Guaranteed, name):
+		ls.add(name, true)
+	case src.CanHave(name):
+		ls.add(name, false)
+	default:
+		ls.exclude(name)
+	}
+}
+
+func (ls *LabelSet) remove(name string) {
+	ls.Guaranteed = without(ls.Guaranteed, name)
+	ls.Possible = without(ls.Possible, name)
+	ls.Excluded = without(ls.Excluded, name)
+}
+
+func (ls *LabelSet) exclude(name string) {
+	ls.remove(name)
+	ls.Excluded = append(ls.Excluded, name)
+}
+
+func (ls LabelSet) clone() LabelSet {
+	return LabelSet{
+		Guaranteed: slices.Clone(ls.Guaranteed),
+		Possible:   slices.Clone(ls.Possible),
+		Excluded:   slices.Clone(ls.Excluded),
+		IsExact:    ls.IsExact,
+	}
+}
+
+func without(names []string, name string) []string {
+	if i := slices.Index(names, name); i >= 0 {
+		return slices.Delete(names, i, i+1)
+	}
+	return names
+}
+
+// InferLabels returns the set of labels present on the results of given query.
+func InferLabels(node *parser.PromQLNode) LabelSet {
+	return inferLabels(node.Node)
+}
+
+func inferLabels(node promParser.Node) (ls LabelSet) {
+	switch n := node.(type) {
+	case *promParser.VectorSelector:
+		for _, lm := range n.LabelMatchers {
+			if lm.Name == labels.MetricName {
+				continue
+			}
+			if lm.Matches("") {
+				if lm.Type == labels.MatchEqual {
+					ls.exclude(lm.Name)
+				}
+				continue
+			}
+			ls.add(lm.Name, true)
+		}
+		return ls
+	case *promParser.MatrixSelector:
+		return inferLabels(n.VectorSelector)
+	case *promParser.SubqueryExpr:
+		return inferLabels(n.Expr)
+	case *promParser.ParenExpr:
+		return inferLabels(n.Expr)
+	case *promParser.UnaryExpr:
+		return inferLabels(n.Expr)
+	case *promParser.StepInvariantExpr:
+		return inferLabels(n.Expr)
+	case *promParser.NumberLiteral, *promParser.StringLiteral:
+		return LabelSet{IsExact: true}
+	case *promParser.AggregateExpr:
+		return inferAggregation(n)
+	case *promParser.Call:
+		return inferCall(n)
+	case *promParser.BinaryExpr:
+		return inferBinaryExpr(n)
+	}
+	return ls
+}
+
+func inferAggregation(n *promParser.AggregateExpr) LabelSet {
+	src := inferLabels(n.Expr)
+	switch n.Op {
+	case promParser.TOPK, promParser.BOTTOMK:
+		return src
+	}
+
+	var ls LabelSet
+	if n.Without {
+		ls = src.clone()
+		for _, name := range n.Grouping {
+			ls.exclude(name)
+		}
+	} else {
+		ls.IsExact = true
+		for _, name := range n.Grouping {
+			ls.addFrom(name, src)
+		}
+	}
+	if n.Op == promParser.COUNT_VALUES {
+		if s, ok := n.Param.(*promParser.StringLiteral); ok {
+			ls.add(s.Val, true)
+		}
+	}
+	return ls
+}
+
+func inferCall(n *promParser.Call) (ls LabelSet) {
+	switch n.Func.Name {
+	case "absent", "absent_over_time":
+		// absent() only returns labels from equality matchers of a selector.
+		ls.IsExact = true
+		if len(n.Args) == 0 {
+			return ls
+		}
+		var vs *promParser.VectorSelector
+		switch a := n.Args[0].(type) {
+		case *promParser.VectorSelector:
+			vs = a
+		case *promParser.MatrixSelector:
+			vs, _ = a.VectorSelector.(*promParser.VectorSelector)
+		}
+		if vs == nil {
+			return ls
+		}
+		for _, lm := range vs.LabelMatchers {
+			if lm.Name != labels.MetricName && lm.Type == labels.MatchEqual && lm.Value != "" {
+				ls.add(lm.Name, true)
+			}
+		}
+		return ls
+	case "label_replace", "label_join":
+		if len(n.Args) < 2 {
+			return ls
+		}
+		ls = inferLabels(n.Args[0]).clone()
+		if s, ok := n.Args[1].(*promParser.StringLiteral); ok {
+			ls.add(s.Val, false)
+		}
+		return ls
+	case "histogram_quantile":
+		if len(n.Args) < 2 {
+			return ls
+		}
+		ls = inferLabels(n.Args[1]).clone()
+		ls.exclude("le")
+		return ls
+	}
+
+	// Any other function either returns the labels of its vector argument
+	// or returns a scalar or vector without any labels, like vector(1).
+	for _, arg := range n.Args {
+		switch arg.Type() {
+		case promParser.ValueTypeVector, promParser.ValueTypeMatrix:
+			return inferLabels(arg)
+		}
+	}
+	return LabelSet{IsExact: true}
+}
+
+func inferBinaryExpr(n *promParser.BinaryExpr) (ls LabelSet) {
+	lhsScalar := n.LHS.Type() == promParser.ValueTypeScalar
+	rhsScalar := n.RHS.Type() == promParser.ValueTypeScalar
+	switch {
+	case lhsScalar && rhsScalar:
+		return LabelSet{IsExact: true}
+	case rhsScalar:
+		return inferLabels(n.LHS)
+	case lhsScalar:
+		return inferLabels(n.RHS)
+	}
+
+	lhs := inferLabels(n.LHS)
+	rhs := inferLabels(n.RHS)
+
+	if n.VectorMatching == nil {
+		return lhs
+	}
+
+	switch n.VectorMatching.Card {
+	case promParser.CardManyToMany:
+		if n.Op != promParser.LOR {
+			return lhs
+		}
+		ls.IsExact = lhs.IsExact && rhs.IsExact
+		for _, name := range lhs.Guaranteed {
+			ls.add(name, slices.Contains(rhs.Guaranteed, name))
+		}
+		for _, name := range append(slices.Clone(lhs.Possible), rhs.Names()...) {
+			if !slices.Contains(ls.Guaranteed, name) && !slices.Contains(ls.Possible, name) {
+				ls.add(name, false)
+			}
+		}
+		for _, name := range lhs.Excluded {
+			if slices.Contains(rhs.Excluded, name) || (rhs.IsExact && !rhs.CanHave(name)) {
+				ls.exclude(name)
+			}
+		}
+		for _, name := range rhs.Excluded {
+			if !slices.Contains(ls.Excluded, name) && lhs.IsExact && !lhs.CanHave(name) {
+				ls.exclude(name)
+			}
+		}
+		return ls
+	case promParser.CardOneToOne:
+		if n.VectorMatching.On {
+			ls.IsExact = true
+			for _, name := range n.VectorMatching.MatchingLabels {
+				ls.addFrom(name, lhs)
+			}
+			return ls
+		}
+		ls = lhs.clone()
+		for _, name := range n.VectorMatching.MatchingLabels {
+			ls.exclude(name)
+		}
+		return ls
+	case promParser.CardManyToOne:
+		ls = lhs.clone()
+		for _, name := range n.VectorMatching.Include {
+			ls.addFrom(name, rhs)
+		}
+		return ls
+	case promParser.CardOneToMany:
+		ls = rhs.clone()
+		for _, name := range n.VectorMatching.Include {
+			ls.addFrom(name, lhs)
+		}
+		return ls
+	}
+	return lhs
+}
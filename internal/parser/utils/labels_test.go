@@ -0,0 +1,166 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/parser/utils"
+)
+
+func TestInferLabels(t *testing.T) {
+	type testCaseT struct {
+		expr   string
+		output utils.LabelSet
+	}
+
+	testCases := []testCaseT{
+		{
+			expr: "foo",
+		},
+		{
+			expr:   `foo{job="bar", instance!="", env=~".*", cluster=""}`,
+			output: utils.LabelSet{Guaranteed: []string{"job", "instance"}, Excluded: []string{"cluster"}},
+		},
+		{
+			expr:   "1",
+			output: utils.LabelSet{IsExact: true},
+		},
+		{
+			expr:   "vector(1)",
+			output: utils.LabelSet{IsExact: true},
+		},
+		{
+			expr:   "sum(foo)",
+			output: utils.LabelSet{IsExact: true},
+		},
+		{
+			expr:   "sum(foo) by(job, instance)",
+			output: utils.LabelSet{Possible: []string{"job", "instance"}, IsExact: true},
+		},
+		{
+			expr:   `sum(foo{job="bar"}) by(job, instance)`,
+			output: utils.LabelSet{Guaranteed: []string{"job"}, Possible: []string{"instance"}, IsExact: true},
+		},
+		{
+			expr:   "sum(foo) without(job)",
+			output: utils.LabelSet{Excluded: []string{"job"}},
+		},
+		{
+			expr:   "sum(sum(foo) by(job, instance)) without(job)",
+			output: utils.LabelSet{Possible: []string{"instance"}, Excluded: []string{"job"}, IsExact: true},
+		},
+		{
+			expr:   "topk(5, sum(foo) by(job))",
+			output: utils.LabelSet{Possible: []string{"job"}, IsExact: true},
+		},
+		{
+			expr:   `count_values("value", sum(foo) by(job))`,
+			output: utils.LabelSet{Guaranteed: []string{"value"}, IsExact: true},
+		},
+		{
+			expr:   "rate(foo[5m])",
+			output: utils.LabelSet{},
+		},
+		{
+			expr:   "round(sum(rate(foo[5m])) by(job), 0.5)",
+			output: utils.LabelSet{Possible: []string{"job"}, IsExact: true},
+		},
+		{
+			expr:   "max_over_time(sum(foo) by(job)[5m:1m])",
+			output: utils.LabelSet{Possible: []string{"job"}, IsExact: true},
+		},
+		{
+			expr:   `absent(foo{job="bar", instance=~".+"})`,
+			output: utils.LabelSet{Guaranteed: []string{"job"}, IsExact: true},
+		},
+		{
+			expr:   `absent_over_time(foo{job="bar"}[5m])`,
+			output: utils.LabelSet{Guaranteed: []string{"job"}, IsExact: true},
+		},
+		{
+			expr:   "absent(sum(foo) by(job))",
+			output: utils.LabelSet{IsExact: true},
+		},
+		{
+			expr:   `label_replace(sum(foo) by(job), "instance", "$1", "job", "(.+)")`,
+			output: utils.LabelSet{Possible: []string{"job", "instance"}, IsExact: true},
+		},
+		{
+			expr:   `label_join(sum(foo) without(job), "job", ",", "instance")`,
+			output: utils.LabelSet{Possible: []string{"job"}},
+		},
+		{
+			expr:   "histogram_quantile(0.9, sum(rate(foo[5m])) by(le, job))",
+			output: utils.LabelSet{Possible: []string{"job"}, Excluded: []string{"le"}, IsExact: true},
+		},
+		{
+			expr:   "sum(foo) by(job) > 5",
+			output: utils.LabelSet{Possible: []string{"job"}, IsExact: true},
+		},
+		{
+			expr:   "5 < sum(foo) by(job)",
+			output: utils.LabelSet{Possible: []string{"job"}, IsExact: true},
+		},
+		{
+			expr:   "sum(foo) by(job) / sum(bar) by(job, instance)",
+			output: utils.LabelSet{Possible: []string{"job"}, IsExact: true},
+		},
+		{
+			expr:   `foo{job="a"} / on(job, instance) bar`,
+			output: utils.LabelSet{Guaranteed: []string{"job"}, Possible: []string{"instance"}, IsExact: true},
+		},
+		{
+			expr:   `foo{job="a"} / ignoring(job) bar`,
+			output: utils.LabelSet{Excluded: []string{"job"}},
+		},
+		{
+			expr:   `sum(foo) by(job) * on(job) group_left(instance) bar{instance="a"}`,
+			output: utils.LabelSet{Possible: []string{"job"}, Guaranteed: []string{"instance"}, IsExact: true},
+		},
+		{
+			expr:   `sum(foo) by(job) * on(job) group_left(instance) sum(bar) by(job)`,
+			output: utils.LabelSet{Possible: []string{"job"}, Excluded: []string{"instance"}, IsExact: true},
+		},
+		{
+			expr:   `sum(foo) by(job) * on(job) group_right(instance) bar`,
+			output: utils.LabelSet{Excluded: []string{"instance"}},
+		},
+		{
+			expr:   "sum(foo) by(job) and bar",
+			output: utils.LabelSet{Possible: []string{"job"}, IsExact: true},
+		},
+		{
+			expr:   "sum(foo) by(job) or sum(bar) by(instance)",
+			output: utils.LabelSet{Possible: []string{"job", "instance"}, IsExact: true},
+		},
+		{
+			expr:   `foo{job="a"} or bar{job="b", instance="c"}`,
+			output: utils.LabelSet{Guaranteed: []string{"job"}, Possible: []string{"instance"}},
+		},
+		{
+			expr:   "sum(foo) by(job) or vector(0)",
+			output: utils.LabelSet{Possible: []string{"job"}, IsExact: true},
+		},
+		{
+			expr:   "sum(foo) without(job) or sum(bar) without(job, instance)",
+			output: utils.LabelSet{Excluded: []string{"job"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			n, err := parser.DecodeExpr(tc.expr)
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+			output := utils.InferLabels(n)
+			require.ElementsMatch(t, tc.output.Guaranteed, output.Guaranteed, "InferLabels() returned wrong guaranteed labels")
+			require.ElementsMatch(t, tc.output.Possible, output.Possible, "InferLabels() returned wrong possible labels")
+			require.ElementsMatch(t, tc.output.Excluded, output.Excluded, "InferLabels() returned wrong excluded labels")
+			require.Equal(t, tc.output.IsExact, output.IsExact, "InferLabels() returned wrong IsExact value")
+		})
+	}
+}
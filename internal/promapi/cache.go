@@ -14,12 +14,16 @@ type cacheEntry struct {
 }
 
 type endpointStats struct {
-	hits   int
-	misses int
+	hits       int
+	misses     int
+	diskHits   int
+	diskMisses int
 }
 
-func (e *endpointStats) hit()  { e.hits++ }
-func (e *endpointStats) miss() { e.misses++ }
+func (e *endpointStats) hit()      { e.hits++ }
+func (e *endpointStats) miss()     { e.misses++ }
+func (e *endpointStats) diskHit()  { e.diskHits++ }
+func (e *endpointStats) diskMiss() { e.diskMisses++ }
 
 func newQueryCache(maxStale time.Duration) *queryCache {
 	return &queryCache{
@@ -65,6 +69,18 @@ func (c *queryCache) get(key uint64, endpoint string) (v any, ok bool) {
 	return ce.data, true
 }
 
+// recordDisk updates on-disk query cache stats for given endpoint.
+func (c *queryCache) recordDisk(endpoint string, hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if hit {
+		c.endpointStats(endpoint).diskHit()
+	} else {
+		c.endpointStats(endpoint).diskMiss()
+	}
+}
+
 // Cache results if it was requested at least twice EVER - which means it's either
 // popular and requested multiple times within a loop OR this cache key survives between loops.
 func (c *queryCache) set(key uint64, val any, ttl time.Duration) {
@@ -98,16 +114,20 @@ func (c *queryCache) gc() {
 }
 
 type cacheCollector struct {
-	cache     *queryCache
-	entries   *prometheus.Desc
-	hits      *prometheus.Desc
-	misses    *prometheus.Desc
-	evictions *prometheus.Desc
+	cache      *queryCache
+	entries    *prometheus.Desc
+	hits       *prometheus.Desc
+	misses     *prometheus.Desc
+	diskHits   *prometheus.Desc
+	diskMisses *prometheus.Desc
+	evictions  *prometheus.Desc
+	withDisk   bool
 }
 
-func newCacheCollector(cache *queryCache, name string) *cacheCollector {
+func newCacheCollector(cache *queryCache, name string, withDisk bool) *cacheCollector {
 	return &cacheCollector{
-		cache: cache,
+		cache:    cache,
+		withDisk: withDisk,
 		entries: prometheus.NewDesc(
 			"pint_prometheus_cache_size",
 			"Total number of entries currently stored in Prometheus query cache",
@@ -126,6 +146,18 @@ func newCacheCollector(cache *queryCache, name string) *cacheCollector {
 			[]string{"endpoint"},
 			prometheus.Labels{"name": name},
 		),
+		diskHits: prometheus.NewDesc(
+			"pint_prometheus_disk_cache_hits_total",
+			"Total number of on-disk query cache hits",
+			[]string{"endpoint"},
+			prometheus.Labels{"name": name},
+		),
+		diskMisses: prometheus.NewDesc(
+			"pint_prometheus_disk_cache_miss_total",
+			"Total number of on-disk query cache misses",
+			[]string{"endpoint"},
+			prometheus.Labels{"name": name},
+		),
 		evictions: prometheus.NewDesc(
 			"pint_prometheus_cache_evictions_total",
 			"Total number of times an entry was evicted from query cache due to size limit or TTL",
@@ -139,6 +171,10 @@ func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.entries
 	ch <- c.hits
 	ch <- c.misses
+	if c.withDisk {
+		ch <- c.diskHits
+		ch <- c.diskMisses
+	}
 	ch <- c.evictions
 }
 
@@ -150,6 +186,10 @@ func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
 	for endpoint, stats := range c.cache.stats {
 		ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.hits), endpoint)
 		ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.misses), endpoint)
+		if c.withDisk {
+			ch <- prometheus.MustNewConstMetric(c.diskHits, prometheus.CounterValue, float64(stats.diskHits), endpoint)
+			ch <- prometheus.MustNewConstMetric(c.diskMisses, prometheus.CounterValue, float64(stats.diskMisses), endpoint)
+		}
 	}
 	ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(c.cache.evictions))
 }
@@ -160,7 +160,7 @@ func TestCacheCollector(t *testing.T) {
 		"pint_prometheus_cache_evictions_total",
 	}
 
-	collector := newCacheCollector(cache, "prom")
+	collector := newCacheCollector(cache, "prom", false)
 	require.NoError(t, testutil.CollectAndCompare(
 		collector, strings.NewReader(`
 # HELP pint_prometheus_cache_evictions_total Total number of times an entry was evicted from query cache due to size limit or TTL
@@ -137,7 +137,11 @@ func streamConfig(r io.Reader) (cfg PrometheusConfig, err error) {
 		return cfg, APIError{Status: status, ErrorType: decodeErrorType(errType), Err: errText}
 	}
 
-	if err = yaml.Unmarshal([]byte(yamlBody), &cfg); err != nil {
+	return parseConfig([]byte(yamlBody))
+}
+
+func parseConfig(body []byte) (cfg PrometheusConfig, err error) {
+	if err = yaml.Unmarshal(body, &cfg); err != nil {
 		return cfg, err
 	}
 
@@ -0,0 +1,181 @@
+package promapi
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/rs/zerolog/log"
+)
+
+const diskCacheTempPrefix = ".tmp-"
+
+type diskCacheHeader struct {
+	Endpoint  string
+	ExpiresAt time.Time
+}
+
+// DiskCache stores query results in files, so they can be reused by
+// multiple pint processes. Each entry is written to a temporary file first
+// and then renamed, so readers will never see partially written entries.
+type DiskCache struct {
+	dir    string
+	maxAge time.Duration
+	once   sync.Once
+}
+
+func NewDiskCache(dir string, maxAge time.Duration) *DiskCache {
+	return &DiskCache{dir: dir, maxAge: maxAge}
+}
+
+// start creates the cache directory and removes all expired entries.
+// It's safe to call it multiple times, only the first call does anything.
+func (dc *DiskCache) start() {
+	dc.once.Do(func() {
+		if err := os.MkdirAll(dc.dir, 0o755); err != nil {
+			log.Error().Err(err).Str("dir", dc.dir).Msg("Failed to create query cache directory")
+			return
+		}
+		dc.gc()
+	})
+}
+
+func (dc *DiskCache) path(key uint64) string {
+	return filepath.Join(dc.dir, fmt.Sprintf("%016x", key))
+}
+
+func (dc *DiskCache) get(key uint64, endpoint string) (qr queryResult, ok bool) {
+	path := dc.path(key)
+	f, err := os.Open(path)
+	if err != nil {
+		return qr, false
+	}
+	defer f.Close()
+
+	dec := gob.NewDecoder(f)
+	var hdr diskCacheHeader
+	if err = dec.Decode(&hdr); err != nil || hdr.Endpoint != endpoint {
+		return qr, false
+	}
+	if hdr.ExpiresAt.Before(time.Now()) {
+		_ = os.Remove(path)
+		return qr, false
+	}
+	if err = dec.Decode(&qr.stats); err != nil {
+		return qr, false
+	}
+	if qr.value, err = decodeCacheValue(dec, endpoint); err != nil {
+		log.Debug().Err(err).Str("path", path).Msg("Failed to decode query cache entry")
+		return qr, false
+	}
+	return qr, true
+}
+
+func (dc *DiskCache) set(key uint64, endpoint string, qr queryResult, ttl time.Duration) {
+	if dc.maxAge > 0 && (ttl <= 0 || ttl > dc.maxAge) {
+		ttl = dc.maxAge
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	if err := dc.write(dc.path(key), diskCacheHeader{Endpoint: endpoint, ExpiresAt: time.Now().Add(ttl)}, qr); err != nil {
+		log.Warn().Err(err).Str("dir", dc.dir).Str("endpoint", endpoint).Msg("Failed to write query cache entry")
+	}
+}
+
+func (dc *DiskCache) write(path string, hdr diskCacheHeader, qr queryResult) error {
+	f, err := os.CreateTemp(dc.dir, diskCacheTempPrefix)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	enc := gob.NewEncoder(f)
+	for _, v := range []any{hdr, qr.stats, qr.value} {
+		if err = enc.Encode(v); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (dc *DiskCache) gc() {
+	entries, err := os.ReadDir(dc.dir)
+	if err != nil {
+		log.Error().Err(err).Str("dir", dc.dir).Msg("Failed to list query cache directory")
+		return
+	}
+
+	now := time.Now()
+	var removed int
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		path := filepath.Join(dc.dir, e.Name())
+		if strings.HasPrefix(e.Name(), diskCacheTempPrefix) {
+			// Leftovers from pint processes that were killed while writing.
+			if info, err := e.Info(); err == nil && now.Sub(info.ModTime()) > time.Hour {
+				_ = os.Remove(path)
+				removed++
+			}
+			continue
+		}
+		if !isDiskCacheEntryValid(path, now) {
+			_ = os.Remove(path)
+			removed++
+		}
+	}
+	log.Debug().Str("dir", dc.dir).Int("entries", len(entries)-removed).Int("removed", removed).Msg("Query cache cleanup completed")
+}
+
+func isDiskCacheEntryValid(path string, now time.Time) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	var hdr diskCacheHeader
+	if err = gob.NewDecoder(f).Decode(&hdr); err != nil {
+		return false
+	}
+	return hdr.ExpiresAt.After(now)
+}
+
+func decodeCacheValue(dec *gob.Decoder, endpoint string) (any, error) {
+	switch endpoint {
+	case instantQuery{}.Endpoint():
+		var v []Sample
+		err := dec.Decode(&v)
+		return v, err
+	case rangeQuery{}.Endpoint():
+		var v MetricTimeRanges
+		err := dec.Decode(&v)
+		return v, err
+	case metadataQuery{}.Endpoint():
+		var v map[string][]v1.Metadata
+		err := dec.Decode(&v)
+		return v, err
+	case configQuery{}.Endpoint():
+		var v PrometheusConfig
+		err := dec.Decode(&v)
+		return v, err
+	case flagsQuery{}.Endpoint():
+		var v v1.FlagsResult
+		err := dec.Decode(&v)
+		return v, err
+	default:
+		return nil, fmt.Errorf("unsupported endpoint: %s", endpoint)
+	}
+}
@@ -0,0 +1,262 @@
+package promapi
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskCacheGetAndSet(t *testing.T) {
+	type testCaseT struct {
+		endpoint string
+		value    any
+	}
+
+	now := time.Now().Round(time.Second)
+	testCases := []testCaseT{
+		{
+			endpoint: "/api/v1/query",
+			value: []Sample{
+				{Labels: labels.FromStrings("job", "foo"), Value: 1},
+				{Labels: labels.FromStrings("job", "bar"), Value: math.NaN()},
+			},
+		},
+		{
+			endpoint: "/api/v1/query",
+			value:    []Sample{},
+		},
+		{
+			endpoint: "/api/v1/query_range",
+			value: MetricTimeRanges{
+				{Fingerprint: 1, Labels: labels.FromStrings("job", "foo"), Start: now.Add(time.Hour * -1), End: now},
+			},
+		},
+		{
+			endpoint: "/api/v1/metadata",
+			value:    map[string][]v1.Metadata{"foo": {{Type: "counter", Help: "Text"}}},
+		},
+		{
+			endpoint: "/api/v1/status/config",
+			value:    PrometheusConfig{Global: ConfigSectionGlobal{ScrapeInterval: time.Minute, ExternalLabels: map[string]string{"a": "b"}}},
+		},
+		{
+			endpoint: "/api/v1/status/flags",
+			value:    v1.FlagsResult{"storage.tsdb.retention.time": "1d"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.endpoint, func(t *testing.T) {
+			dc := NewDiskCache(t.TempDir(), 0)
+			dc.start()
+
+			_, ok := dc.get(1, tc.endpoint)
+			require.False(t, ok)
+
+			stats := QueryStats{Samples: QuerySamples{TotalQueryableSamples: 5, PeakSamples: 2}}
+			dc.set(1, tc.endpoint, queryResult{value: tc.value, stats: stats}, time.Minute)
+
+			qr, ok := dc.get(1, tc.endpoint)
+			require.True(t, ok)
+			require.Equal(t, stats, qr.stats)
+			require.NoError(t, qr.err)
+			if samples, isSamples := tc.value.([]Sample); isSamples {
+				got := qr.value.([]Sample)
+				require.Len(t, got, len(samples))
+				for i := range samples {
+					require.Equal(t, samples[i].Labels, got[i].Labels)
+					if math.IsNaN(samples[i].Value) {
+						require.True(t, math.IsNaN(got[i].Value))
+					} else {
+						require.Equal(t, samples[i].Value, got[i].Value)
+					}
+				}
+			} else {
+				require.Equal(t, tc.value, qr.value)
+			}
+
+			_, ok = dc.get(1, "/api/v1/other")
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestDiskCacheExpiry(t *testing.T) {
+	dc := NewDiskCache(t.TempDir(), time.Millisecond*100)
+	dc.start()
+
+	dc.set(1, "/api/v1/query", queryResult{value: []Sample{}}, time.Hour)
+	dc.set(2, "/api/v1/query", queryResult{value: []Sample{}}, time.Millisecond)
+	dc.set(3, "/api/v1/query", queryResult{value: []Sample{}}, 0)
+
+	_, ok := dc.get(1, "/api/v1/query")
+	require.True(t, ok, "maxAge should cap TTL, but entry should still be valid")
+
+	time.Sleep(time.Millisecond * 150)
+
+	for _, key := range []uint64{1, 2} {
+		_, ok = dc.get(key, "/api/v1/query")
+		require.False(t, ok)
+		require.NoFileExists(t, dc.path(key))
+	}
+	_, ok = dc.get(3, "/api/v1/query")
+	require.False(t, ok)
+}
+
+func TestDiskCacheNoTTL(t *testing.T) {
+	dc := NewDiskCache(t.TempDir(), 0)
+	dc.start()
+
+	dc.set(1, "/api/v1/query", queryResult{value: []Sample{}}, 0)
+	require.NoFileExists(t, dc.path(1))
+}
+
+func TestDiskCacheGC(t *testing.T) {
+	dir := t.TempDir()
+
+	dc := NewDiskCache(dir, 0)
+	dc.start()
+	dc.set(1, "/api/v1/query", queryResult{value: []Sample{}}, time.Hour)
+	dc.set(2, "/api/v1/query", queryResult{value: []Sample{}}, time.Millisecond)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken"), []byte("foo"), 0o644))
+	for name, age := range map[string]time.Duration{diskCacheTempPrefix + "old": time.Hour * 2, diskCacheTempPrefix + "new": 0} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, nil, 0o644))
+		mtime := time.Now().Add(age * -1)
+		require.NoError(t, os.Chtimes(path, mtime, mtime))
+	}
+	time.Sleep(time.Millisecond * 10)
+
+	NewDiskCache(dir, 0).start()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	require.ElementsMatch(t, []string{filepath.Base(dc.path(1)), diskCacheTempPrefix + "new"}, names)
+}
+
+func TestDiskCacheConcurrentWrites(t *testing.T) {
+	dir := t.TempDir()
+	value := []Sample{{Labels: labels.FromStrings("job", strings.Repeat("x", 4096)), Value: 1}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dc := NewDiskCache(dir, 0)
+			dc.start()
+			for j := 0; j < 20; j++ {
+				dc.set(1, "/api/v1/query", queryResult{value: value}, time.Minute)
+				if qr, ok := dc.get(1, "/api/v1/query"); ok {
+					require.Equal(t, value, qr.value)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestDiskCacheSharedBetweenGroups(t *testing.T) {
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(200)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"job":"foo"},"value":[1614859502.068,"1"]}]}}`))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	for i := 0; i < 2; i++ {
+		fg := NewFailoverGroup("test", []*Prometheus{
+			NewPrometheus("test", srv.URL, nil, time.Second, 1, 100, nil),
+		}, true, "up", nil, nil, nil)
+		fg.SetDiskCache(NewDiskCache(dir, time.Hour))
+		fg.StartWorkers()
+
+		qr, err := fg.Query(context.Background(), "up")
+		require.NoError(t, err)
+		require.Equal(t, []Sample{{Labels: labels.FromStrings("job", "foo"), Value: 1}}, qr.Series)
+
+		if i == 0 {
+			require.NoError(t, testutil.CollectAndCompare(fg.cacheCollector, strings.NewReader(`
+# HELP pint_prometheus_disk_cache_hits_total Total number of on-disk query cache hits
+# TYPE pint_prometheus_disk_cache_hits_total counter
+pint_prometheus_disk_cache_hits_total{endpoint="/api/v1/query",name="test"} 0
+# HELP pint_prometheus_disk_cache_miss_total Total number of on-disk query cache misses
+# TYPE pint_prometheus_disk_cache_miss_total counter
+pint_prometheus_disk_cache_miss_total{endpoint="/api/v1/query",name="test"} 1
+`), "pint_prometheus_disk_cache_hits_total", "pint_prometheus_disk_cache_miss_total"))
+		} else {
+			require.NoError(t, testutil.CollectAndCompare(fg.cacheCollector, strings.NewReader(`
+# HELP pint_prometheus_disk_cache_hits_total Total number of on-disk query cache hits
+# TYPE pint_prometheus_disk_cache_hits_total counter
+pint_prometheus_disk_cache_hits_total{endpoint="/api/v1/query",name="test"} 1
+# HELP pint_prometheus_disk_cache_miss_total Total number of on-disk query cache misses
+# TYPE pint_prometheus_disk_cache_miss_total counter
+pint_prometheus_disk_cache_miss_total{endpoint="/api/v1/query",name="test"} 0
+`), "pint_prometheus_disk_cache_hits_total", "pint_prometheus_disk_cache_miss_total"))
+		}
+		fg.Close()
+	}
+	require.Equal(t, int64(1), requests.Load())
+}
+
+func TestDiskCacheRelativeRange(t *testing.T) {
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(200)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`))
+	}))
+	defer srv.Close()
+
+	params := NewRelativeRange(time.Hour, time.Minute*10)
+	end := params.End()
+
+	dir := t.TempDir()
+	for i := 0; i < 2; i++ {
+		if i > 0 {
+			// Make sure that the second run is using a different time.Now().
+			time.Sleep(time.Second * 2)
+		}
+
+		fg := NewFailoverGroup("test", []*Prometheus{
+			NewPrometheus("test", srv.URL, nil, time.Second, 1, 100, nil),
+		}, true, "up", nil, nil, nil)
+		fg.SetDiskCache(NewDiskCache(dir, time.Hour))
+		fg.StartWorkers()
+
+		_, err := fg.RangeQuery(context.Background(), "up", params)
+		require.NoError(t, err)
+		fg.Close()
+	}
+
+	if !params.End().Equal(end) {
+		t.Skip("test crossed query step boundary")
+	}
+	require.Equal(t, int64(1), requests.Load(), "second run should read range query results from the disk cache")
+}
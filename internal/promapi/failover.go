@@ -48,6 +48,7 @@ type FailoverGroup struct {
 	strictErrors   bool
 	uptimeMetric   string
 	cacheCollector *cacheCollector
+	diskCache      *DiskCache
 	quitChan       chan bool
 
 	pathsInclude []*regexp.Regexp
@@ -96,15 +97,27 @@ func (fg *FailoverGroup) IsEnabledForPath(path string) bool {
 	return false
 }
 
+// SetDiskCache enables on-disk query cache for all servers in this group,
+// except for servers using fixture files.
+func (fg *FailoverGroup) SetDiskCache(dc *DiskCache) {
+	fg.diskCache = dc
+}
+
 func (fg *FailoverGroup) StartWorkers() {
 	queryCache := newQueryCache(time.Hour)
 	fg.quitChan = make(chan bool)
 	go cacheCleaner(queryCache, time.Minute*2, fg.quitChan)
 
-	fg.cacheCollector = newCacheCollector(queryCache, fg.name)
+	fg.cacheCollector = newCacheCollector(queryCache, fg.name, fg.diskCache != nil)
 	prometheus.MustRegister(fg.cacheCollector)
+	if fg.diskCache != nil {
+		fg.diskCache.start()
+	}
 	for _, prom := range fg.servers {
 		prom.cache = queryCache
+		if prom.fixture == nil {
+			prom.diskCache = fg.diskCache
+		}
 		prom.StartWorkers()
 	}
 }
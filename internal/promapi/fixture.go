@@ -0,0 +1,396 @@
+package promapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/textparse"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/util/stats"
+	"github.com/rs/zerolog/log"
+)
+
+// fixtureConfigFile is the name of the file inside a fixture directory
+// that will be used as the Prometheus configuration file.
+const fixtureConfigFile = "prometheus.yml"
+
+type fixtureSample struct {
+	path  string
+	lset  labels.Labels
+	t     int64
+	value float64
+}
+
+// fixture answers queries using samples from text exposition or OpenMetrics
+// files, all files are loaded on the first query.
+// Samples with explicit timestamps are stored in an in-process TSDB.
+// Samples without timestamps are treated as series that always had the same
+// value and will be present at every scrape interval of any queried time range.
+type fixture struct {
+	path    string
+	timeout time.Duration
+
+	once     sync.Once
+	err      error
+	dir      string
+	db       *tsdb.DB
+	static   []staticSeries
+	engine   *promql.Engine
+	metadata map[string][]v1.Metadata
+	config   PrometheusConfig
+}
+
+func newFixture(path string, timeout time.Duration) *fixture {
+	return &fixture{path: path, timeout: timeout}
+}
+
+func (f *fixture) run(q querier) (qr queryResult) {
+	f.once.Do(func() {
+		if f.err = f.load(); f.err != nil {
+			f.err = fmt.Errorf("failed to load fixture data from %s: %w", f.path, f.err)
+		}
+	})
+	if f.err != nil {
+		qr.err = f.err
+		return qr
+	}
+
+	switch q := q.(type) {
+	case instantQuery:
+		qr.value, qr.stats, qr.err = f.instantQuery(q.ctx, q.expr, q.timestamp)
+	case rangeQuery:
+		qr.value, qr.stats, qr.err = f.rangeQuery(q.ctx, q.expr, q.r)
+	case metadataQuery:
+		qr.value = map[string][]v1.Metadata{q.metric: f.metadata[q.metric]}
+	case configQuery:
+		qr.value = f.config
+	case flagsQuery:
+		qr.value = v1.FlagsResult{}
+	default:
+		qr.err = fmt.Errorf("%s endpoint is not supported by fixture data", q.Endpoint())
+	}
+	return qr
+}
+
+func (f *fixture) instantQuery(ctx context.Context, expr string, ts time.Time) ([]Sample, QueryStats, error) {
+	log.Debug().Str("path", f.path).Str("query", expr).Msg("Running fixture query")
+
+	qry, err := f.engine.NewInstantQuery(ctx, f, nil, expr, ts)
+	if err != nil {
+		return nil, QueryStats{}, APIError{Status: "error", ErrorType: v1.ErrBadData, Err: err.Error()}
+	}
+	defer qry.Close()
+
+	res := qry.Exec(ctx)
+	if res.Err != nil {
+		return nil, QueryStats{}, fixtureError(ctx, res.Err)
+	}
+
+	vector, ok := res.Value.(promql.Vector)
+	if !ok {
+		return nil, QueryStats{}, APIError{Status: "success", ErrorType: v1.ErrBadResponse, Err: fmt.Sprintf("invalid result type, expected vector, got %s", res.Value.Type())}
+	}
+
+	samples := make([]Sample, 0, len(vector))
+	for _, s := range vector {
+		samples = append(samples, Sample{Labels: s.Metric, Value: s.F})
+	}
+	return samples, fixtureStats(qry.Stats()), nil
+}
+
+func (f *fixture) rangeQuery(ctx context.Context, expr string, r v1.Range) (MetricTimeRanges, QueryStats, error) {
+	log.Debug().Str("path", f.path).Str("query", expr).Msg("Running fixture range query")
+
+	qry, err := f.engine.NewRangeQuery(ctx, f, nil, expr, r.Start, r.End, r.Step)
+	if err != nil {
+		return nil, QueryStats{}, APIError{Status: "error", ErrorType: v1.ErrBadData, Err: err.Error()}
+	}
+	defer qry.Close()
+
+	res := qry.Exec(ctx)
+	if res.Err != nil {
+		return nil, QueryStats{}, fixtureError(ctx, res.Err)
+	}
+
+	matrix, ok := res.Value.(promql.Matrix)
+	if !ok {
+		return nil, QueryStats{}, APIError{Status: "success", ErrorType: v1.ErrBadResponse, Err: fmt.Sprintf("invalid result type, expected matrix, got %s", res.Value.Type())}
+	}
+
+	var ranges MetricTimeRanges
+	for _, s := range matrix {
+		values := make([]model.SamplePair, 0, len(s.Floats))
+		for _, p := range s.Floats {
+			values = append(values, model.SamplePair{Timestamp: model.Time(p.T), Value: model.SampleValue(p.F)})
+		}
+		ranges = AppendSampleToRanges(ranges, s.Metric, values, r.Step)
+	}
+	ExpandRangesEnd(ranges, r.Step)
+	return ranges, fixtureStats(qry.Stats()), nil
+}
+
+func (f *fixture) load() (err error) {
+	files, err := fixtureFiles(f.path)
+	if err != nil {
+		return err
+	}
+
+	f.config, _ = parseConfig(nil)
+	f.metadata = map[string][]v1.Metadata{}
+	var samples []fixtureSample
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if filepath.Base(path) == fixtureConfigFile {
+			if f.config, err = parseConfig(data); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+			continue
+		}
+
+		s, err := f.parseFile(path, data)
+		if err != nil {
+			return err
+		}
+		samples = append(samples, s...)
+	}
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].t < samples[j].t
+	})
+	sort.Slice(f.static, func(i, j int) bool {
+		return labels.Compare(f.static[i].lset, f.static[j].lset) < 0
+	})
+
+	if f.dir, err = os.MkdirTemp("", "pint-fixture-"); err != nil {
+		return err
+	}
+	if f.db, err = tsdb.Open(f.dir, nil, nil, tsdb.DefaultOptions(), nil); err != nil {
+		return err
+	}
+	f.db.DisableCompactions()
+
+	app := f.db.Appender(context.Background())
+	seen := map[uint64]fixtureSample{}
+	for i, s := range samples {
+		if i > 0 && samples[i-1].t != s.t {
+			seen = map[uint64]fixtureSample{}
+		}
+		h := s.lset.Hash()
+		if prev, ok := seen[h]; ok {
+			if prev.value != s.value {
+				_ = app.Rollback()
+				return fmt.Errorf("%s sample from %s has a different value than the sample from %s with the same timestamp", s.lset, s.path, prev.path)
+			}
+			continue
+		}
+		seen[h] = s
+
+		if _, err = app.Append(0, s.lset, s.t, s.value); err != nil {
+			_ = app.Rollback()
+			return fmt.Errorf("failed to append %s sample from %s: %w", s.lset, s.path, err)
+		}
+	}
+	if err = app.Commit(); err != nil {
+		return err
+	}
+
+	f.engine = promql.NewEngine(promql.EngineOpts{
+		MaxSamples:           50000000,
+		Timeout:              f.timeout,
+		EnableAtModifier:     true,
+		EnableNegativeOffset: true,
+		NoStepSubqueryIntervalFn: func(int64) int64 {
+			return f.config.Global.EvaluationInterval.Milliseconds()
+		},
+	})
+
+	log.Debug().
+		Str("path", f.path).
+		Int("files", len(files)).
+		Int("samples", len(samples)).
+		Int("static", len(f.static)).
+		Msg("Loaded fixture data")
+
+	return nil
+}
+
+func (f *fixture) parseFile(path string, data []byte) (samples []fixtureSample, err error) {
+	var p textparse.Parser
+	if filepath.Ext(path) == ".om" || strings.HasSuffix(strings.TrimSpace(string(data)), "# EOF") {
+		p = textparse.NewOpenMetricsParser(data)
+	} else {
+		p = textparse.NewPromParser(data)
+	}
+
+	metadata := map[string]*v1.Metadata{}
+	getMetadata := func(name []byte) *v1.Metadata {
+		m, ok := metadata[string(name)]
+		if !ok {
+			m = &v1.Metadata{}
+			metadata[string(name)] = m
+		}
+		return m
+	}
+
+	for {
+		var entry textparse.Entry
+		entry, err = p.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		switch entry {
+		case textparse.EntryType:
+			name, typ := p.Type()
+			getMetadata(name).Type = v1.MetricType(typ)
+		case textparse.EntryHelp:
+			name, help := p.Help()
+			getMetadata(name).Help = string(help)
+		case textparse.EntryUnit:
+			name, unit := p.Unit()
+			getMetadata(name).Unit = string(unit)
+		case textparse.EntrySeries:
+			_, ts, value := p.Series()
+			var lset labels.Labels
+			p.Metric(&lset)
+			if ts != nil {
+				samples = append(samples, fixtureSample{path: path, lset: lset, t: *ts, value: value})
+				continue
+			}
+			if err = f.addStatic(path, lset, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for name, m := range metadata {
+		if !containsMetadata(f.metadata[name], *m) {
+			f.metadata[name] = append(f.metadata[name], *m)
+		}
+	}
+
+	return samples, nil
+}
+
+func (f *fixture) addStatic(path string, lset labels.Labels, value float64) error {
+	for _, s := range f.static {
+		if labels.Equal(s.lset, lset) {
+			if s.value != value {
+				return fmt.Errorf("%s sample from %s has a different value than another sample without a timestamp", lset, path)
+			}
+			return nil
+		}
+	}
+	f.static = append(f.static, staticSeries{lset: lset, value: value})
+	return nil
+}
+
+// Querier implements storage.Queryable.
+func (f *fixture) Querier(ctx context.Context, mint, maxt int64) (storage.Querier, error) {
+	q, err := f.db.Querier(ctx, mint, maxt)
+	if err != nil {
+		return nil, err
+	}
+	return storage.NewMergeQuerier(
+		[]storage.Querier{
+			q,
+			staticQuerier{
+				series:   f.static,
+				mint:     mint,
+				maxt:     maxt,
+				interval: f.config.Global.ScrapeInterval.Milliseconds(),
+			},
+		},
+		nil,
+		storage.ChainedSeriesMerge,
+	), nil
+}
+
+func (f *fixture) close() {
+	if f.db != nil {
+		if err := f.db.Close(); err != nil {
+			log.Error().Err(err).Str("path", f.path).Msg("Failed to close fixture TSDB")
+		}
+	}
+	if f.dir != "" {
+		_ = os.RemoveAll(f.dir)
+	}
+}
+
+func fixtureFiles(path string) ([]string, error) {
+	s, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !s.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		files = append(files, filepath.Join(path, e.Name()))
+	}
+	return files, nil
+}
+
+func containsMetadata(src []v1.Metadata, m v1.Metadata) bool {
+	for _, s := range src {
+		if s == m {
+			return true
+		}
+	}
+	return false
+}
+
+func fixtureStats(s *stats.Statistics) (qs QueryStats) {
+	b := stats.NewQueryStats(s).Builtin()
+	qs.Timings.EvalTotalTime = b.Timings.EvalTotalTime
+	qs.Timings.ResultSortTime = b.Timings.ResultSortTime
+	qs.Timings.QueryPreparationTime = b.Timings.QueryPreparationTime
+	qs.Timings.InnerEvalTime = b.Timings.InnerEvalTime
+	qs.Timings.ExecQueueTime = b.Timings.ExecQueueTime
+	qs.Timings.ExecTotalTime = b.Timings.ExecTotalTime
+	if b.Samples != nil {
+		qs.Samples.TotalQueryableSamples = int(b.Samples.TotalQueryableSamples)
+		qs.Samples.PeakSamples = b.Samples.PeakSamples
+	}
+	return qs
+}
+
+func fixtureError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var errTimeout promql.ErrQueryTimeout
+	if errors.As(err, &errTimeout) {
+		return APIError{Status: "error", ErrorType: v1.ErrTimeout, Err: err.Error()}
+	}
+	return APIError{Status: "error", ErrorType: v1.ErrExec, Err: err.Error()}
+}
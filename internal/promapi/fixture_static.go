@@ -0,0 +1,145 @@
+package promapi
+
+import (
+	"sort"
+
+	"github.com/prometheus/prometheus/model/histogram"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb/chunkenc"
+	"github.com/prometheus/prometheus/tsdb/tsdbutil"
+	"golang.org/x/exp/slices"
+)
+
+// staticSeries is a time series loaded from a fixture file sample without
+// a timestamp. It's treated as a series that always had the same value.
+type staticSeries struct {
+	lset  labels.Labels
+	value float64
+}
+
+func (s staticSeries) matches(matchers []*labels.Matcher) bool {
+	for _, m := range matchers {
+		if !m.Matches(s.lset.Get(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// staticQuerier returns samples for all static series, one sample for every
+// multiple of interval within the queried time range.
+type staticQuerier struct {
+	series   []staticSeries
+	mint     int64
+	maxt     int64
+	interval int64
+}
+
+func (q staticQuerier) Select(_ bool, hints *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	mint, maxt := q.mint, q.maxt
+	if hints != nil {
+		mint, maxt = hints.Start, hints.End
+	}
+
+	set := staticSeriesSet{pos: -1}
+	for _, s := range q.series {
+		if !s.matches(matchers) {
+			continue
+		}
+		var samples []tsdbutil.Sample
+		for t := alignUp(mint, q.interval); t <= maxt; t += q.interval {
+			samples = append(samples, staticSample{t: t, v: s.value})
+		}
+		set.series = append(set.series, storage.NewListSeries(s.lset, samples))
+	}
+	return &set
+}
+
+func (q staticQuerier) LabelValues(name string, matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
+	var values []string
+	for _, s := range q.series {
+		if v := s.lset.Get(name); v != "" && s.matches(matchers) && !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	return values, nil, nil
+}
+
+func (q staticQuerier) LabelNames(matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
+	var names []string
+	for _, s := range q.series {
+		if !s.matches(matchers) {
+			continue
+		}
+		s.lset.Range(func(l labels.Label) {
+			if !slices.Contains(names, l.Name) {
+				names = append(names, l.Name)
+			}
+		})
+	}
+	sort.Strings(names)
+	return names, nil, nil
+}
+
+func (q staticQuerier) Close() error {
+	return nil
+}
+
+type staticSeriesSet struct {
+	series []storage.Series
+	pos    int
+}
+
+func (ss *staticSeriesSet) Next() bool {
+	ss.pos++
+	return ss.pos < len(ss.series)
+}
+
+func (ss *staticSeriesSet) At() storage.Series {
+	return ss.series[ss.pos]
+}
+
+func (ss *staticSeriesSet) Err() error {
+	return nil
+}
+
+func (ss *staticSeriesSet) Warnings() storage.Warnings {
+	return nil
+}
+
+type staticSample struct {
+	t int64
+	v float64
+}
+
+func (s staticSample) T() int64 {
+	return s.t
+}
+
+func (s staticSample) F() float64 {
+	return s.v
+}
+
+func (s staticSample) H() *histogram.Histogram {
+	return nil
+}
+
+func (s staticSample) FH() *histogram.FloatHistogram {
+	return nil
+}
+
+func (s staticSample) Type() chunkenc.ValueType {
+	return chunkenc.ValFloat
+}
+
+func alignUp(t, interval int64) int64 {
+	if r := t % interval; r != 0 {
+		if t < 0 {
+			return t - r
+		}
+		return t + interval - r
+	}
+	return t
+}
@@ -0,0 +1,165 @@
+package promapi_test
+
+import (
+	"context"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/promapi"
+)
+
+func newFixtureGroup(t *testing.T, files map[string]string) (*promapi.FailoverGroup, string) {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(path.Join(dir, name), []byte(content), 0o644))
+	}
+
+	fg := promapi.NewFailoverGroup("test", []*promapi.Prometheus{
+		promapi.NewFixturePrometheus("test", dir, time.Second*5, 1),
+	}, true, "up", nil, nil, nil)
+	fg.StartWorkers()
+	t.Cleanup(fg.Close)
+	return fg, dir
+}
+
+func TestFixture(t *testing.T) {
+	now := time.Now()
+	fg, _ := newFixtureGroup(t, map[string]string{
+		"node.prom": `# HELP up Target health.
+# TYPE up gauge
+up{job="node", instance="a"} 1
+up{job="node", instance="b"} 0
+`,
+		"app.om": `# HELP http_requests Total requests.
+# TYPE http_requests counter
+http_requests_total{job="app",code="200"} 100
+http_requests_total{job="app",code="500"} 5
+# EOF
+`,
+		"history.prom": `old{job="history"} 1 ` + formatMillis(now.Add(time.Hour*-2)) + `
+old{job="history"} 2 ` + formatMillis(now.Add(time.Hour*-1)) + `
+`,
+		"prometheus.yml": "global:\n  scrape_interval: 30s\n",
+	})
+
+	t.Run("query", func(t *testing.T) {
+		qr, err := fg.Query(context.Background(), `sum(http_requests_total) by(job)`)
+		require.NoError(t, err)
+		require.Equal(t, []promapi.Sample{
+			{Labels: labels.FromStrings("job", "app"), Value: 105},
+		}, qr.Series)
+		require.Equal(t, 2, qr.Stats.Samples.TotalQueryableSamples)
+	})
+
+	t.Run("query with no results", func(t *testing.T) {
+		qr, err := fg.Query(context.Background(), `old`)
+		require.NoError(t, err)
+		require.Empty(t, qr.Series)
+	})
+
+	t.Run("query with parse error", func(t *testing.T) {
+		_, err := fg.Query(context.Background(), `sum(up`)
+		require.EqualError(t, err, "bad_data: 1:7: parse error: unclosed left parenthesis")
+	})
+
+	t.Run("query returning scalar", func(t *testing.T) {
+		_, err := fg.Query(context.Background(), `1`)
+		require.EqualError(t, err, "bad_response: invalid result type, expected vector, got scalar")
+	})
+
+	t.Run("range query", func(t *testing.T) {
+		qr, err := fg.RangeQuery(context.Background(), `old`, promapi.NewRelativeRange(time.Hour*3, time.Minute))
+		require.NoError(t, err)
+		require.Len(t, qr.Series.Ranges, 2)
+		for _, r := range qr.Series.Ranges {
+			require.Equal(t, labels.FromStrings("__name__", "old", "job", "history"), r.Labels)
+			require.Equal(t, time.Minute*5-time.Second, r.End.Sub(r.Start))
+		}
+	})
+
+	t.Run("range query for samples without timestamps", func(t *testing.T) {
+		params := promapi.NewRelativeRange(time.Hour*24*7, time.Minute*5)
+		qr, err := fg.RangeQuery(context.Background(), `count(up)`, params)
+		require.NoError(t, err)
+		require.Len(t, qr.Series.Ranges, 1)
+		require.Equal(t, labels.EmptyLabels(), qr.Series.Ranges[0].Labels)
+		require.False(t, qr.Series.Ranges[0].Start.After(qr.Series.From))
+		require.WithinDuration(t, qr.Series.Until, qr.Series.Ranges[0].End, time.Minute*5)
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		meta, err := fg.Metadata(context.Background(), "http_requests")
+		require.NoError(t, err)
+		require.Equal(t, []v1.Metadata{{Type: "counter", Help: "Total requests."}}, meta.Metadata)
+
+		meta, err = fg.Metadata(context.Background(), "missing")
+		require.NoError(t, err)
+		require.Empty(t, meta.Metadata)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		cfg, err := fg.Config(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, time.Second*30, cfg.Config.Global.ScrapeInterval)
+		require.Equal(t, time.Minute, cfg.Config.Global.EvaluationInterval)
+	})
+
+	t.Run("flags", func(t *testing.T) {
+		flags, err := fg.Flags(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, flags.Flags)
+	})
+}
+
+func TestFixtureErrors(t *testing.T) {
+	type testCaseT struct {
+		description string
+		files       map[string]string
+		err         string
+	}
+
+	testCases := []testCaseT{
+		{
+			description: "invalid exposition file",
+			files:       map[string]string{"bad.prom": "up{job=\"a\" 1\n"},
+			err:         `failed to parse {dir}/bad.prom: expected label name, got "1" ("INVALID") while parsing: "up{job=\"a\" 1"`,
+		},
+		{
+			description: "invalid config file",
+			files:       map[string]string{"prometheus.yml": "global: []\n"},
+			err:         "failed to parse {dir}/prometheus.yml: yaml: unmarshal errors:\n  line 1: cannot unmarshal !!seq into promapi.ConfigSectionGlobal",
+		},
+		{
+			description: "conflicting samples",
+			files: map[string]string{
+				"a.prom": "up{job=\"a\"} 1 1000\n",
+				"b.prom": "up{job=\"a\"} 0 1000\n",
+			},
+			err: `{__name__="up", job="a"} sample from {dir}/b.prom has a different value than the sample from {dir}/a.prom with the same timestamp`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			fg, dir := newFixtureGroup(t, tc.files)
+			_, err := fg.Query(context.Background(), "up")
+			require.EqualError(t, err, "failed to load fixture data from "+dir+": "+replaceDir(tc.err, dir))
+		})
+	}
+}
+
+func formatMillis(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
+
+func replaceDir(s, dir string) string {
+	return strings.ReplaceAll(s, "{dir}", dir)
+}
@@ -92,10 +92,12 @@ type Prometheus struct {
 	concurrency int
 	client      http.Client
 	cache       *queryCache
+	diskCache   *DiskCache
 	locker      *partitionLocker
 	rateLimiter ratelimit.Limiter
 	wg          sync.WaitGroup
 	queries     chan queryRequest
+	fixture     *fixture
 }
 
 func NewPrometheus(name, uri string, headers map[string]string, timeout time.Duration, concurrency, rl int, tlsConf *tls.Config) *Prometheus {
@@ -118,10 +120,30 @@ func NewPrometheus(name, uri string, headers map[string]string, timeout time.Dur
 	return &prom
 }
 
+// NewFixturePrometheus returns a Prometheus instance that will answer all
+// queries using samples loaded from text exposition files found in path,
+// instead of sending HTTP requests to a Prometheus server.
+func NewFixturePrometheus(name, path string, timeout time.Duration, concurrency int) *Prometheus {
+	prom := Prometheus{
+		name:        name,
+		unsafeURI:   path,
+		safeURI:     path,
+		timeout:     timeout,
+		locker:      newPartitionLocker((&sync.Mutex{})),
+		rateLimiter: ratelimit.NewUnlimited(),
+		concurrency: concurrency,
+		fixture:     newFixture(path, timeout),
+	}
+	return &prom
+}
+
 func (prom *Prometheus) Close() {
 	log.Debug().Str("name", prom.name).Str("uri", prom.safeURI).Msg("Stopping query workers")
 	close(prom.queries)
 	prom.wg.Wait()
+	if prom.fixture != nil {
+		prom.fixture.close()
+	}
 }
 
 func (prom *Prometheus) StartWorkers() {
@@ -190,12 +212,29 @@ func processJob(prom *Prometheus, job queryRequest) queryResult {
 			return cached.(queryResult)
 		}
 	}
+	if prom.diskCache != nil {
+		cached, ok := prom.diskCache.get(cacheKey, job.query.Endpoint())
+		if prom.cache != nil {
+			prom.cache.recordDisk(job.query.Endpoint(), ok)
+		}
+		if ok {
+			if prom.cache != nil {
+				prom.cache.set(cacheKey, cached, job.query.CacheTTL())
+			}
+			return cached
+		}
+	}
 
 	prometheusQueriesTotal.WithLabelValues(prom.name, job.query.Endpoint()).Inc()
 	prometheusQueriesRunning.WithLabelValues(prom.name, job.query.Endpoint()).Inc()
 
 	prom.rateLimiter.Take()
-	result := job.query.Run()
+	var result queryResult
+	if prom.fixture != nil {
+		result = prom.fixture.run(job.query)
+	} else {
+		result = job.query.Run()
+	}
 	prometheusQueriesRunning.WithLabelValues(prom.name, job.query.Endpoint()).Dec()
 
 	if result.err != nil {
@@ -214,6 +253,9 @@ func processJob(prom *Prometheus, job queryRequest) queryResult {
 	if prom.cache != nil {
 		prom.cache.set(cacheKey, result, job.query.CacheTTL())
 	}
+	if prom.diskCache != nil {
+		prom.diskCache.set(cacheKey, job.query.Endpoint(), result, job.query.CacheTTL())
+	}
 
 	return result
 }
@@ -252,11 +252,15 @@ type RelativeRange struct {
 }
 
 func (rr RelativeRange) Start() time.Time {
-	return time.Now().Add(rr.lookback * -1)
+	return rr.End().Add(rr.lookback * -1)
 }
 
+// End returns current time truncated to the query step.
+// This way all queries for the same range run within one step will use
+// the same start and end time, and so the same cache keys, which allows
+// query results to be reused between different pint runs.
 func (rr RelativeRange) End() time.Time {
-	return time.Now()
+	return time.Now().Truncate(rr.step)
 }
 
 func (rr RelativeRange) Dur() time.Duration {
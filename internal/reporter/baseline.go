@@ -0,0 +1,115 @@
+package reporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+)
+
+// Some problems reference other rules using path:line, strip line numbers
+// so that moving those rules around doesn't invalidate the baseline.
+var baselinePositionRe = regexp.MustCompile(`:[0-9]+\b`)
+
+// BaselineEntry identifies a single accepted problem.
+// Line numbers are deliberately not part of it, so that unrelated changes
+// to a file don't invalidate the baseline.
+type BaselineEntry struct {
+	Path     string `json:"path"`
+	Rule     string `json:"rule"`
+	Reporter string `json:"reporter"`
+	Hash     string `json:"hash"`
+	Count    int    `json:"count"`
+}
+
+func (be BaselineEntry) key() string {
+	return be.Path + "\x00" + be.Rule + "\x00" + be.Reporter + "\x00" + be.Hash
+}
+
+type Baseline struct {
+	Entries []BaselineEntry `json:"entries"`
+}
+
+func baselineEntryForReport(report Report) BaselineEntry {
+	h := sha256.Sum256([]byte(baselinePositionRe.ReplaceAllString(report.Problem.Text, ":")))
+	return BaselineEntry{
+		Path:     report.ReportedPath,
+		Rule:     report.Rule.Name(),
+		Reporter: report.Problem.Reporter,
+		Hash:     hex.EncodeToString(h[:8]),
+		Count:    1,
+	}
+}
+
+func NewBaseline(reports []Report) Baseline {
+	index := map[string]int{}
+	b := Baseline{Entries: []BaselineEntry{}}
+	for _, report := range reports {
+		be := baselineEntryForReport(report)
+		if i, ok := index[be.key()]; ok {
+			b.Entries[i].Count++
+			continue
+		}
+		index[be.key()] = len(b.Entries)
+		b.Entries = append(b.Entries, be)
+	}
+	sort.SliceStable(b.Entries, func(i, j int) bool {
+		return b.Entries[i].key() < b.Entries[j].key()
+	})
+	return b
+}
+
+func LoadBaseline(path string) (b Baseline, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return b, err
+	}
+	if err = json.Unmarshal(content, &b); err != nil {
+		return b, fmt.Errorf("failed to parse baseline file %s: %w", path, err)
+	}
+	return b, nil
+}
+
+func (b Baseline) Write(path string) error {
+	content, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0o644)
+}
+
+// Apply removes all reports matching baseline entries from the summary.
+// It returns baseline entries that didn't match any report.
+func (b Baseline) Apply(s *Summary) (stale []BaselineEntry) {
+	remaining := map[string]int{}
+	for _, be := range b.Entries {
+		count := be.Count
+		if count <= 0 {
+			count = 1
+		}
+		remaining[be.key()] += count
+	}
+
+	reports := make([]Report, 0, len(s.reports))
+	for _, report := range s.reports {
+		key := baselineEntryForReport(report).key()
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		reports = append(reports, report)
+	}
+	s.reports = reports
+
+	for _, be := range b.Entries {
+		if n := remaining[be.key()]; n > 0 {
+			be.Count = n
+			stale = append(stale, be)
+			remaining[be.key()] = 0
+		}
+	}
+	return stale
+}
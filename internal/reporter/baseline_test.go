@@ -0,0 +1,147 @@
+package reporter_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+func TestBaseline(t *testing.T) {
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+- record: foo
+  expr: sum(foo)
+- record: bar
+  expr: sum(bar)
+`))
+
+	newReport := func(rule parser.Rule, lines []int, name, text string) reporter.Report {
+		return reporter.Report{
+			ReportedPath:  "rules.yml",
+			SourcePath:    "rules.yml",
+			ModifiedLines: lines,
+			Rule:          rule,
+			Problem: checks.Problem{
+				Lines:    lines,
+				Reporter: name,
+				Text:     text,
+				Severity: checks.Bug,
+			},
+		}
+	}
+
+	old := reporter.NewSummary([]reporter.Report{
+		newReport(mockRules[0], []int{2, 3}, "promql/aggregate", "job label is required"),
+		newReport(mockRules[1], []int{4, 5}, "promql/aggregate", "job label is required"),
+		newReport(mockRules[1], []int{4, 5}, "promql/series", "bar metric is missing"),
+	})
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, reporter.NewBaseline(old.Reports()).Write(path))
+
+	baseline, err := reporter.LoadBaseline(path)
+	require.NoError(t, err)
+	require.Len(t, baseline.Entries, 3)
+	require.Equal(t, "bar", baseline.Entries[0].Rule)
+	require.Equal(t, "promql/aggregate", baseline.Entries[0].Reporter)
+	require.Equal(t, 1, baseline.Entries[0].Count)
+
+	// Line numbers changed, one problem was fixed and a new one was added.
+	current := reporter.NewSummary([]reporter.Report{
+		newReport(mockRules[0], []int{12, 13}, "promql/aggregate", "job label is required"),
+		newReport(mockRules[1], []int{14, 15}, "promql/aggregate", "job label is required"),
+		newReport(mockRules[1], []int{14, 15}, "promql/rate", "rate is wrong"),
+	})
+	stale := baseline.Apply(&current)
+	require.Equal(t, []reporter.BaselineEntry{
+		{
+			Path:     "rules.yml",
+			Rule:     "bar",
+			Reporter: "promql/series",
+			Hash:     baseline.Entries[1].Hash,
+			Count:    1,
+		},
+	}, stale)
+	require.Len(t, current.Reports(), 1)
+	require.Equal(t, "promql/rate", current.Reports()[0].Problem.Reporter)
+}
+
+func TestBaselineDuplicates(t *testing.T) {
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+- record: foo
+  expr: foo{job=~"a"} / bar{job=~"a"}
+`))
+
+	newReport := func(lines []int) reporter.Report {
+		return reporter.Report{
+			ReportedPath:  "rules.yml",
+			SourcePath:    "rules.yml",
+			ModifiedLines: lines,
+			Rule:          mockRules[0],
+			Problem: checks.Problem{
+				Lines:    lines,
+				Reporter: "promql/regexp",
+				Text:     "unnecessary regexp match",
+				Severity: checks.Bug,
+			},
+		}
+	}
+
+	baseline := reporter.NewBaseline([]reporter.Report{newReport([]int{2}), newReport([]int{3})})
+	require.Len(t, baseline.Entries, 1)
+	require.Equal(t, 2, baseline.Entries[0].Count)
+
+	current := reporter.NewSummary([]reporter.Report{newReport([]int{2}), newReport([]int{3}), newReport([]int{4})})
+	require.Empty(t, baseline.Apply(&current))
+	require.Len(t, current.Reports(), 1)
+
+	current = reporter.NewSummary([]reporter.Report{newReport([]int{2})})
+	stale := baseline.Apply(&current)
+	require.Len(t, stale, 1)
+	require.Equal(t, 1, stale[0].Count)
+	require.Empty(t, current.Reports())
+}
+
+func TestBaselineIgnoresLineNumbers(t *testing.T) {
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+- record: foo
+  expr: sum(foo)
+`))
+
+	newReport := func(text string) reporter.Report {
+		return reporter.Report{
+			ReportedPath:  "rules.yml",
+			SourcePath:    "rules.yml",
+			ModifiedLines: []int{2, 3},
+			Rule:          mockRules[0],
+			Problem: checks.Problem{
+				Lines:    []int{2},
+				Reporter: "rule/duplicate",
+				Text:     text,
+				Severity: checks.Bug,
+			},
+		}
+	}
+
+	baseline := reporter.NewBaseline([]reporter.Report{newReport("duplicated rule, identical rule found at other.yml:4")})
+
+	current := reporter.NewSummary([]reporter.Report{newReport("duplicated rule, identical rule found at other.yml:14")})
+	require.Empty(t, baseline.Apply(&current))
+	require.Empty(t, current.Reports())
+
+	current = reporter.NewSummary([]reporter.Report{newReport("duplicated rule, identical rule found at another.yml:4")})
+	require.Len(t, baseline.Apply(&current), 1)
+	require.Len(t, current.Reports(), 1)
+}
+
+func TestLoadBaselineErrors(t *testing.T) {
+	_, err := reporter.LoadBaseline(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/cloudflare/pint/internal/output"
 
 	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -157,7 +158,7 @@ func (r BitBucketReporter) makeAnnotation(report Report) (annotations []BitBucke
 		Message:  fmt.Sprintf("%s%s: %s", msgPrefix, report.Problem.Reporter, report.Problem.Text),
 		Severity: severity,
 		Type:     atype,
-		Link:     fmt.Sprintf("https://cloudflare.github.io/pint/checks/%s.html", report.Problem.Reporter),
+		Link:     CheckDocsURL(report.Problem.Reporter),
 	}
 	annotations = append(annotations, a)
 
@@ -255,7 +256,12 @@ func (r BitBucketReporter) postReport(commit string, isPassing bool, annotations
 // to the first modified line.
 // Without this we could have a report that is marked as failed, but with no annotations
 // at all, which would make it more difficult to fix.
+// If the problem has a text range starting on a modified line then that line is used.
 func moveReportedLine(report Report) (reported, original int) {
+	if tr := report.Problem.Range; tr != nil && slices.Contains(report.Problem.Lines, tr.Line) && slices.Contains(report.ModifiedLines, tr.Line) {
+		return tr.Line, tr.Line
+	}
+
 	reported = -1
 	original = -1
 	for _, pl := range report.Problem.Lines {
@@ -326,6 +326,63 @@ func TestBitBucketReporter(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			description: "annotation is reported on the first line of problem range",
+			gitCmd: func(args ...string) ([]byte, error) {
+				if args[0] == "rev-parse" {
+					return []byte("fake-commit-id"), nil
+				}
+				return nil, nil
+			},
+			reports: []reporter.Report{
+				{
+					ReportedPath:  "foo.txt",
+					SourcePath:    "foo.txt",
+					ModifiedLines: []int{4, 5},
+					Rule:          mockRules[1],
+					Problem: checks.Problem{
+						Fragment: "sum(errors) by (job)",
+						Lines:    []int{4, 5},
+						Reporter: "mock",
+						Text:     "mock text",
+						Severity: checks.Bug,
+						Range:    &checks.TextRange{Line: 4, Column: 9, EndLine: 4, EndColumn: 29},
+					},
+				},
+			},
+			report: reporter.BitBucketReport{
+				Reporter: "Prometheus rule linter",
+				Title:    "pint v0.0.0",
+				Details:  reporter.BitBucketDescription,
+				Link:     "https://cloudflare.github.io/pint/",
+				Result:   "FAIL",
+				Data: []reporter.BitBucketReportData{
+					{Title: "Number of rules checked", Type: reporter.NumberType, Value: float64(0)},
+					{Title: "Number of problems found", Type: reporter.NumberType, Value: float64(1)},
+					{Title: "Number of offline checks", Type: reporter.NumberType, Value: float64(0)},
+					{Title: "Number of online checks", Type: reporter.NumberType, Value: float64(0)},
+					{Title: "Checks duration", Type: reporter.DurationType, Value: float64(0)},
+				},
+			},
+			annotations: reporter.BitBucketAnnotations{
+				Annotations: []reporter.BitBucketAnnotation{
+					{
+						Path:     "foo.txt",
+						Line:     4,
+						Message:  "mock: mock text",
+						Severity: "MEDIUM",
+						Type:     "BUG",
+						Link:     "https://cloudflare.github.io/pint/checks/mock.html",
+					},
+				},
+			},
+			errorHandler: func(err error) error {
+				if err != nil {
+					return fmt.Errorf("Unpexpected error: %w", err)
+				}
+				return nil
+			},
+		},
 		{
 			description: "sends a correct empty report",
 			gitCmd: func(args ...string) ([]byte, error) {
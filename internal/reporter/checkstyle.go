@@ -0,0 +1,77 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"os"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+func NewCheckstyleReporter(path string) CheckstyleReporter {
+	return CheckstyleReporter{path: path}
+}
+
+// CheckstyleReporter writes all reports to a file using Checkstyle XML format,
+// which is supported by many CI plugins, like Jenkins Warnings Next Generation.
+type CheckstyleReporter struct {
+	path string
+}
+
+type CheckstyleResult struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []CheckstyleFile `xml:"file"`
+}
+
+type CheckstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []CheckstyleError `xml:"error"`
+}
+
+type CheckstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func (cr CheckstyleReporter) Submit(reports []Report) error {
+	result := CheckstyleResult{Version: "8.0", Files: []CheckstyleFile{}}
+
+	fileIndex := map[string]int{}
+	for _, report := range reports {
+		idx, ok := fileIndex[report.ReportedPath]
+		if !ok {
+			idx = len(result.Files)
+			fileIndex[report.ReportedPath] = idx
+			result.Files = append(result.Files, CheckstyleFile{Name: report.ReportedPath})
+		}
+
+		e := CheckstyleError{
+			Severity: checkstyleSeverity(report.Problem.Severity),
+			Message:  report.Problem.Text,
+			Source:   "pint." + report.Problem.Reporter,
+		}
+		if len(report.Problem.Lines) > 0 {
+			e.Line, _ = report.Problem.LineRange()
+		}
+		result.Files[idx].Errors = append(result.Files[idx].Errors, e)
+	}
+
+	content, err := xml.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(cr.path, append([]byte(xml.Header), append(content, '\n')...), 0o644)
+}
+
+func checkstyleSeverity(s checks.Severity) string {
+	switch s {
+	case checks.Information:
+		return "info"
+	case checks.Warning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,97 @@
+package reporter_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+func TestCheckstyleReporter(t *testing.T) {
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+- record: target is down
+  expr: up == 0
+- record: sum errors
+  expr: sum(errors) by (job)
+`))
+
+	type testCaseT struct {
+		description string
+		reports     []reporter.Report
+		output      string
+	}
+
+	testCases := []testCaseT{
+		{
+			description: "no reports",
+			output: `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="8.0"></checkstyle>
+`,
+		},
+		{
+			description: "multiple reports",
+			reports: []reporter.Report{
+				{
+					ReportedPath: "foo.yml",
+					SourcePath:   "foo.yml",
+					Rule:         mockRules[1],
+					Problem: checks.Problem{
+						Lines:    []int{4, 5},
+						Reporter: "promql/aggregate",
+						Text:     "job label should be removed",
+						Severity: checks.Warning,
+					},
+				},
+				{
+					ReportedPath: "bar.yml",
+					SourcePath:   "bar.yml",
+					Rule:         mockRules[0],
+					Problem: checks.Problem{
+						Lines:    []int{3},
+						Reporter: "promql/series",
+						Text:     `"up" metric is missing`,
+						Severity: checks.Bug,
+					},
+				},
+				{
+					ReportedPath: "foo.yml",
+					SourcePath:   "foo.yml",
+					Rule:         mockRules[0],
+					Problem: checks.Problem{
+						Reporter: "alerts/template",
+						Text:     "info problem",
+						Severity: checks.Information,
+					},
+				},
+			},
+			output: `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="8.0">
+  <file name="foo.yml">
+    <error line="4" severity="warning" message="job label should be removed" source="pint.promql/aggregate"></error>
+    <error severity="info" message="info problem" source="pint.alerts/template"></error>
+  </file>
+  <file name="bar.yml">
+    <error line="3" severity="error" message="&#34;up&#34; metric is missing" source="pint.promql/series"></error>
+  </file>
+</checkstyle>
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "checkstyle.xml")
+			require.NoError(t, reporter.NewCheckstyleReporter(path).Submit(tc.reports))
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, tc.output, string(content))
+		})
+	}
+}
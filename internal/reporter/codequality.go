@@ -0,0 +1,97 @@
+package reporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+func NewCodeQualityReporter(path string) CodeQualityReporter {
+	return CodeQualityReporter{path: path}
+}
+
+// CodeQualityReporter writes all reports to a file using GitLab Code Quality
+// format, so problems are visible in merge request widgets.
+type CodeQualityReporter struct {
+	path string
+}
+
+type CodeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    CodeQualityLocation `json:"location"`
+}
+
+type CodeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines CodeQualityLines `json:"lines"`
+}
+
+type CodeQualityLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+func (cr CodeQualityReporter) Submit(reports []Report) error {
+	issues := make([]CodeQualityIssue, 0, len(reports))
+	fingerprints := reportFingerprints(reports)
+	for i, report := range reports {
+		issue := CodeQualityIssue{
+			Description: report.Problem.Text,
+			CheckName:   report.Problem.Reporter,
+			Fingerprint: fingerprints[i],
+			Severity:    codeQualitySeverity(report.Problem.Severity),
+			Location:    CodeQualityLocation{Path: report.ReportedPath},
+		}
+		if len(report.Problem.Lines) > 0 {
+			issue.Location.Lines.Begin, issue.Location.Lines.End = report.Problem.LineRange()
+		}
+		issues = append(issues, issue)
+	}
+
+	result, err := json.Marshal(issues)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(cr.path, result, 0o644)
+}
+
+// reportFingerprints returns a unique fingerprint for every report.
+// Line numbers are not part of the fingerprint, same as with baseline entries,
+// so that problems keep the same fingerprint when other rules are added or
+// removed. Identical problems reported for the same rule are numbered in the
+// order they were reported.
+func reportFingerprints(reports []Report) []string {
+	seen := map[string]int{}
+	fingerprints := make([]string, 0, len(reports))
+	for _, report := range reports {
+		key := baselineEntryForReport(report).key()
+		n := seen[key]
+		seen[key]++
+		if n > 0 {
+			key += "\x00" + strconv.Itoa(n)
+		}
+		h := sha256.Sum256([]byte(key))
+		fingerprints = append(fingerprints, hex.EncodeToString(h[:16]))
+	}
+	return fingerprints
+}
+
+func codeQualitySeverity(s checks.Severity) string {
+	switch s {
+	case checks.Information:
+		return "info"
+	case checks.Warning:
+		return "minor"
+	case checks.Bug:
+		return "major"
+	default:
+		return "blocker"
+	}
+}
@@ -0,0 +1,114 @@
+package reporter_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+func TestCodeQualityReporter(t *testing.T) {
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+- record: target is down
+  expr: up == 0
+- record: sum errors
+  expr: sum(errors) by (job)
+`))
+
+	reports := []reporter.Report{
+		{
+			ReportedPath: "foo.yml",
+			SourcePath:   "foo.yml",
+			Rule:         mockRules[1],
+			Problem: checks.Problem{
+				Lines:    []int{4, 5},
+				Reporter: "promql/aggregate",
+				Text:     "job label should be removed",
+				Severity: checks.Warning,
+			},
+		},
+		{
+			ReportedPath: "foo.yml",
+			SourcePath:   "foo.yml",
+			Rule:         mockRules[0],
+			Problem: checks.Problem{
+				Lines:    []int{3},
+				Reporter: "promql/series",
+				Text:     "up metric is missing",
+				Severity: checks.Bug,
+			},
+		},
+		{
+			ReportedPath: "foo.yml",
+			SourcePath:   "foo.yml",
+			Rule:         mockRules[0],
+			Problem: checks.Problem{
+				Lines:    []int{2},
+				Reporter: "promql/series",
+				Text:     "up metric is missing",
+				Severity: checks.Fatal,
+			},
+		},
+		{
+			ReportedPath: "bar.yml",
+			SourcePath:   "bar.yml",
+			Rule:         mockRules[1],
+			Problem: checks.Problem{
+				Reporter: "promql/aggregate",
+				Text:     "info problem",
+				Severity: checks.Information,
+			},
+		},
+	}
+
+	submit := func(reports []reporter.Report) []reporter.CodeQualityIssue {
+		path := filepath.Join(t.TempDir(), "codequality.json")
+		require.NoError(t, reporter.NewCodeQualityReporter(path).Submit(reports))
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var issues []reporter.CodeQualityIssue
+		require.NoError(t, json.Unmarshal(content, &issues))
+		return issues
+	}
+
+	issues := submit(reports)
+	require.Len(t, issues, 4)
+	require.Equal(t, reporter.CodeQualityIssue{
+		Description: "job label should be removed",
+		CheckName:   "promql/aggregate",
+		Fingerprint: issues[0].Fingerprint,
+		Severity:    "minor",
+		Location: reporter.CodeQualityLocation{
+			Path:  "foo.yml",
+			Lines: reporter.CodeQualityLines{Begin: 4, End: 5},
+		},
+	}, issues[0])
+	require.Len(t, issues[0].Fingerprint, 32)
+	require.Equal(t, []string{"minor", "major", "blocker", "info"}, []string{
+		issues[0].Severity, issues[1].Severity, issues[2].Severity, issues[3].Severity,
+	})
+	require.Equal(t, reporter.CodeQualityLines{Begin: 0, End: 0}, issues[3].Location.Lines)
+
+	fingerprints := map[string]struct{}{}
+	for _, issue := range issues {
+		fingerprints[issue.Fingerprint] = struct{}{}
+	}
+	require.Len(t, fingerprints, 4, "fingerprints must be unique")
+
+	// Fingerprints must not change when line numbers change.
+	moved := make([]reporter.Report, len(reports))
+	copy(moved, reports)
+	moved[0].Problem.Lines = []int{14, 15}
+	movedIssues := submit(moved)
+	for i := range issues {
+		require.Equal(t, issues[i].Fingerprint, movedIssues[i].Fingerprint)
+	}
+	require.Equal(t, reporter.CodeQualityLines{Begin: 14, End: 15}, movedIssues[0].Location.Lines)
+}
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 	"github.com/rs/zerolog/log"
@@ -16,40 +17,56 @@ import (
 	"github.com/cloudflare/pint/internal/output"
 )
 
-func NewConsoleReporter(output io.Writer, minSeverity checks.Severity) ConsoleReporter {
-	return ConsoleReporter{output: output, minSeverity: minSeverity}
+// ConsoleFormat controls how problems are printed by ConsoleReporter.
+type ConsoleFormat string
+
+const (
+	// ConsoleFormatText prints problems with the content of affected lines.
+	ConsoleFormatText ConsoleFormat = "text"
+	// ConsoleFormatGitHub prints GitHub Actions workflow commands.
+	ConsoleFormatGitHub ConsoleFormat = "github"
+	// ConsoleFormatAzure prints Azure Pipelines logging commands.
+	ConsoleFormatAzure ConsoleFormat = "azure"
+	// ConsoleFormatTeamCity prints TeamCity service messages.
+	ConsoleFormatTeamCity ConsoleFormat = "teamcity"
+)
+
+var ConsoleFormats = []ConsoleFormat{
+	ConsoleFormatText,
+	ConsoleFormatGitHub,
+	ConsoleFormatAzure,
+	ConsoleFormatTeamCity,
+}
+
+func ParseConsoleFormat(s string) (ConsoleFormat, error) {
+	for _, f := range ConsoleFormats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+	names := make([]string, 0, len(ConsoleFormats))
+	for _, f := range ConsoleFormats {
+		names = append(names, string(f))
+	}
+	return ConsoleFormatText, fmt.Errorf("unknown format: %q, must be one of: %s", s, strings.Join(names, ", "))
+}
+
+func NewConsoleReporter(output io.Writer, minSeverity checks.Severity, format ConsoleFormat) ConsoleReporter {
+	return ConsoleReporter{output: output, minSeverity: minSeverity, format: format}
 }
 
 type ConsoleReporter struct {
 	output      io.Writer
 	minSeverity checks.Severity
+	format      ConsoleFormat
 }
 
 func (cr ConsoleReporter) Submit(summary Summary) error {
 	reports := summary.Reports()
-	sort.Slice(reports, func(i, j int) bool {
-		if reports[i].SourcePath < reports[j].SourcePath {
-			return true
-		}
-		if reports[i].SourcePath > reports[j].SourcePath {
-			return false
-		}
-		if reports[i].Problem.Lines[0] < reports[j].Problem.Lines[0] {
-			return true
-		}
-		if reports[i].Problem.Lines[0] > reports[j].Problem.Lines[0] {
-			return false
-		}
-		if reports[i].Problem.Reporter < reports[j].Problem.Reporter {
-			return true
-		}
-		if reports[i].Problem.Reporter > reports[j].Problem.Reporter {
-			return false
-		}
-		return reports[i].Problem.Text < reports[j].Problem.Text
-	})
+	sortReports(reports)
 
 	perFile := map[string][]string{}
+	inspections := map[string]struct{}{}
 	for _, report := range reports {
 		if report.Problem.Severity < cr.minSeverity {
 			continue
@@ -63,6 +80,22 @@ func (cr ConsoleReporter) Submit(summary Summary) error {
 			continue
 		}
 
+		switch cr.format {
+		case ConsoleFormatGitHub:
+			fmt.Fprintln(cr.output, githubAnnotation(report))
+			continue
+		case ConsoleFormatAzure:
+			fmt.Fprintln(cr.output, azureAnnotation(report))
+			continue
+		case ConsoleFormatTeamCity:
+			if _, ok := inspections[report.Problem.Reporter]; !ok {
+				fmt.Fprintln(cr.output, teamCityInspectionType(report.Problem.Reporter))
+				inspections[report.Problem.Reporter] = struct{}{}
+			}
+			fmt.Fprintln(cr.output, teamCityInspection(report))
+			continue
+		}
+
 		if _, ok := perFile[report.SourcePath]; !ok {
 			perFile[report.SourcePath] = []string{}
 		}
@@ -104,6 +137,9 @@ func (cr ConsoleReporter) Submit(summary Summary) error {
 			switch {
 			case slices.Contains(report.Problem.Lines, i):
 				msg = append(msg, color.WhiteString(nrFmt+" | %s\n", i, lines[i-1]))
+				if marker, ok := rangeMarker(lines[i-1], i, report.Problem.Range); ok {
+					msg = append(msg, color.RedString("%s | %s\n", strings.Repeat(" ", countDigits(lastLine)+1), marker))
+				}
 				inPlaceholder = false
 			case inPlaceholder:
 				//
@@ -131,6 +167,69 @@ func (cr ConsoleReporter) Submit(summary Summary) error {
 	return nil
 }
 
+// sortReports orders reports by path, line, reporter and problem text.
+func sortReports(reports []Report) {
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].SourcePath < reports[j].SourcePath {
+			return true
+		}
+		if reports[i].SourcePath > reports[j].SourcePath {
+			return false
+		}
+		if reports[i].Problem.Lines[0] < reports[j].Problem.Lines[0] {
+			return true
+		}
+		if reports[i].Problem.Lines[0] > reports[j].Problem.Lines[0] {
+			return false
+		}
+		if reports[i].Problem.Reporter < reports[j].Problem.Reporter {
+			return true
+		}
+		if reports[i].Problem.Reporter > reports[j].Problem.Reporter {
+			return false
+		}
+		return reports[i].Problem.Text < reports[j].Problem.Text
+	})
+}
+
+// rangeMarker returns a line of carets underlining the part of given line
+// that is inside the problem range.
+func rangeMarker(line string, lineNr int, tr *checks.TextRange) (string, bool) {
+	if tr == nil || lineNr < tr.Line || lineNr > tr.EndLine {
+		return "", false
+	}
+
+	start := len(line) - len(strings.TrimLeft(line, " \t")) + 1
+	if lineNr == tr.Line {
+		start = tr.Column
+	}
+	end := len(line) + 1
+	if lineNr == tr.EndLine {
+		end = tr.EndColumn
+	}
+	start = max(1, min(start, len(line)+1))
+	end = min(end, len(line)+1)
+	if end <= start {
+		// Empty ranges, like syntax errors at the end of the query,
+		// are marked with a single caret.
+		if tr.Line != tr.EndLine || tr.Column != tr.EndColumn {
+			return "", false
+		}
+		end = start + 1
+	}
+
+	var b strings.Builder
+	for _, r := range line[:start-1] {
+		if r == '\t' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	b.WriteString(strings.Repeat("^", max(1, utf8.RuneCountInString(line[start-1:min(end-1, len(line))]))))
+	return b.String(), true
+}
+
 func readFile(path string) (string, error) {
 	f, err := os.Open(path)
 	if err != nil {
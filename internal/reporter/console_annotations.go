@@ -0,0 +1,97 @@
+package reporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+// https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+var (
+	githubDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func githubAnnotation(report Report) string {
+	var level string
+	switch report.Problem.Severity {
+	case checks.Information:
+		level = "notice"
+	case checks.Warning:
+		level = "warning"
+	default:
+		level = "error"
+	}
+
+	firstLine, lastLine := report.Problem.LineRange()
+	var columns string
+	if tr := report.Problem.Range; tr != nil {
+		firstLine, lastLine = tr.Line, tr.EndLine
+		columns = fmt.Sprintf(",col=%d,endColumn=%d", tr.Column, tr.EndColumn)
+	}
+	return fmt.Sprintf("::%s file=%s,line=%d,endLine=%d%s,title=%s::%s",
+		level,
+		githubPropertyEscaper.Replace(report.ReportedPath),
+		firstLine,
+		lastLine,
+		columns,
+		githubPropertyEscaper.Replace(fmt.Sprintf("%s: %s", report.Problem.Severity, report.Problem.Reporter)),
+		githubDataEscaper.Replace(report.Problem.Text),
+	)
+}
+
+// https://learn.microsoft.com/en-us/azure/devops/pipelines/scripts/logging-commands
+var (
+	azureDataEscaper     = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A")
+	azurePropertyEscaper = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A", ";", "%3B", "]", "%5D")
+)
+
+func azureAnnotation(report Report) string {
+	// Azure Pipelines only supports errors and warnings.
+	level := "warning"
+	if report.Problem.Severity >= checks.Bug {
+		level = "error"
+	}
+
+	firstLine, _ := report.Problem.LineRange()
+	return fmt.Sprintf("##vso[task.logissue type=%s;sourcepath=%s;linenumber=%d;code=%s]%s",
+		level,
+		azurePropertyEscaper.Replace(report.ReportedPath),
+		firstLine,
+		azurePropertyEscaper.Replace(report.Problem.Reporter),
+		azureDataEscaper.Replace(fmt.Sprintf("%s: %s", report.Problem.Severity, report.Problem.Text)),
+	)
+}
+
+// https://www.jetbrains.com/help/teamcity/service-messages.html
+var teamCityEscaper = strings.NewReplacer("|", "||", "'", "|'", "\n", "|n", "\r", "|r", "[", "|[", "]", "|]")
+
+func teamCityInspectionType(name string) string {
+	return fmt.Sprintf("##teamcity[inspectionType id='%s' name='%s' category='pint' description='%s']",
+		teamCityEscaper.Replace(name),
+		teamCityEscaper.Replace(name),
+		teamCityEscaper.Replace(CheckDocsURL(name)),
+	)
+}
+
+func teamCityInspection(report Report) string {
+	var level string
+	switch report.Problem.Severity {
+	case checks.Information:
+		level = "INFO"
+	case checks.Warning:
+		level = "WARNING"
+	default:
+		level = "ERROR"
+	}
+
+	firstLine, _ := report.Problem.LineRange()
+	return fmt.Sprintf("##teamcity[inspection typeId='%s' message='%s' file='%s' line='%d' SEVERITY='%s']",
+		teamCityEscaper.Replace(report.Problem.Reporter),
+		teamCityEscaper.Replace(report.Problem.Text),
+		teamCityEscaper.Replace(report.ReportedPath),
+		firstLine,
+		level,
+	)
+}
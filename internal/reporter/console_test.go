@@ -0,0 +1,176 @@
+package reporter_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+func TestConsoleReporterAnnotations(t *testing.T) {
+	type testCaseT struct {
+		format reporter.ConsoleFormat
+		output string
+	}
+
+	reports := []reporter.Report{
+		{
+			ReportedPath:  "rules/a,b.yml",
+			SourcePath:    "rules/a,b.yml",
+			ModifiedLines: []int{2, 3, 4},
+			Problem: checks.Problem{
+				Lines:    []int{3, 4},
+				Reporter: "promql/series",
+				Text:     "100% of [foo]\nisn't present",
+				Severity: checks.Bug,
+				Range:    &checks.TextRange{Line: 3, Column: 5, EndLine: 4, EndColumn: 10},
+			},
+		},
+		{
+			ReportedPath:  "rules/a,b.yml",
+			SourcePath:    "rules/a,b.yml",
+			ModifiedLines: []int{2, 3, 4},
+			Problem: checks.Problem{
+				Lines:    []int{2},
+				Reporter: "promql/series",
+				Text:     "info",
+				Severity: checks.Information,
+			},
+		},
+		{
+			ReportedPath:  "rules/a,b.yml",
+			SourcePath:    "rules/a,b.yml",
+			ModifiedLines: []int{2, 3, 4},
+			Problem: checks.Problem{
+				Lines:    []int{2},
+				Reporter: "promql/series",
+				Text:     "not modified",
+				Severity: checks.Warning,
+			},
+		},
+	}
+	reports[2].ModifiedLines = []int{1}
+
+	testCases := []testCaseT{
+		{
+			format: reporter.ConsoleFormatGitHub,
+			output: `::notice file=rules/a%2Cb.yml,line=2,endLine=2,title=Information%3A promql/series::info
+::error file=rules/a%2Cb.yml,line=3,endLine=4,col=5,endColumn=10,title=Bug%3A promql/series::100%25 of [foo]%0Aisn't present
+`,
+		},
+		{
+			format: reporter.ConsoleFormatAzure,
+			output: `##vso[task.logissue type=warning;sourcepath=rules/a,b.yml;linenumber=2;code=promql/series]Information: info
+##vso[task.logissue type=error;sourcepath=rules/a,b.yml;linenumber=3;code=promql/series]Bug: 100%AZP25 of [foo]%0Aisn't present
+`,
+		},
+		{
+			format: reporter.ConsoleFormatTeamCity,
+			output: `##teamcity[inspectionType id='promql/series' name='promql/series' category='pint' description='https://cloudflare.github.io/pint/checks/promql/series.html']
+##teamcity[inspection typeId='promql/series' message='info' file='rules/a,b.yml' line='2' SEVERITY='INFO']
+##teamcity[inspection typeId='promql/series' message='100% of |[foo|]|nisn|'t present' file='rules/a,b.yml' line='3' SEVERITY='ERROR']
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.format), func(t *testing.T) {
+			buf := bytes.NewBuffer(nil)
+			r := reporter.NewConsoleReporter(buf, checks.Information, tc.format)
+			require.NoError(t, r.Submit(reporter.NewSummary(reports)))
+			require.Equal(t, tc.output, buf.String())
+		})
+	}
+}
+
+func TestConsoleReporterRange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`- record: foo
+  expr: |
+    sum(
+      foo{job=~"bar"}
+    ) by (job)
+- record: bar
+  expr: sum(bar) by(
+`), 0o644))
+
+	reports := []reporter.Report{
+		{
+			ReportedPath:  path,
+			SourcePath:    path,
+			ModifiedLines: []int{1, 2, 3, 4, 5, 6, 7},
+			Problem: checks.Problem{
+				Lines:    []int{2, 3, 4, 5},
+				Reporter: "promql/regexp",
+				Text:     "unnecessary regexp",
+				Severity: checks.Bug,
+				Range:    &checks.TextRange{Line: 4, Column: 7, EndLine: 4, EndColumn: 22},
+			},
+		},
+		{
+			ReportedPath:  path,
+			SourcePath:    path,
+			ModifiedLines: []int{1, 2, 3, 4, 5, 6, 7},
+			Problem: checks.Problem{
+				Lines:    []int{2, 3, 4, 5},
+				Reporter: "promql/aggregate",
+				Text:     "job label should be removed",
+				Severity: checks.Warning,
+				Range:    &checks.TextRange{Line: 3, Column: 5, EndLine: 5, EndColumn: 15},
+			},
+		},
+		{
+			ReportedPath:  path,
+			SourcePath:    path,
+			ModifiedLines: []int{1, 2, 3, 4, 5, 6, 7},
+			Problem: checks.Problem{
+				Lines:    []int{7},
+				Reporter: "promql/syntax",
+				Text:     "syntax error: unclosed left parenthesis",
+				Severity: checks.Fatal,
+				Range:    &checks.TextRange{Line: 7, Column: 21, EndLine: 7, EndColumn: 21},
+			},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	r := reporter.NewConsoleReporter(buf, checks.Information, reporter.ConsoleFormatText)
+	require.NoError(t, r.Submit(reporter.NewSummary(reports)))
+	require.Equal(t, path+`:2-5 Warning: job label should be removed (promql/aggregate)
+ 2 |   expr: |
+ 3 |     sum(
+   |     ^^^^
+ 4 |       foo{job=~"bar"}
+   |       ^^^^^^^^^^^^^^^
+ 5 |     ) by (job)
+   |     ^^^^^^^^^^
+
+`+path+`:2-5 Bug: unnecessary regexp (promql/regexp)
+ 2 |   expr: |
+ 3 |     sum(
+ 4 |       foo{job=~"bar"}
+   |       ^^^^^^^^^^^^^^^
+ 5 |     ) by (job)
+
+`+path+`:7 Fatal: syntax error: unclosed left parenthesis (promql/syntax)
+ 7 |   expr: sum(bar) by(
+   |                     ^
+
+`, buf.String())
+}
+
+func TestParseConsoleFormat(t *testing.T) {
+	for _, f := range reporter.ConsoleFormats {
+		format, err := reporter.ParseConsoleFormat(string(f))
+		require.NoError(t, err)
+		require.Equal(t, f, format)
+	}
+
+	_, err := reporter.ParseConsoleFormat("xml")
+	require.EqualError(t, err, `unknown format: "xml", must be one of: text, github, azure, teamcity`)
+}
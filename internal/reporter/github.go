@@ -255,7 +255,7 @@ func formatGHReviewBody(version string, summary Summary) string {
 	b.WriteString("<details><summary>Problems</summary>\n<p>\n\n")
 	if summary.Entries > 0 {
 		buf := bytes.NewBuffer(nil)
-		cr := NewConsoleReporter(buf, checks.Information)
+		cr := NewConsoleReporter(buf, checks.Information, ConsoleFormatText)
 		err := cr.Submit(summary)
 		if err != nil {
 			b.WriteString(fmt.Sprintf("Failed to generate list of problems: %s", err))
@@ -283,9 +283,9 @@ func reportToGitHubComment(headCommit string, rep Report) *github.PullRequestCom
 		CommitID: github.String(headCommit),
 		Path:     github.String(rep.ReportedPath),
 		Body: github.String(fmt.Sprintf(
-			"[%s](https://cloudflare.github.io/pint/checks/%s.html): %s%s",
-			rep.Problem.Reporter,
+			"[%s](%s): %s%s",
 			rep.Problem.Reporter,
+			CheckDocsURL(rep.Problem.Reporter),
 			msgPrefix,
 			rep.Problem.Text,
 		)),
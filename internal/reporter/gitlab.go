@@ -0,0 +1,300 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/git"
+	"github.com/cloudflare/pint/internal/output"
+)
+
+type gitLabDiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	HeadSHA  string `json:"head_sha"`
+	StartSHA string `json:"start_sha"`
+}
+
+type gitLabMergeRequest struct {
+	IID      int            `json:"iid"`
+	DiffRefs gitLabDiffRefs `json:"diff_refs"`
+}
+
+type gitLabPosition struct {
+	PositionType string `json:"position_type"`
+	BaseSHA      string `json:"base_sha,omitempty"`
+	HeadSHA      string `json:"head_sha,omitempty"`
+	StartSHA     string `json:"start_sha,omitempty"`
+	OldPath      string `json:"old_path,omitempty"`
+	NewPath      string `json:"new_path"`
+	NewLine      int    `json:"new_line"`
+}
+
+type gitLabNote struct {
+	ID         int             `json:"id"`
+	Body       string          `json:"body"`
+	Resolvable bool            `json:"resolvable"`
+	Resolved   bool            `json:"resolved"`
+	Position   *gitLabPosition `json:"position,omitempty"`
+}
+
+type gitLabDiscussion struct {
+	ID    string       `json:"id"`
+	Notes []gitLabNote `json:"notes"`
+}
+
+type gitLabNewDiscussion struct {
+	Body     string         `json:"body"`
+	Position gitLabPosition `json:"position"`
+}
+
+type gitLabCommitStatus struct {
+	State       string `json:"state"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func NewGitLabReporter(version, uri string, timeout time.Duration, token, project string, mrIID int, gitCmd git.CommandRunner) GitLabReporter {
+	return GitLabReporter{
+		version:   version,
+		uri:       strings.TrimSuffix(uri, "/"),
+		timeout:   timeout,
+		authToken: token,
+		project:   project,
+		mrIID:     mrIID,
+		gitCmd:    gitCmd,
+	}
+}
+
+// GitLabReporter reports problems as discussions on GitLab merge request
+// diff lines and sets a commit status with the result of all checks.
+// https://docs.gitlab.com/ee/api/discussions.html#merge-requests
+type GitLabReporter struct {
+	version   string
+	uri       string
+	timeout   time.Duration
+	authToken string
+	project   string
+	mrIID     int
+	gitCmd    git.CommandRunner
+}
+
+func (gl GitLabReporter) Submit(summary Summary) error {
+	headCommit, err := git.HeadCommit(gl.gitCmd)
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD commit: %w", err)
+	}
+	log.Info().Str("commit", headCommit).Msg("Got HEAD commit from git")
+
+	mr, err := gl.getMergeRequest()
+	if err != nil {
+		return fmt.Errorf("failed to get merge request details: %w", err)
+	}
+
+	existing, err := gl.getDiscussions()
+	if err != nil {
+		return fmt.Errorf("failed to list merge request discussions: %w", err)
+	}
+
+	var isFailing bool
+	var problems int
+	kept := map[string]struct{}{}
+	for _, report := range summary.Reports() {
+		if !shouldReport(report) {
+			log.Debug().
+				Str("path", report.SourcePath).
+				Str("lines", output.FormatLineRangeString(report.Problem.Lines)).
+				Msg("Problem reported on unmodified line, skipping")
+			continue
+		}
+		problems++
+		if report.Problem.Severity >= checks.Bug {
+			isFailing = true
+		}
+
+		discussion := reportToGitLabDiscussion(mr.DiffRefs, report)
+		if d, ok := findGitLabDiscussion(existing, discussion); ok {
+			log.Debug().Str("body", discussion.Body).Str("discussion", d.ID).Msg("Discussion already exist")
+			kept[d.ID] = struct{}{}
+			continue
+		}
+		if err = gl.createDiscussion(discussion); err != nil {
+			return fmt.Errorf("failed to create merge request discussion: %w", err)
+		}
+	}
+
+	for _, d := range existing {
+		if _, ok := kept[d.ID]; ok {
+			continue
+		}
+		if !isPintDiscussion(d) || d.Notes[0].Resolved {
+			continue
+		}
+		if err = gl.resolveDiscussion(d.ID); err != nil {
+			return fmt.Errorf("failed to resolve stale merge request discussion: %w", err)
+		}
+	}
+
+	return gl.setCommitStatus(headCommit, isFailing, problems)
+}
+
+func (gl GitLabReporter) projectURL(format string, args ...any) string {
+	return fmt.Sprintf("%s/api/v4/projects/%s", gl.uri, url.PathEscape(gl.project)) + fmt.Sprintf(format, args...)
+}
+
+func (gl GitLabReporter) getMergeRequest() (mr gitLabMergeRequest, err error) {
+	body, _, err := gl.gitLabRequest(http.MethodGet, gl.projectURL("/merge_requests/%d", gl.mrIID), nil)
+	if err != nil {
+		return mr, err
+	}
+	if err = json.Unmarshal(body, &mr); err != nil {
+		return mr, fmt.Errorf("failed to decode merge request: %w", err)
+	}
+	return mr, nil
+}
+
+func (gl GitLabReporter) getDiscussions() (discussions []gitLabDiscussion, err error) {
+	page := "1"
+	for page != "" {
+		body, headers, err := gl.gitLabRequest(
+			http.MethodGet,
+			gl.projectURL("/merge_requests/%d/discussions?per_page=100&page=%s", gl.mrIID, page),
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+		var ds []gitLabDiscussion
+		if err = json.Unmarshal(body, &ds); err != nil {
+			return nil, fmt.Errorf("failed to decode discussions: %w", err)
+		}
+		discussions = append(discussions, ds...)
+		page = headers.Get("X-Next-Page")
+	}
+	return discussions, nil
+}
+
+func (gl GitLabReporter) createDiscussion(discussion gitLabNewDiscussion) error {
+	log.Debug().Str("body", discussion.Body).Str("path", discussion.Position.NewPath).Int("line", discussion.Position.NewLine).Msg("Creating merge request discussion")
+	payload, _ := json.Marshal(discussion)
+	_, _, err := gl.gitLabRequest(http.MethodPost, gl.projectURL("/merge_requests/%d/discussions", gl.mrIID), payload)
+	return err
+}
+
+func (gl GitLabReporter) resolveDiscussion(id string) error {
+	log.Debug().Str("discussion", id).Msg("Resolving stale merge request discussion")
+	payload, _ := json.Marshal(map[string]bool{"resolved": true})
+	_, _, err := gl.gitLabRequest(http.MethodPut, gl.projectURL("/merge_requests/%d/discussions/%s", gl.mrIID, id), payload)
+	return err
+}
+
+func (gl GitLabReporter) setCommitStatus(commit string, isFailing bool, problems int) error {
+	status := gitLabCommitStatus{
+		State:       "success",
+		Name:        "pint",
+		Description: "No problems found",
+	}
+	if problems > 0 {
+		status.Description = fmt.Sprintf("Problems found: %d", problems)
+	}
+	if isFailing {
+		status.State = "failed"
+	}
+	log.Info().Str("commit", commit).Str("state", status.State).Msg("Setting commit status")
+	payload, _ := json.Marshal(status)
+	if _, _, err := gl.gitLabRequest(http.MethodPost, gl.projectURL("/statuses/%s", commit), payload); err != nil {
+		return fmt.Errorf("failed to set commit status: %w", err)
+	}
+	return nil
+}
+
+func (gl GitLabReporter) gitLabRequest(method, url string, body []byte) ([]byte, http.Header, error) {
+	log.Debug().Str("url", url).Str("method", method).Msg("Sending a request to GitLab")
+	log.Debug().Bytes("body", body).Msg("Request payload")
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("PRIVATE-TOKEN", gl.authToken)
+
+	netClient := &http.Client{
+		Timeout: gl.timeout,
+	}
+
+	resp, err := netClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Debug().Int("status", resp.StatusCode).Msg("GitLab request completed")
+	if resp.StatusCode >= 300 {
+		log.Error().Bytes("body", respBody).Str("url", url).Int("code", resp.StatusCode).Msg("Got a non 2xx response")
+		return nil, nil, fmt.Errorf("%s request failed with %s", method, resp.Status)
+	}
+
+	return respBody, resp.Header, nil
+}
+
+func reportToGitLabDiscussion(refs gitLabDiffRefs, report Report) gitLabNewDiscussion {
+	var msgPrefix string
+	reportLine, srcLine := moveReportedLine(report)
+	if reportLine != srcLine {
+		msgPrefix = fmt.Sprintf("Problem reported on unmodified line %d, annotation moved here: ", srcLine)
+	}
+
+	return gitLabNewDiscussion{
+		Body: fmt.Sprintf(
+			"[%s](%s): %s%s",
+			report.Problem.Reporter,
+			CheckDocsURL(report.Problem.Reporter),
+			msgPrefix,
+			report.Problem.Text,
+		),
+		Position: gitLabPosition{
+			PositionType: "text",
+			BaseSHA:      refs.BaseSHA,
+			HeadSHA:      refs.HeadSHA,
+			StartSHA:     refs.StartSHA,
+			OldPath:      report.ReportedPath,
+			NewPath:      report.ReportedPath,
+			NewLine:      reportLine,
+		},
+	}
+}
+
+// isPintDiscussion returns true if given discussion was started by pint.
+func isPintDiscussion(d gitLabDiscussion) bool {
+	if len(d.Notes) == 0 || d.Notes[0].Position == nil {
+		return false
+	}
+	body := d.Notes[0].Body
+	return strings.HasPrefix(body, "[") && strings.Contains(body, "]("+checkDocsPrefix)
+}
+
+func findGitLabDiscussion(discussions []gitLabDiscussion, nd gitLabNewDiscussion) (gitLabDiscussion, bool) {
+	for _, d := range discussions {
+		if !isPintDiscussion(d) || d.Notes[0].Resolved {
+			continue
+		}
+		note := d.Notes[0]
+		if note.Body == nd.Body && note.Position.NewPath == nd.Position.NewPath && note.Position.NewLine == nd.Position.NewLine {
+			return d, true
+		}
+	}
+	return gitLabDiscussion{}, false
+}
@@ -0,0 +1,229 @@
+package reporter_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/git"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+type gitLabRequest struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+type gitLabMock struct {
+	mtx         sync.Mutex
+	token       string
+	discussions string
+	requests    []gitLabRequest
+}
+
+func (m *gitLabMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if r.Header.Get("PRIVATE-TOKEN") != m.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"401 Unauthorized"}`))
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v4/projects/1/merge_requests/2":
+		_, _ = w.Write([]byte(`{"iid":2,"diff_refs":{"base_sha":"base","head_sha":"head","start_sha":"start"}}`))
+		return
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v4/projects/1/merge_requests/2/discussions":
+		_, _ = w.Write([]byte(m.discussions))
+		return
+	}
+
+	m.requests = append(m.requests, gitLabRequest{Method: r.Method, Path: r.URL.Path, Body: string(body)})
+	_, _ = w.Write([]byte(`{}`))
+}
+
+func TestGitLabReporter(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	type testCaseT struct {
+		description string
+		reports     []reporter.Report
+		discussions string
+		token       string
+		timeout     time.Duration
+		httpHandler http.Handler
+		requests    []gitLabRequest
+		error       string
+	}
+
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+- record: target is down
+  expr: up == 0
+- record: sum errors
+  expr: sum(errors) by (job)
+`))
+
+	gitCmd := func(args ...string) ([]byte, error) {
+		if args[0] == "rev-parse" {
+			return []byte("fake-commit-id"), nil
+		}
+		return nil, nil
+	}
+
+	fooReport := reporter.Report{
+		ReportedPath:  "foo.yml",
+		SourcePath:    "foo.yml",
+		ModifiedLines: []int{2, 3},
+		Rule:          mockRules[0],
+		Problem: checks.Problem{
+			Fragment: "up == 0",
+			Lines:    []int{3},
+			Reporter: "mock",
+			Text:     "mock problem",
+			Severity: checks.Bug,
+		},
+	}
+	fooDiscussion := gitLabRequest{
+		Method: http.MethodPost,
+		Path:   "/api/v4/projects/1/merge_requests/2/discussions",
+		Body:   `{"body":"[mock](https://cloudflare.github.io/pint/checks/mock.html): mock problem","position":{"position_type":"text","base_sha":"base","head_sha":"head","start_sha":"start","old_path":"foo.yml","new_path":"foo.yml","new_line":3}}`,
+	}
+	existingFoo := `{"id":"d1","notes":[{"id":1,"body":"[mock](https://cloudflare.github.io/pint/checks/mock.html): mock problem","resolvable":true,"resolved":false,"position":{"position_type":"text","new_path":"foo.yml","new_line":3}}]}`
+	staleBar := `{"id":"d2","notes":[{"id":2,"body":"[mock](https://cloudflare.github.io/pint/checks/mock.html): old problem","resolvable":true,"resolved":false,"position":{"position_type":"text","new_path":"bar.yml","new_line":1}}]}`
+	resolvedBar := `{"id":"d3","notes":[{"id":3,"body":"[mock](https://cloudflare.github.io/pint/checks/mock.html): old problem","resolvable":true,"resolved":true,"position":{"position_type":"text","new_path":"bar.yml","new_line":1}}]}`
+	userNote := `{"id":"d4","notes":[{"id":4,"body":"LGTM","resolvable":true,"resolved":false,"position":{"position_type":"text","new_path":"foo.yml","new_line":3}}]}`
+
+	for _, tc := range []testCaseT{
+		{
+			description: "no problems",
+			token:       "secret",
+			timeout:     time.Second,
+			discussions: "[]",
+			requests: []gitLabRequest{
+				{Method: http.MethodPost, Path: "/api/v4/projects/1/statuses/fake-commit-id", Body: `{"state":"success","name":"pint","description":"No problems found"}`},
+			},
+		},
+		{
+			description: "creates new discussions",
+			token:       "secret",
+			timeout:     time.Second,
+			discussions: "[]",
+			reports: []reporter.Report{
+				fooReport,
+				{
+					ReportedPath:  "foo.yml",
+					SourcePath:    "foo.yml",
+					ModifiedLines: []int{2, 3},
+					Rule:          mockRules[1],
+					Problem: checks.Problem{
+						Lines:    []int{5},
+						Reporter: "mock",
+						Text:     "problem on unmodified line",
+						Severity: checks.Warning,
+					},
+				},
+			},
+			requests: []gitLabRequest{
+				fooDiscussion,
+				{Method: http.MethodPost, Path: "/api/v4/projects/1/statuses/fake-commit-id", Body: `{"state":"failed","name":"pint","description":"Problems found: 1"}`},
+			},
+		},
+		{
+			description: "moves problems to modified lines",
+			token:       "secret",
+			timeout:     time.Second,
+			discussions: "[]",
+			reports: []reporter.Report{
+				{
+					ReportedPath:  "foo.yml",
+					SourcePath:    "foo.yml",
+					ModifiedLines: []int{3},
+					Rule:          mockRules[0],
+					Problem: checks.Problem{
+						Lines:    []int{2, 3},
+						Reporter: "mock",
+						Text:     "mock warning",
+						Severity: checks.Warning,
+					},
+				},
+			},
+			requests: []gitLabRequest{
+				{
+					Method: http.MethodPost,
+					Path:   "/api/v4/projects/1/merge_requests/2/discussions",
+					Body:   `{"body":"[mock](https://cloudflare.github.io/pint/checks/mock.html): mock warning","position":{"position_type":"text","base_sha":"base","head_sha":"head","start_sha":"start","old_path":"foo.yml","new_path":"foo.yml","new_line":3}}`,
+				},
+				{Method: http.MethodPost, Path: "/api/v4/projects/1/statuses/fake-commit-id", Body: `{"state":"success","name":"pint","description":"Problems found: 1"}`},
+			},
+		},
+		{
+			description: "skips existing and resolves stale discussions",
+			token:       "secret",
+			timeout:     time.Second,
+			discussions: fmt.Sprintf("[%s,%s,%s,%s]", existingFoo, staleBar, resolvedBar, userNote),
+			reports:     []reporter.Report{fooReport},
+			requests: []gitLabRequest{
+				{Method: http.MethodPut, Path: "/api/v4/projects/1/merge_requests/2/discussions/d2", Body: `{"resolved":true}`},
+				{Method: http.MethodPost, Path: "/api/v4/projects/1/statuses/fake-commit-id", Body: `{"state":"failed","name":"pint","description":"Problems found: 1"}`},
+			},
+		},
+		{
+			description: "invalid token",
+			token:       "bogus",
+			timeout:     time.Second,
+			discussions: "[]",
+			reports:     []reporter.Report{fooReport},
+			error:       "failed to get merge request details: GET request failed with 401 Unauthorized",
+		},
+		{
+			description: "timeout errors out",
+			token:       "secret",
+			timeout:     100 * time.Millisecond,
+			httpHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(time.Second)
+				_, _ = w.Write([]byte("{}"))
+			}),
+			reports: []reporter.Report{fooReport},
+			error:   "failed to get merge request details: ",
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			mock := &gitLabMock{token: "secret", discussions: tc.discussions}
+			var handler http.Handler = mock
+			if tc.httpHandler != nil {
+				handler = tc.httpHandler
+			}
+			srv := httptest.NewServer(handler)
+			defer srv.Close()
+
+			r := reporter.NewGitLabReporter("v0.999", srv.URL, tc.timeout, tc.token, "1", 2, git.CommandRunner(gitCmd))
+			err := r.Submit(reporter.NewSummary(tc.reports))
+			switch {
+			case tc.error == "":
+				require.NoError(t, err)
+			case tc.httpHandler != nil:
+				require.ErrorContains(t, err, tc.error)
+			default:
+				require.EqualError(t, err, tc.error)
+			}
+
+			if tc.httpHandler == nil {
+				require.Equal(t, tc.requests, mock.requests)
+			}
+		})
+	}
+}
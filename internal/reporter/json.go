@@ -24,23 +24,15 @@ type JSONReport struct {
 }
 
 type JSONReportRule struct {
-	Name string `json:"name"`
-	Type string `json:"type"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Group string `json:"group,omitempty"`
 }
 
 func (cr JSONReporter) Submit(reports []Report) error {
 	jsonReports := make([]JSONReport, 0, len(reports))
 	for _, report := range reports {
-		jsonReports = append(jsonReports, JSONReport{
-			ReportedPath: report.ReportedPath,
-			SourcePath:   report.SourcePath,
-			Owner:        report.Owner,
-			Problem:      report.Problem,
-			Rule: JSONReportRule{
-				Name: report.Rule.Name(),
-				Type: string(report.Rule.Type()),
-			},
-		})
+		jsonReports = append(jsonReports, NewJSONReport(report))
 	}
 	result, err := json.Marshal(jsonReports)
 	if err != nil {
@@ -54,3 +46,22 @@ func (cr JSONReporter) Submit(reports []Report) error {
 	_, err = f.WriteString(string(result))
 	return err
 }
+
+// NewJSONReport converts a report into the structure used for JSON output.
+func NewJSONReport(report Report) JSONReport {
+	var group string
+	if report.Rule.Group != nil {
+		group = report.Rule.Group.GetName()
+	}
+	return JSONReport{
+		ReportedPath: report.ReportedPath,
+		SourcePath:   report.SourcePath,
+		Owner:        report.Owner,
+		Problem:      report.Problem,
+		Rule: JSONReportRule{
+			Name:  report.Rule.Name(),
+			Type:  string(report.Rule.Type()),
+			Group: group,
+		},
+	}
+}
@@ -47,3 +47,65 @@ func TestJSONReporter(t *testing.T) {
 	expected := "[{\"reportedPath\":\"\",\"sourcePath\":\"foo.txt\",\"rule\":{\"name\":\"sum errors\",\"type\":\"recording\"},\"problem\":{\"Fragment\":\"syntax error\",\"Lines\":[2],\"Reporter\":\"mock\",\"Text\":\"syntax error\",\"Severity\":\"Fatal\"},\"owner\":\"\"}]"
 	require.Equal(t, expected, string(byteValue))
 }
+
+func TestJSONReporterGroup(t *testing.T) {
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+groups:
+- name: mygroup
+  rules:
+  - record: target is down
+    expr: up == 0
+`))
+	reports := []reporter.Report{
+		{
+			SourcePath:    "foo.txt",
+			ModifiedLines: []int{5},
+			Rule:          mockRules[0],
+			Problem: checks.Problem{
+				Fragment: "up == 0",
+				Lines:    []int{6},
+				Reporter: "mock",
+				Text:     "mock problem",
+				Severity: checks.Bug,
+			},
+		},
+	}
+	path := filepath.Join(t.TempDir(), "json-reporter-test.json")
+	jsonReporter := reporter.NewJSONReporter(path)
+	require.NoError(t, jsonReporter.Submit(reports))
+	byteValue, err := os.ReadFile(path)
+	require.NoError(t, err, "Error reading json")
+	expected := "[{\"reportedPath\":\"\",\"sourcePath\":\"foo.txt\",\"rule\":{\"name\":\"target is down\",\"type\":\"recording\",\"group\":\"mygroup\"},\"problem\":{\"Fragment\":\"up == 0\",\"Lines\":[6],\"Reporter\":\"mock\",\"Text\":\"mock problem\",\"Severity\":\"Bug\"},\"owner\":\"\"}]"
+	require.Equal(t, expected, string(byteValue))
+}
+
+func TestJSONReporterRange(t *testing.T) {
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+- record: sum errors
+  expr: sum(errors) by (job)
+`))
+	reports := []reporter.Report{
+		{
+			SourcePath:    "foo.txt",
+			ModifiedLines: []int{2, 3},
+			Rule:          mockRules[0],
+			Problem: checks.Problem{
+				Fragment: "sum(errors) by (job)",
+				Lines:    []int{2, 3},
+				Reporter: "mock",
+				Text:     "mock problem",
+				Severity: checks.Bug,
+				Range:    &checks.TextRange{Line: 3, Column: 9, EndLine: 3, EndColumn: 29},
+			},
+		},
+	}
+	path := filepath.Join(t.TempDir(), "json-reporter-test.json")
+	jsonReporter := reporter.NewJSONReporter(path)
+	require.NoError(t, jsonReporter.Submit(reports))
+	byteValue, err := os.ReadFile(path)
+	require.NoError(t, err, "Error reading json")
+	expected := "[{\"reportedPath\":\"\",\"sourcePath\":\"foo.txt\",\"rule\":{\"name\":\"sum errors\",\"type\":\"recording\"},\"problem\":{\"Fragment\":\"sum(errors) by (job)\",\"Lines\":[2,3],\"Reporter\":\"mock\",\"Text\":\"mock problem\",\"Severity\":\"Bug\",\"Range\":{\"Line\":3,\"Column\":9,\"EndLine\":3,\"EndColumn\":29}},\"owner\":\"\"}]"
+	require.Equal(t, expected, string(byteValue))
+}
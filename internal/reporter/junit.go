@@ -0,0 +1,161 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/output"
+)
+
+func NewJUnitReporter(path string) JUnitReporter {
+	return JUnitReporter{path: path}
+}
+
+// JUnitReporter writes all reports to a file using JUnit XML format.
+// Every rule file is reported as a test suite and every rule as a test case,
+// problems reported for a rule are added to its test case as failures.
+type JUnitReporter struct {
+	path string
+}
+
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+type JUnitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []JUnitTestCase `xml:"testcase"`
+}
+
+type JUnitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	File      string         `xml:"file,attr"`
+	Line      int            `xml:"line,attr,omitempty"`
+	Failures  []JUnitFailure `xml:"failure"`
+	SystemOut string         `xml:"system-out,omitempty"`
+
+	lines []int
+}
+
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (jr JUnitReporter) Submit(summary Summary) error {
+	result := JUnitTestSuites{
+		Name: "pint",
+		Time: fmt.Sprintf("%.3f", summary.Duration.Seconds()),
+	}
+
+	suiteIndex := map[string]int{}
+	suite := func(path string) *JUnitTestSuite {
+		idx, ok := suiteIndex[path]
+		if !ok {
+			idx = len(result.Suites)
+			suiteIndex[path] = idx
+			result.Suites = append(result.Suites, JUnitTestSuite{Name: path, Cases: []JUnitTestCase{}})
+		}
+		return &result.Suites[idx]
+	}
+
+	for _, rule := range summary.Rules() {
+		ts := suite(rule.ReportedPath)
+		ts.Cases = append(ts.Cases, junitTestCase(rule.ReportedPath, rule.Rule.Name(), rule.Rule.Lines()))
+	}
+
+	for _, report := range summary.Reports() {
+		ts := suite(report.ReportedPath)
+		idx := slices.IndexFunc(ts.Cases, func(tc JUnitTestCase) bool {
+			return slices.Equal(tc.lines, report.Rule.Lines())
+		})
+		if idx < 0 {
+			// Problems not attached to any checked rule, like file parse errors.
+			idx = len(ts.Cases)
+			ts.Cases = append(ts.Cases, junitTestCase(report.ReportedPath, report.Rule.Name(), report.Rule.Lines()))
+		}
+
+		tc := &ts.Cases[idx]
+		if report.Problem.Severity == checks.Information {
+			if tc.SystemOut != "" {
+				tc.SystemOut += "\n"
+			}
+			tc.SystemOut += junitProblemText(report)
+			continue
+		}
+		tc.Failures = append(tc.Failures, JUnitFailure{
+			Message: report.Problem.Text,
+			Type:    report.Problem.Reporter,
+			Text:    junitProblemText(report),
+		})
+	}
+
+	for i := range result.Suites {
+		ts := &result.Suites[i]
+		ts.Tests = len(ts.Cases)
+		for _, tc := range ts.Cases {
+			if len(tc.Failures) > 0 {
+				ts.Failures++
+			}
+		}
+		result.Tests += ts.Tests
+		result.Failures += ts.Failures
+	}
+
+	content, err := xml.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(jr.path, append([]byte(xml.Header), append(content, '\n')...), 0o644)
+}
+
+func junitTestCase(path, name string, lines []int) JUnitTestCase {
+	if name == "" {
+		name = path
+	}
+	tc := JUnitTestCase{
+		Name:      name,
+		ClassName: path,
+		File:      path,
+		lines:     lines,
+	}
+	if len(lines) > 0 {
+		tc.Line = lines[0]
+		for _, line := range lines {
+			tc.Line = min(tc.Line, line)
+		}
+		tc.Name = fmt.Sprintf("%s:%s", name, output.FormatLineRangeString(lines))
+	}
+	return tc
+}
+
+func junitProblemText(report Report) string {
+	var b strings.Builder
+	b.WriteString("Check: ")
+	b.WriteString(report.Problem.Reporter)
+	b.WriteString("\nSeverity: ")
+	b.WriteString(report.Problem.Severity.String())
+	if len(report.Problem.Lines) > 0 {
+		b.WriteString("\nLines: ")
+		b.WriteString(output.FormatLineRangeString(report.Problem.Lines))
+	}
+	b.WriteString("\nDocs: ")
+	b.WriteString(CheckDocsURL(report.Problem.Reporter))
+	b.WriteString("\n\n")
+	b.WriteString(report.Problem.Text)
+	return b.String()
+}
@@ -0,0 +1,144 @@
+package reporter_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+func TestJUnitReporter(t *testing.T) {
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+- record: target is down
+  expr: up == 0
+- record: sum errors
+  expr: sum(errors) by (job)
+`))
+
+	type testCaseT struct {
+		description string
+		rules       []reporter.CheckedRule
+		reports     []reporter.Report
+		output      string
+	}
+
+	testCases := []testCaseT{
+		{
+			description: "no rules",
+			output: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="pint" tests="0" failures="0" time="1.500"></testsuites>
+`,
+		},
+		{
+			description: "rules without problems",
+			rules: []reporter.CheckedRule{
+				{ReportedPath: "foo.yml", SourcePath: "foo.yml", Rule: mockRules[0]},
+				{ReportedPath: "foo.yml", SourcePath: "foo.yml", Rule: mockRules[1]},
+			},
+			output: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="pint" tests="2" failures="0" time="1.500">
+  <testsuite name="foo.yml" tests="2" failures="0">
+    <testcase name="target is down:2-3" classname="foo.yml" file="foo.yml" line="2"></testcase>
+    <testcase name="sum errors:4-5" classname="foo.yml" file="foo.yml" line="4"></testcase>
+  </testsuite>
+</testsuites>
+`,
+		},
+		{
+			description: "multiple reports",
+			rules: []reporter.CheckedRule{
+				{ReportedPath: "foo.yml", SourcePath: "foo.yml", Rule: mockRules[0]},
+				{ReportedPath: "foo.yml", SourcePath: "foo.yml", Rule: mockRules[1]},
+				{ReportedPath: "bar.yml", SourcePath: "bar.yml", Rule: mockRules[1]},
+			},
+			reports: []reporter.Report{
+				{
+					ReportedPath: "foo.yml",
+					SourcePath:   "foo.yml",
+					Rule:         mockRules[1],
+					Problem: checks.Problem{
+						Lines:    []int{4, 5},
+						Reporter: "promql/aggregate",
+						Text:     "job label should be removed",
+						Severity: checks.Warning,
+					},
+				},
+				{
+					ReportedPath: "foo.yml",
+					SourcePath:   "foo.yml",
+					Rule:         mockRules[1],
+					Problem: checks.Problem{
+						Lines:    []int{5},
+						Reporter: "promql/series",
+						Text:     "errors metric is missing",
+						Severity: checks.Bug,
+					},
+				},
+				{
+					ReportedPath: "bar.yml",
+					SourcePath:   "bar.yml",
+					Rule:         mockRules[1],
+					Problem: checks.Problem{
+						Lines:    []int{5},
+						Reporter: "promql/aggregate",
+						Text:     "info problem",
+						Severity: checks.Information,
+					},
+				},
+				{
+					ReportedPath: "broken.yml",
+					SourcePath:   "broken.yml",
+					Problem: checks.Problem{
+						Lines:    []int{1},
+						Reporter: "yaml/parse",
+						Text:     "mapping values are not allowed in this context",
+						Severity: checks.Fatal,
+					},
+				},
+			},
+			output: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="pint" tests="4" failures="2" time="1.500">
+  <testsuite name="foo.yml" tests="2" failures="1">
+    <testcase name="target is down:2-3" classname="foo.yml" file="foo.yml" line="2"></testcase>
+    <testcase name="sum errors:4-5" classname="foo.yml" file="foo.yml" line="4">
+      <failure message="job label should be removed" type="promql/aggregate">Check: promql/aggregate&#xA;Severity: Warning&#xA;Lines: 4-5&#xA;Docs: https://cloudflare.github.io/pint/checks/promql/aggregate.html&#xA;&#xA;job label should be removed</failure>
+      <failure message="errors metric is missing" type="promql/series">Check: promql/series&#xA;Severity: Bug&#xA;Lines: 5&#xA;Docs: https://cloudflare.github.io/pint/checks/promql/series.html&#xA;&#xA;errors metric is missing</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="bar.yml" tests="1" failures="0">
+    <testcase name="sum errors:4-5" classname="bar.yml" file="bar.yml" line="4">
+      <system-out>Check: promql/aggregate&#xA;Severity: Information&#xA;Lines: 5&#xA;Docs: https://cloudflare.github.io/pint/checks/promql/aggregate.html&#xA;&#xA;info problem</system-out>
+    </testcase>
+  </testsuite>
+  <testsuite name="broken.yml" tests="1" failures="1">
+    <testcase name="broken.yml" classname="broken.yml" file="broken.yml">
+      <failure message="mapping values are not allowed in this context" type="yaml/parse">Check: yaml/parse&#xA;Severity: Fatal&#xA;Lines: 1&#xA;Docs: https://cloudflare.github.io/pint/checks/yaml/parse.html&#xA;&#xA;mapping values are not allowed in this context</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			summary := reporter.NewSummary(tc.reports)
+			summary.AddRules(tc.rules...)
+			summary.Duration = time.Millisecond * 1500
+
+			path := filepath.Join(t.TempDir(), "junit.xml")
+			require.NoError(t, reporter.NewJUnitReporter(path).Submit(summary))
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, tc.output, string(content))
+		})
+	}
+}
@@ -9,6 +9,13 @@ import (
 	"github.com/cloudflare/pint/internal/parser"
 )
 
+const checkDocsPrefix = "https://cloudflare.github.io/pint/checks/"
+
+// CheckDocsURL returns the link to the documentation page of given check.
+func CheckDocsURL(name string) string {
+	return checkDocsPrefix + name + ".html"
+}
+
 type Report struct {
 	ReportedPath  string
 	SourcePath    string
@@ -46,12 +53,21 @@ func (r Report) isEqual(nr Report) bool {
 	return true
 }
 
+// CheckedRule is a rule that was checked, with or without any problems.
+type CheckedRule struct {
+	ReportedPath string
+	SourcePath   string
+	Rule         parser.Rule
+	Owner        string
+}
+
 type Summary struct {
 	OfflineChecks int64
 	OnlineChecks  int64
 	Duration      time.Duration
 	Entries       int
 	reports       []Report
+	rules         []CheckedRule
 }
 
 func NewSummary(reports []Report) Summary {
@@ -79,6 +95,15 @@ func (s Summary) Reports() (reports []Report) {
 	return s.reports
 }
 
+func (s *Summary) AddRules(rules ...CheckedRule) {
+	s.rules = append(s.rules, rules...)
+}
+
+// Rules returns all rules that were checked.
+func (s Summary) Rules() []CheckedRule {
+	return s.rules
+}
+
 func (s Summary) HasFatalProblems() bool {
 	for _, r := range s.Reports() {
 		if r.Problem.Severity == checks.Fatal {
@@ -0,0 +1,157 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+func NewSARIFReporter(version, path string) SARIFReporter {
+	return SARIFReporter{version: version, path: path}
+}
+
+// SARIFReporter writes all reports to a file using Static Analysis Results
+// Interchange Format, which is supported by most code scanning dashboards.
+type SARIFReporter struct {
+	version string
+	path    string
+}
+
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+type SARIFRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription SARIFMessage `json:"shortDescription"`
+	Help             SARIFMessage `json:"help"`
+	HelpURI          string       `json:"helpUri"`
+}
+
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations"`
+}
+
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SARIFRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine"`
+}
+
+func (sr SARIFReporter) Submit(reports []Report) error {
+	run := SARIFRun{
+		Tool: SARIFTool{
+			Driver: SARIFDriver{
+				Name:           "pint",
+				Version:        sr.version,
+				InformationURI: "https://cloudflare.github.io/pint/",
+				Rules:          []SARIFRule{},
+			},
+		},
+		Results: []SARIFResult{},
+	}
+
+	ruleIndex := map[string]int{}
+	for _, report := range reports {
+		idx, ok := ruleIndex[report.Problem.Reporter]
+		if !ok {
+			idx = len(run.Tool.Driver.Rules)
+			ruleIndex[report.Problem.Reporter] = idx
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule(report.Problem.Reporter))
+		}
+
+		location := SARIFPhysicalLocation{
+			ArtifactLocation: SARIFArtifactLocation{URI: report.ReportedPath},
+		}
+		if len(report.Problem.Lines) > 0 {
+			startLine, endLine := report.Problem.LineRange()
+			location.Region = &SARIFRegion{StartLine: startLine, EndLine: endLine}
+		}
+
+		run.Results = append(run.Results, SARIFResult{
+			RuleID:    report.Problem.Reporter,
+			RuleIndex: idx,
+			Level:     sarifLevel(report.Problem.Severity),
+			Message:   SARIFMessage{Text: report.Problem.Text},
+			Locations: []SARIFLocation{{PhysicalLocation: location}},
+		})
+	}
+
+	result, err := json.Marshal(SARIFLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []SARIFRun{run},
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(sr.path, result, 0o644)
+}
+
+func sarifRule(name string) SARIFRule {
+	uri := CheckDocsURL(name)
+	return SARIFRule{
+		ID:               name,
+		Name:             name,
+		ShortDescription: SARIFMessage{Text: fmt.Sprintf("Problem reported by %s check", name)},
+		Help:             SARIFMessage{Text: fmt.Sprintf("See %s for details about this check.", uri)},
+		HelpURI:          uri,
+	}
+}
+
+func sarifLevel(s checks.Severity) string {
+	switch s {
+	case checks.Information:
+		return "note"
+	case checks.Warning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,111 @@
+package reporter_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+func TestSARIFReporter(t *testing.T) {
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+- record: target is down
+  expr: up == 0
+- record: sum errors
+  expr: sum(errors) by (job)
+`))
+
+	type testCaseT struct {
+		description string
+		reports     []reporter.Report
+		output      string
+	}
+
+	testCases := []testCaseT{
+		{
+			description: "no reports",
+			output:      `{"$schema":"https://json.schemastore.org/sarif-2.1.0.json","version":"2.1.0","runs":[{"tool":{"driver":{"name":"pint","version":"v0.0.0","informationUri":"https://cloudflare.github.io/pint/","rules":[]}},"results":[]}]}`,
+		},
+		{
+			description: "multiple reports",
+			reports: []reporter.Report{
+				{
+					ReportedPath:  "foo.yml",
+					SourcePath:    "foo.yml",
+					ModifiedLines: []int{4, 5},
+					Rule:          mockRules[1],
+					Problem: checks.Problem{
+						Fragment: "sum(errors) by (job)",
+						Lines:    []int{4, 5},
+						Reporter: "promql/aggregate",
+						Text:     "job label should be removed",
+						Severity: checks.Warning,
+					},
+				},
+				{
+					ReportedPath:  "foo.yml",
+					SourcePath:    "foo.yml",
+					ModifiedLines: []int{2, 3},
+					Rule:          mockRules[0],
+					Problem: checks.Problem{
+						Fragment: "up == 0",
+						Lines:    []int{3},
+						Reporter: "promql/series",
+						Text:     "up metric is missing",
+						Severity: checks.Bug,
+					},
+				},
+				{
+					ReportedPath:  "bar.yml",
+					SourcePath:    "bar.yml",
+					ModifiedLines: []int{4, 5},
+					Rule:          mockRules[1],
+					Problem: checks.Problem{
+						Fragment: "sum(errors) by (job)",
+						Lines:    []int{5},
+						Reporter: "promql/aggregate",
+						Text:     "info problem",
+						Severity: checks.Information,
+					},
+				},
+				{
+					ReportedPath:  "bar.yml",
+					SourcePath:    "bar.yml",
+					ModifiedLines: []int{1},
+					Problem: checks.Problem{
+						Reporter: "yaml/parse",
+						Text:     "fatal problem",
+						Severity: checks.Fatal,
+					},
+				},
+			},
+			output: `{"$schema":"https://json.schemastore.org/sarif-2.1.0.json","version":"2.1.0","runs":[{"tool":{"driver":{"name":"pint","version":"v0.0.0","informationUri":"https://cloudflare.github.io/pint/","rules":[` +
+				`{"id":"promql/aggregate","name":"promql/aggregate","shortDescription":{"text":"Problem reported by promql/aggregate check"},"help":{"text":"See https://cloudflare.github.io/pint/checks/promql/aggregate.html for details about this check."},"helpUri":"https://cloudflare.github.io/pint/checks/promql/aggregate.html"},` +
+				`{"id":"promql/series","name":"promql/series","shortDescription":{"text":"Problem reported by promql/series check"},"help":{"text":"See https://cloudflare.github.io/pint/checks/promql/series.html for details about this check."},"helpUri":"https://cloudflare.github.io/pint/checks/promql/series.html"},` +
+				`{"id":"yaml/parse","name":"yaml/parse","shortDescription":{"text":"Problem reported by yaml/parse check"},"help":{"text":"See https://cloudflare.github.io/pint/checks/yaml/parse.html for details about this check."},"helpUri":"https://cloudflare.github.io/pint/checks/yaml/parse.html"}` +
+				`]}},"results":[` +
+				`{"ruleId":"promql/aggregate","ruleIndex":0,"level":"warning","message":{"text":"job label should be removed"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"foo.yml"},"region":{"startLine":4,"endLine":5}}}]},` +
+				`{"ruleId":"promql/series","ruleIndex":1,"level":"error","message":{"text":"up metric is missing"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"foo.yml"},"region":{"startLine":3,"endLine":3}}}]},` +
+				`{"ruleId":"promql/aggregate","ruleIndex":0,"level":"note","message":{"text":"info problem"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"bar.yml"},"region":{"startLine":5,"endLine":5}}}]},` +
+				`{"ruleId":"yaml/parse","ruleIndex":2,"level":"error","message":{"text":"fatal problem"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"bar.yml"}}}]}` +
+				`]}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "sarif-reporter-test.sarif")
+			r := reporter.NewSARIFReporter("v0.0.0", path)
+			require.NoError(t, r.Submit(tc.reports))
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.output, string(content))
+		})
+	}
+}
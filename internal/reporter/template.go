@@ -0,0 +1,94 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/output"
+)
+
+// ParseTemplate parses a template used to render each report.
+// Trailing newlines are removed, every rendered report is always followed
+// by a single newline.
+func ParseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Parse(strings.TrimRight(text, "\n"))
+}
+
+func NewTemplateReporter(output io.Writer, tmpl *template.Template, minSeverity checks.Severity) TemplateReporter {
+	return TemplateReporter{output: output, tmpl: tmpl, minSeverity: minSeverity}
+}
+
+// TemplateReporter renders every report using a user provided text/template.
+type TemplateReporter struct {
+	output      io.Writer
+	tmpl        *template.Template
+	minSeverity checks.Severity
+}
+
+// TemplateReport is the data passed to templates for each report.
+type TemplateReport struct {
+	Path       string
+	SourcePath string
+	Lines      []int
+	FirstLine  int
+	LastLine   int
+	LineRange  string
+	Rule       TemplateReportRule
+	Owner      string
+	Reporter   string
+	Severity   string
+	Text       string
+	Docs       string
+}
+
+type TemplateReportRule struct {
+	Name  string
+	Type  string
+	Group string
+}
+
+func NewTemplateReport(report Report) TemplateReport {
+	tr := TemplateReport{
+		Path:       report.ReportedPath,
+		SourcePath: report.SourcePath,
+		Lines:      report.Problem.Lines,
+		LineRange:  output.FormatLineRangeString(report.Problem.Lines),
+		Rule: TemplateReportRule{
+			Name: report.Rule.Name(),
+			Type: string(report.Rule.Type()),
+		},
+		Owner:    report.Owner,
+		Reporter: report.Problem.Reporter,
+		Severity: report.Problem.Severity.String(),
+		Text:     report.Problem.Text,
+		Docs:     CheckDocsURL(report.Problem.Reporter),
+	}
+	if len(report.Problem.Lines) > 0 {
+		tr.FirstLine, tr.LastLine = report.Problem.LineRange()
+	}
+	if report.Rule.Group != nil {
+		tr.Rule.Group = report.Rule.Group.GetName()
+	}
+	return tr
+}
+
+func (tr TemplateReporter) Submit(summary Summary) error {
+	reports := summary.Reports()
+	sortReports(reports)
+
+	for _, report := range reports {
+		if report.Problem.Severity < tr.minSeverity || !shouldReport(report) {
+			continue
+		}
+		if err := tr.tmpl.Execute(tr.output, NewTemplateReport(report)); err != nil {
+			return fmt.Errorf("failed to render template: %w", err)
+		}
+		if _, err := io.WriteString(tr.output, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
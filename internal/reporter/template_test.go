@@ -0,0 +1,114 @@
+package reporter_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+func TestTemplateReporter(t *testing.T) {
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+groups:
+- name: mygroup
+  rules:
+  - record: target is down
+    expr: up == 0
+  - alert: errors
+    expr: sum(errors) by (job) > 0
+`))
+
+	reports := []reporter.Report{
+		{
+			ReportedPath:  "foo.yml",
+			SourcePath:    "foo.yml",
+			ModifiedLines: []int{5, 6, 7, 8},
+			Rule:          mockRules[1],
+			Problem: checks.Problem{
+				Lines:    []int{7, 8},
+				Reporter: "promql/aggregate",
+				Text:     "job label should be removed",
+				Severity: checks.Warning,
+			},
+			Owner: "bob",
+		},
+		{
+			ReportedPath:  "foo.yml",
+			SourcePath:    "foo.yml",
+			ModifiedLines: []int{5, 6, 7, 8},
+			Rule:          mockRules[0],
+			Problem: checks.Problem{
+				Lines:    []int{6},
+				Reporter: "promql/series",
+				Text:     "up metric is missing",
+				Severity: checks.Bug,
+			},
+		},
+		{
+			ReportedPath:  "foo.yml",
+			SourcePath:    "foo.yml",
+			ModifiedLines: []int{5, 6, 7, 8},
+			Rule:          mockRules[0],
+			Problem: checks.Problem{
+				Lines:    []int{5},
+				Reporter: "alerts/template",
+				Text:     "info problem",
+				Severity: checks.Information,
+			},
+		},
+	}
+
+	type testCaseT struct {
+		description string
+		template    string
+		minSeverity checks.Severity
+		output      string
+		err         string
+	}
+
+	testCases := []testCaseT{
+		{
+			description: "one line per problem",
+			template:    "{{ .Path }}:{{ .FirstLine }}: {{ .Severity }}: {{ .Text }} ({{ .Reporter }})\n",
+			minSeverity: checks.Warning,
+			output: `foo.yml:6: Bug: up metric is missing (promql/series)
+foo.yml:7: Warning: job label should be removed (promql/aggregate)
+`,
+		},
+		{
+			description: "all fields",
+			template:    `{{ .Path }} {{ .SourcePath }} {{ .Lines }} {{ .FirstLine }}-{{ .LastLine }} {{ .LineRange }} {{ .Rule.Group }}/{{ .Rule.Type }}/{{ .Rule.Name }} owner={{ .Owner }} {{ .Docs }}`,
+			minSeverity: checks.Information,
+			output: `foo.yml foo.yml [5] 5-5 5 mygroup/recording/target is down owner= https://cloudflare.github.io/pint/checks/alerts/template.html
+foo.yml foo.yml [6] 6-6 6 mygroup/recording/target is down owner= https://cloudflare.github.io/pint/checks/promql/series.html
+foo.yml foo.yml [7 8] 7-8 7-8 mygroup/alerting/errors owner=bob https://cloudflare.github.io/pint/checks/promql/aggregate.html
+`,
+		},
+		{
+			description: "bad field",
+			template:    "{{ .Foo }}",
+			err:         `failed to render template: template: test:1:3: executing "test" at <.Foo>: can't evaluate field Foo in type reporter.TemplateReport`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			tmpl, err := reporter.ParseTemplate("test", tc.template)
+			require.NoError(t, err)
+
+			buf := bytes.NewBuffer(nil)
+			err = reporter.NewTemplateReporter(buf, tmpl, tc.minSeverity).Submit(reporter.NewSummary(reports))
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.output, buf.String())
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package ruletest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v3"
+)
+
+// File is a single unit test file using the same format as
+// `promtool test rules`.
+type File struct {
+	Path               string         `yaml:"-"`
+	RuleFiles          []string       `yaml:"rule_files"`
+	EvaluationInterval model.Duration `yaml:"evaluation_interval,omitempty"`
+	GroupEvalOrder     []string       `yaml:"group_eval_order"`
+	Tests              []TestGroup    `yaml:"tests"`
+}
+
+// TestGroup is a group of input series and tests run against them.
+type TestGroup struct {
+	Interval        model.Duration    `yaml:"interval"`
+	InputSeries     []Series          `yaml:"input_series"`
+	AlertRuleTests  []AlertTestCase   `yaml:"alert_rule_test,omitempty"`
+	PromQLExprTests []PromQLTestCase  `yaml:"promql_expr_test,omitempty"`
+	ExternalLabels  map[string]string `yaml:"external_labels,omitempty"`
+	ExternalURL     string            `yaml:"external_url,omitempty"`
+	Name            string            `yaml:"name,omitempty"`
+}
+
+type Series struct {
+	Series string `yaml:"series"`
+	Values string `yaml:"values"`
+}
+
+type AlertTestCase struct {
+	Line      int            `yaml:"-"`
+	EvalTime  model.Duration `yaml:"eval_time"`
+	Alertname string         `yaml:"alertname"`
+	ExpAlerts []Alert        `yaml:"exp_alerts"`
+}
+
+func (tc *AlertTestCase) UnmarshalYAML(value *yaml.Node) error {
+	type plain AlertTestCase
+	if err := value.Decode((*plain)(tc)); err != nil {
+		return err
+	}
+	tc.Line = value.Line
+	return nil
+}
+
+type Alert struct {
+	ExpLabels      map[string]string `yaml:"exp_labels"`
+	ExpAnnotations map[string]string `yaml:"exp_annotations"`
+}
+
+type PromQLTestCase struct {
+	Line       int            `yaml:"-"`
+	Expr       string         `yaml:"expr"`
+	EvalTime   model.Duration `yaml:"eval_time"`
+	ExpSamples []Sample       `yaml:"exp_samples"`
+}
+
+func (tc *PromQLTestCase) UnmarshalYAML(value *yaml.Node) error {
+	type plain PromQLTestCase
+	if err := value.Decode((*plain)(tc)); err != nil {
+		return err
+	}
+	tc.Line = value.Line
+	return nil
+}
+
+type Sample struct {
+	Labels string  `yaml:"labels"`
+	Value  float64 `yaml:"value"`
+}
+
+// Load reads a unit test file. All relative rule file paths are resolved
+// against the directory of the test file and globs are expanded.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f := File{Path: path}
+	if err = yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse unit test file %q: %w", path, err)
+	}
+
+	if f.EvaluationInterval == 0 {
+		f.EvaluationInterval = model.Duration(time.Minute)
+	}
+
+	seen := map[string]struct{}{}
+	for _, name := range f.GroupEvalOrder {
+		if _, ok := seen[name]; ok {
+			return nil, fmt.Errorf("group name repeated in evaluation order: %s", name)
+		}
+		seen[name] = struct{}{}
+	}
+
+	var ruleFiles []string
+	for _, rf := range f.RuleFiles {
+		if rf != "" && !filepath.IsAbs(rf) {
+			rf = filepath.Join(filepath.Dir(path), rf)
+		}
+		m, err := filepath.Glob(rf)
+		if err != nil {
+			return nil, err
+		}
+		if len(m) == 0 {
+			return nil, fmt.Errorf("no rule file matching %q pattern found", rf)
+		}
+		ruleFiles = append(ruleFiles, m...)
+	}
+	f.RuleFiles = ruleFiles
+
+	for i, tg := range f.Tests {
+		if tg.Interval == 0 {
+			f.Tests[i].Interval = f.EvaluationInterval
+		}
+		for _, tc := range tg.AlertRuleTests {
+			if tc.Alertname == "" {
+				return nil, fmt.Errorf("%s:%d: alert_rule_test is missing required alertname attribute", path, tc.Line)
+			}
+		}
+	}
+
+	return &f, nil
+}
@@ -0,0 +1,552 @@
+package ruletest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+	promParser "github.com/prometheus/prometheus/promql/parser"
+	promRules "github.com/prometheus/prometheus/rules"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+const (
+	ReporterName = "rule/tests"
+)
+
+// Run evaluates all rules from entries using input series from each test
+// group in f and returns reports for all failed tests.
+// Failed alert tests are reported on the alerting rules they are testing,
+// failed PromQL expression tests are reported on the recording rules
+// used in tested expressions, everything else is reported on the test file.
+func Run(f *File, entries []discovery.Entry) (reports []reporter.Report, err error) {
+	rules, err := newRuleEntries(f, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tg := range f.Tests {
+		r, err := runTestGroup(f, tg, rules)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, r...)
+	}
+	return reports, nil
+}
+
+// Untested returns reports for all alerting rules that are not tested by
+// any alert_rule_test in given files.
+func Untested(files []*File, entries []discovery.Entry) (reports []reporter.Report) {
+	tested := map[string]struct{}{}
+	for _, f := range files {
+		for _, tg := range f.Tests {
+			for _, tc := range tg.AlertRuleTests {
+				tested[tc.Alertname] = struct{}{}
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.State == discovery.Removed || entry.PathError != nil || entry.Rule.Error.Err != nil {
+			continue
+		}
+		if entry.Rule.AlertingRule == nil {
+			continue
+		}
+		if _, ok := tested[entry.Rule.AlertingRule.Alert.Value.Value]; ok {
+			continue
+		}
+		reports = append(reports, entryReport(entry, entry.Rule.AlertingRule.Alert.Lines(),
+			fmt.Sprintf("`%s` alerting rule doesn't have any unit tests, add an `alert_rule_test` for it to one of the unit test files",
+				entry.Rule.AlertingRule.Alert.Value.Value)))
+	}
+	return reports
+}
+
+func runTestGroup(f *File, tg TestGroup, entries []ruleEntry) (reports []reporter.Report, err error) {
+	var input strings.Builder
+	input.WriteString(fmt.Sprintf("load %s\n", tg.Interval))
+	for _, is := range tg.InputSeries {
+		input.WriteString(fmt.Sprintf("  %s %s\n", is.Series, is.Values))
+	}
+
+	t := &errorCollector{}
+	defer func() {
+		if r := recover(); r != nil {
+			if r != errFailNow {
+				panic(r)
+			}
+			err = t.err
+		}
+	}()
+
+	ll, err := promql.NewLazyLoader(t, input.String(), promql.LazyLoaderOpts{
+		EnableAtModifier:     true,
+		EnableNegativeOffset: true,
+	})
+	if ll != nil {
+		defer ll.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid input series: %w", f.Path, err)
+	}
+	ll.SubqueryInterval = time.Duration(f.EvaluationInterval)
+
+	externalURL, err := url.Parse(tg.ExternalURL)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid external_url: %w", f.Path, err)
+	}
+
+	query := promRules.EngineQueryFunc(ll.QueryEngine(), ll.Storage())
+	groups, rules := newRuleGroups(tg, entries, &promRules.ManagerOptions{
+		ExternalURL: externalURL,
+		QueryFunc:   query,
+		NotifyFunc:  func(context.Context, string, ...*promRules.Alert) {},
+		Context:     ll.Context(),
+		Appendable:  ll.Storage(),
+		Queryable:   ll.Storage(),
+		Logger:      log.NewNopLogger(),
+	})
+
+	alertTests := make([]AlertTestCase, len(tg.AlertRuleTests))
+	copy(alertTests, tg.AlertRuleTests)
+	sort.SliceStable(alertTests, func(i, j int) bool {
+		return alertTests[i].EvalTime < alertTests[j].EvalTime
+	})
+
+	evalInterval := time.Duration(f.EvaluationInterval)
+	mint := time.Unix(0, 0).UTC()
+	maxt := mint.Add(tg.maxEvalTime())
+
+	var curr int
+	for ts := mint; !ts.After(maxt); ts = ts.Add(evalInterval) {
+		var loadErr error
+		ll.WithSamplesTill(ts, func(err error) {
+			loadErr = err
+		})
+		if loadErr != nil {
+			return nil, fmt.Errorf("%s: failed to load input series: %w", f.Path, loadErr)
+		}
+
+		for _, g := range groups {
+			g.Eval(ll.Context(), ts)
+		}
+		for _, r := range rules {
+			if err := r.rule.LastError(); err != nil {
+				reports = append(reports, entryReport(r.entry, r.entry.Rule.Lines(),
+					fmt.Sprintf("rule evaluation failed at %s while running unit tests from %s: %s",
+						model.Duration(ts.Sub(mint)), f.Path, err)))
+			}
+		}
+		if len(reports) > 0 {
+			return reports, nil
+		}
+
+		for curr < len(alertTests) && time.Duration(alertTests[curr].EvalTime) < ts.Add(evalInterval).Sub(mint) {
+			reports = append(reports, checkAlerts(f, tg, alertTests[curr], rules)...)
+			curr++
+		}
+	}
+
+	for _, tc := range tg.PromQLExprTests {
+		reports = append(reports, checkExpr(ll.Context(), query, f, tg, tc, rules)...)
+	}
+
+	return reports, nil
+}
+
+func (tg TestGroup) maxEvalTime() time.Duration {
+	var maxd model.Duration
+	for _, tc := range tg.AlertRuleTests {
+		if tc.EvalTime > maxd {
+			maxd = tc.EvalTime
+		}
+	}
+	for _, tc := range tg.PromQLExprTests {
+		if tc.EvalTime > maxd {
+			maxd = tc.EvalTime
+		}
+	}
+	return time.Duration(maxd)
+}
+
+func (tg TestGroup) testName() string {
+	if tg.Name != "" {
+		return fmt.Sprintf(" (%s)", tg.Name)
+	}
+	return ""
+}
+
+func checkAlerts(f *File, tg TestGroup, tc AlertTestCase, rules []evaluatedRule) (reports []reporter.Report) {
+	var got, exp alertList
+	var tested []evaluatedRule
+	for _, r := range rules {
+		ar, ok := r.rule.(*promRules.AlertingRule)
+		if !ok || ar.Name() != tc.Alertname {
+			continue
+		}
+		tested = append(tested, r)
+		for _, a := range ar.ActiveAlerts() {
+			if a.State == promRules.StateFiring {
+				got = append(got, alertLabels{labels: a.Labels.Copy(), annotations: a.Annotations.Copy()})
+			}
+		}
+	}
+
+	for _, a := range tc.ExpAlerts {
+		lm := map[string]string{}
+		for k, v := range a.ExpLabels {
+			lm[k] = v
+		}
+		lm[labels.AlertName] = tc.Alertname
+		exp = append(exp, alertLabels{labels: labels.FromMap(lm), annotations: labels.FromMap(a.ExpAnnotations)})
+	}
+
+	sort.Sort(got)
+	sort.Sort(exp)
+	if got.String() == exp.String() {
+		return nil
+	}
+
+	text := fmt.Sprintf("`%s` alert unit test at %s:%d%s failed at %s, expected: %s, got: %s",
+		tc.Alertname, f.Path, tc.Line, tg.testName(), tc.EvalTime, exp, got)
+	if len(tested) == 0 {
+		return append(reports, fileReport(f.Path, tc.Line, text))
+	}
+	for _, r := range tested {
+		reports = append(reports, entryReport(r.entry, r.entry.Rule.Lines(), text))
+	}
+	return reports
+}
+
+func checkExpr(ctx context.Context, query promRules.QueryFunc, f *File, tg TestGroup, tc PromQLTestCase, rules []evaluatedRule) (reports []reporter.Report) {
+	report := func(text string) []reporter.Report {
+		var used []evaluatedRule
+		if expr, err := promParser.ParseExpr(tc.Expr); err == nil {
+			promParser.Inspect(expr, func(node promParser.Node, _ []promParser.Node) error {
+				if vs, ok := node.(*promParser.VectorSelector); ok {
+					for _, r := range rules {
+						if _, ok := r.rule.(*promRules.RecordingRule); ok && r.rule.Name() == vs.Name {
+							used = append(used, r)
+						}
+					}
+				}
+				return nil
+			})
+		}
+		if len(used) == 0 {
+			return []reporter.Report{fileReport(f.Path, tc.Line, text)}
+		}
+		reports := make([]reporter.Report, 0, len(used))
+		for _, r := range used {
+			reports = append(reports, entryReport(r.entry, r.entry.Rule.Lines(), text))
+		}
+		return reports
+	}
+
+	got, err := query(ctx, tc.Expr, time.Unix(0, 0).UTC().Add(time.Duration(tc.EvalTime)))
+	if err != nil {
+		return report(fmt.Sprintf("`%s` unit test at %s:%d%s failed at %s: %s",
+			tc.Expr, f.Path, tc.Line, tg.testName(), tc.EvalTime, err))
+	}
+
+	gotSamples := make(sampleList, 0, len(got))
+	for _, s := range got {
+		gotSamples = append(gotSamples, parsedSample{labels: s.Metric, value: s.F})
+	}
+
+	expSamples := make(sampleList, 0, len(tc.ExpSamples))
+	for _, s := range tc.ExpSamples {
+		lset, err := promParser.ParseMetric(s.Labels)
+		if err != nil {
+			return []reporter.Report{fileReport(f.Path, tc.Line,
+				fmt.Sprintf("`%s` unit test at %s:%d%s has invalid expected labels %q: %s",
+					tc.Expr, f.Path, tc.Line, tg.testName(), s.Labels, err))}
+		}
+		expSamples = append(expSamples, parsedSample{labels: lset, value: s.Value})
+	}
+
+	sort.Sort(gotSamples)
+	sort.Sort(expSamples)
+	if gotSamples.String() == expSamples.String() {
+		return nil
+	}
+
+	return report(fmt.Sprintf("`%s` unit test at %s:%d%s failed at %s, expected: %s, got: %s",
+		tc.Expr, f.Path, tc.Line, tg.testName(), tc.EvalTime, expSamples, gotSamples))
+}
+
+// ruleEntry is a rule to evaluate in unit tests.
+type ruleEntry struct {
+	entry        discovery.Entry
+	expr         promParser.Expr
+	holdDuration time.Duration
+	interval     time.Duration
+	limit        int
+}
+
+// evaluatedRule is a Prometheus rule created for a single test group
+// together with the entry it was created from.
+type evaluatedRule struct {
+	entry discovery.Entry
+	rule  promRules.Rule
+}
+
+func newRuleEntries(f *File, entries []discovery.Entry) (rules []ruleEntry, err error) {
+	order := map[string]int{}
+	for i, name := range f.GroupEvalOrder {
+		order[name] = i
+	}
+	groupOrder := func(r parser.Rule) int {
+		if r.Group != nil {
+			if i, ok := order[r.Group.GetName()]; ok {
+				return i
+			}
+		}
+		return len(order)
+	}
+
+	for _, entry := range entries {
+		if entry.State == discovery.Removed {
+			continue
+		}
+		if entry.PathError != nil {
+			return nil, entry.PathError
+		}
+		if entry.Rule.Error.Err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", entry.ReportedPath, entry.Rule.Error.Line, entry.Rule.Error.Err)
+		}
+
+		var expr parser.PromQLExpr
+		switch {
+		case entry.Rule.AlertingRule != nil:
+			expr = entry.Rule.AlertingRule.Expr
+		case entry.Rule.RecordingRule != nil:
+			expr = entry.Rule.RecordingRule.Expr
+		default:
+			continue
+		}
+		if expr.SyntaxError != nil {
+			return nil, fmt.Errorf("%s:%d: %w", entry.ReportedPath, expr.Lines()[0], expr.SyntaxError)
+		}
+
+		re := ruleEntry{entry: entry}
+		if re.expr, err = promParser.ParseExpr(expr.Value.Value); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", entry.ReportedPath, expr.Lines()[0], err)
+		}
+		if entry.Rule.AlertingRule != nil && entry.Rule.AlertingRule.For != nil {
+			d, err := model.ParseDuration(entry.Rule.AlertingRule.For.Value.Value)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", entry.ReportedPath, entry.Rule.AlertingRule.For.Lines()[0], err)
+			}
+			re.holdDuration = time.Duration(d)
+		}
+		if g := entry.Rule.Group; g != nil && g.Interval != nil {
+			d, err := model.ParseDuration(g.Interval.Value.Value)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", entry.ReportedPath, g.Interval.Lines()[0], err)
+			}
+			re.interval = time.Duration(d)
+		}
+		if g := entry.Rule.Group; g != nil && g.Limit != nil {
+			if re.limit, err = strconv.Atoi(g.Limit.Value.Value); err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", entry.ReportedPath, g.Limit.Lines()[0], err)
+			}
+		}
+		rules = append(rules, re)
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		return groupOrder(rules[i].entry.Rule) < groupOrder(rules[j].entry.Rule)
+	})
+	return rules, nil
+}
+
+// newRuleGroups creates Prometheus rule groups for a single test group,
+// the same way `promtool test rules` does it.
+// Groups are returned in the evaluation order, rules without a group are
+// placed in a group with an empty name.
+func newRuleGroups(tg TestGroup, entries []ruleEntry, opts *promRules.ManagerOptions) (groups []*promRules.Group, rules []evaluatedRule) {
+	type groupRules struct {
+		name, file string
+		interval   time.Duration
+		limit      int
+		rules      []promRules.Rule
+	}
+
+	externalLabels := labels.FromMap(tg.ExternalLabels)
+
+	var keys []string
+	byKey := map[string]*groupRules{}
+	for _, re := range entries {
+		var name string
+		if re.entry.Rule.Group != nil {
+			name = re.entry.Rule.Group.GetName()
+		}
+		key := promRules.GroupKey(re.entry.ReportedPath, name)
+		gr, ok := byKey[key]
+		if !ok {
+			gr = &groupRules{name: name, file: re.entry.ReportedPath, interval: time.Duration(tg.Interval), limit: re.limit}
+			if re.interval > 0 {
+				gr.interval = re.interval
+			}
+			byKey[key] = gr
+			keys = append(keys, key)
+		}
+
+		var rule promRules.Rule
+		if ar := re.entry.Rule.AlertingRule; ar != nil {
+			rule = promRules.NewAlertingRule(
+				ar.Alert.Value.Value,
+				re.expr,
+				re.holdDuration,
+				0,
+				mapLabels(ar.Labels),
+				mapLabels(ar.Annotations),
+				externalLabels,
+				tg.ExternalURL,
+				// Mark alerting rules as restored so the ALERTS series is written.
+				true,
+				opts.Logger,
+			)
+		} else {
+			rr := re.entry.Rule.RecordingRule
+			rule = promRules.NewRecordingRule(rr.Record.Value.Value, re.expr, mapLabels(rr.Labels))
+		}
+		gr.rules = append(gr.rules, rule)
+		rules = append(rules, evaluatedRule{entry: re.entry, rule: rule})
+	}
+
+	for _, key := range keys {
+		gr := byKey[key]
+		groups = append(groups, promRules.NewGroup(promRules.GroupOptions{
+			Name:     gr.name,
+			File:     gr.file,
+			Interval: gr.interval,
+			Limit:    gr.limit,
+			Rules:    gr.rules,
+			Opts:     opts,
+		}))
+	}
+	return groups, rules
+}
+
+func mapLabels(m *parser.YamlMap) labels.Labels {
+	if m == nil {
+		return labels.EmptyLabels()
+	}
+	lm := make(map[string]string, len(m.Items))
+	for _, item := range m.Items {
+		lm[item.Key.Value] = item.Value.Value
+	}
+	return labels.FromMap(lm)
+}
+
+type alertLabels struct {
+	labels      labels.Labels
+	annotations labels.Labels
+}
+
+type alertList []alertLabels
+
+func (al alertList) Len() int      { return len(al) }
+func (al alertList) Swap(i, j int) { al[i], al[j] = al[j], al[i] }
+func (al alertList) Less(i, j int) bool {
+	if diff := labels.Compare(al[i].labels, al[j].labels); diff != 0 {
+		return diff < 0
+	}
+	return labels.Compare(al[i].annotations, al[j].annotations) < 0
+}
+
+func (al alertList) String() string {
+	s := make([]string, 0, len(al))
+	for _, a := range al {
+		if a.annotations.IsEmpty() {
+			s = append(s, a.labels.String())
+		} else {
+			s = append(s, a.labels.String()+" with annotations "+a.annotations.String())
+		}
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+type parsedSample struct {
+	labels labels.Labels
+	value  float64
+}
+
+type sampleList []parsedSample
+
+func (sl sampleList) Len() int      { return len(sl) }
+func (sl sampleList) Swap(i, j int) { sl[i], sl[j] = sl[j], sl[i] }
+func (sl sampleList) Less(i, j int) bool {
+	return labels.Compare(sl[i].labels, sl[j].labels) < 0
+}
+
+func (sl sampleList) String() string {
+	s := make([]string, 0, len(sl))
+	for _, ps := range sl {
+		s = append(s, ps.labels.String()+" "+strconv.FormatFloat(ps.value, 'f', -1, 64))
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+func entryReport(entry discovery.Entry, lines []int, text string) reporter.Report {
+	return reporter.Report{
+		ReportedPath:  entry.ReportedPath,
+		SourcePath:    entry.SourcePath,
+		ModifiedLines: entry.ModifiedLines,
+		Rule:          entry.Rule,
+		Owner:         entry.Owner,
+		Problem: checks.Problem{
+			Lines:    lines,
+			Reporter: ReporterName,
+			Text:     text,
+			Severity: checks.Bug,
+		},
+	}
+}
+
+func fileReport(path string, line int, text string) reporter.Report {
+	return reporter.Report{
+		ReportedPath:  path,
+		SourcePath:    path,
+		ModifiedLines: []int{line},
+		Problem: checks.Problem{
+			Lines:    []int{line},
+			Reporter: ReporterName,
+			Text:     text,
+			Severity: checks.Bug,
+		},
+	}
+}
+
+var errFailNow = errors.New("unit test storage failure")
+
+// errorCollector implements testutil.T interface needed by promql.LazyLoader
+// so storage errors are returned instead of causing a panic.
+type errorCollector struct {
+	err error
+}
+
+func (ec *errorCollector) Errorf(format string, args ...interface{}) {
+	ec.err = fmt.Errorf(format, args...)
+}
+
+func (ec *errorCollector) FailNow() {
+	panic(errFailNow)
+}
@@ -0,0 +1,302 @@
+package ruletest_test
+
+import (
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/ruletest"
+)
+
+const testRules = `groups:
+- name: test
+  rules:
+  - record: job:up:sum
+    expr: sum(up) by(job)
+  - alert: JobDown
+    expr: job:up:sum == 0
+    for: 5m
+    labels:
+      severity: page
+    annotations:
+      summary: '{{ $labels.job }} is down'
+  - alert: InstanceDown
+    expr: up == 0
+`
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(path.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestLoad(t *testing.T) {
+	type testCaseT struct {
+		content string
+		err     string
+	}
+
+	testCases := []testCaseT{
+		{
+			content: "rule_files: [rules.yml]\n",
+		},
+		{
+			content: "rule_files: [missing.yml]\n",
+			err:     `no rule file matching "{dir}/missing.yml" pattern found`,
+		},
+		{
+			content: "rule_files: [rules.yml]\ngroup_eval_order: [a, b, a]\n",
+			err:     "group name repeated in evaluation order: a",
+		},
+		{
+			content: "rule_files: [rules.yml]\ntests:\n- alert_rule_test:\n  - eval_time: 5m\n",
+			err:     "{dir}/test.yml:4: alert_rule_test is missing required alertname attribute",
+		},
+		{
+			content: "rule_files: {}\n",
+			err:     "failed to parse unit test file \"{dir}/test.yml\": yaml: unmarshal errors:\n  line 1: cannot unmarshal !!map into []string",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.content, func(t *testing.T) {
+			dir := writeFiles(t, map[string]string{"rules.yml": testRules, "test.yml": tc.content})
+			f, err := ruletest.Load(path.Join(dir, "test.yml"))
+			if tc.err != "" {
+				require.EqualError(t, err, replaceDir(tc.err, dir))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []string{path.Join(dir, "rules.yml")}, f.RuleFiles)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	type testCaseT struct {
+		description string
+		content     string
+		reports     []string
+		err         string
+	}
+
+	testCases := []testCaseT{
+		{
+			description: "passing tests",
+			content: `rule_files: [rules.yml]
+tests:
+- interval: 1m
+  input_series:
+  - series: 'up{job="a", instance="1"}'
+    values: '1 0x10'
+  - series: 'up{job="b", instance="1"}'
+    values: '1x10'
+  alert_rule_test:
+  - eval_time: 3m
+    alertname: JobDown
+  - eval_time: 6m
+    alertname: JobDown
+    exp_alerts:
+    - exp_labels:
+        job: a
+        severity: page
+      exp_annotations:
+        summary: a is down
+  promql_expr_test:
+  - expr: job:up:sum
+    eval_time: 2m
+    exp_samples:
+    - labels: 'job:up:sum{job="a"}'
+      value: 0
+    - labels: 'job:up:sum{job="b"}'
+      value: 1
+`,
+		},
+		{
+			description: "failing tests",
+			content: `rule_files: [rules.yml]
+tests:
+- interval: 1m
+  name: failing
+  input_series:
+  - series: 'up{job="a", instance="1"}'
+    values: '1 0x10'
+  alert_rule_test:
+  - eval_time: 5m
+    alertname: JobDown
+    exp_alerts:
+    - exp_labels:
+        job: a
+        severity: page
+  - eval_time: 5m
+    alertname: Missing
+  - eval_time: 5m
+    alertname: Other
+    exp_alerts:
+    - exp_labels:
+        job: a
+  promql_expr_test:
+  - expr: job:up:sum
+    eval_time: 2m
+    exp_samples:
+    - labels: 'job:up:sum{job="b"}'
+      value: 1
+  - expr: sum(up)
+    eval_time: 2m
+  - expr: sum(up
+    eval_time: 2m
+`,
+			reports: []string{
+				"{dir}/rules.yml:6-12: `JobDown` alert unit test at {dir}/test.yml:9 (failing) failed at 5m, expected: [{alertname=\"JobDown\", job=\"a\", severity=\"page\"}], got: []",
+				"{dir}/test.yml:17: `Other` alert unit test at {dir}/test.yml:17 (failing) failed at 5m, expected: [{alertname=\"Other\", job=\"a\"}], got: []",
+				"{dir}/rules.yml:4-5: `job:up:sum` unit test at {dir}/test.yml:23 (failing) failed at 2m, expected: [{__name__=\"job:up:sum\", job=\"b\"} 1], got: [{__name__=\"job:up:sum\", job=\"a\"} 0]",
+				"{dir}/test.yml:28: `sum(up)` unit test at {dir}/test.yml:28 (failing) failed at 2m, expected: [], got: [{} 0]",
+				"{dir}/test.yml:30: `sum(up` unit test at {dir}/test.yml:30 (failing) failed at 2m: 1:7: parse error: unclosed left parenthesis",
+			},
+		},
+		{
+			description: "ALERTS and ALERTS_FOR_STATE series",
+			content: `rule_files: [rules.yml]
+tests:
+- interval: 1m
+  input_series:
+  - series: 'up{job="a", instance="1"}'
+    values: '0x10'
+  promql_expr_test:
+  - expr: ALERTS
+    eval_time: 2m
+    exp_samples:
+    - labels: 'ALERTS{alertname="JobDown", alertstate="pending", job="a", severity="page"}'
+      value: 1
+    - labels: 'ALERTS{alertname="InstanceDown", alertstate="firing", instance="1", job="a"}'
+      value: 1
+  - expr: ALERTS_FOR_STATE
+    eval_time: 2m
+    exp_samples:
+    - labels: 'ALERTS_FOR_STATE{alertname="JobDown", job="a", severity="page"}'
+      value: 0
+    - labels: 'ALERTS_FOR_STATE{alertname="InstanceDown", instance="1", job="a"}'
+      value: 0
+`,
+		},
+		{
+			description: "group order",
+			content: `rule_files: [rules.yml, other.yml]
+group_eval_order: [other, test]
+tests:
+- interval: 1m
+  input_series:
+  - series: 'up{job="a", instance="1"}'
+    values: '1x10'
+  promql_expr_test:
+  - expr: job:up:sum
+    eval_time: 0m
+    exp_samples:
+    - labels: 'job:up:sum{job="a"}'
+      value: 1
+  - expr: job:up:count
+    eval_time: 0m
+`,
+		},
+		{
+			description: "evaluation error",
+			content: `rule_files: [bad.yml]
+tests:
+- interval: 1m
+  input_series:
+  - series: 'up{job="a", instance="1"}'
+    values: '1x10'
+  - series: 'up{job="a", instance="2"}'
+    values: '1x10'
+  alert_rule_test:
+  - eval_time: 5m
+    alertname: Bad
+`,
+			reports: []string{
+				"{dir}/bad.yml:4-7: rule evaluation failed at 0s while running unit tests from {dir}/test.yml: vector contains metrics with the same labelset after applying alert labels",
+			},
+		},
+		{
+			description: "invalid input series",
+			content: `rule_files: [rules.yml]
+tests:
+- interval: 1m
+  input_series:
+  - series: 'up{job="a"'
+    values: '1x10'
+`,
+			err: "{dir}/test.yml: invalid input series: 1:12: parse error: unexpected character inside braces: '1'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := writeFiles(t, map[string]string{
+				"rules.yml": testRules,
+				"other.yml": "groups:\n- name: other\n  rules:\n  - record: job:up:count\n    expr: count(job:up:sum)\n",
+				"bad.yml":   "groups:\n- name: bad\n  rules:\n  - alert: Bad\n    expr: up\n    labels:\n      instance: x\n",
+				"test.yml":  tc.content,
+			})
+			f, err := ruletest.Load(path.Join(dir, "test.yml"))
+			require.NoError(t, err)
+
+			entries, err := discovery.NewGlobFinder(f.RuleFiles, nil).Find()
+			require.NoError(t, err)
+
+			reports, err := ruletest.Run(f, entries)
+			if tc.err != "" {
+				require.EqualError(t, err, replaceDir(tc.err, dir))
+				return
+			}
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(reports))
+			for _, r := range reports {
+				require.Equal(t, ruletest.ReporterName, r.Problem.Reporter)
+				first, last := r.Problem.LineRange()
+				lines := itoa(first)
+				if last != first {
+					lines += "-" + itoa(last)
+				}
+				got = append(got, r.ReportedPath+":"+lines+": "+r.Problem.Text)
+			}
+			exp := make([]string, 0, len(tc.reports))
+			for _, r := range tc.reports {
+				exp = append(exp, replaceDir(r, dir))
+			}
+			require.Equal(t, exp, got)
+		})
+	}
+}
+
+func TestUntested(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"rules.yml": testRules,
+		"test.yml":  "rule_files: [rules.yml]\ntests:\n- alert_rule_test:\n  - eval_time: 5m\n    alertname: JobDown\n",
+	})
+	f, err := ruletest.Load(path.Join(dir, "test.yml"))
+	require.NoError(t, err)
+
+	entries, err := discovery.NewGlobFinder(f.RuleFiles, nil).Find()
+	require.NoError(t, err)
+
+	reports := ruletest.Untested([]*ruletest.File{f}, entries)
+	require.Len(t, reports, 1)
+	require.Equal(t, []int{13}, reports[0].Problem.Lines)
+	require.Equal(t, "`InstanceDown` alerting rule doesn't have any unit tests, add an `alert_rule_test` for it to one of the unit test files", reports[0].Problem.Text)
+}
+
+func replaceDir(s, dir string) string {
+	return strings.ReplaceAll(s, "{dir}", dir)
+}
+
+func itoa(i int) string {
+	return strconv.Itoa(i)
+}